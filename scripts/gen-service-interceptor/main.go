@@ -0,0 +1,197 @@
+// Command gen-service-interceptor generates the ServiceAPI implementation used by service.Intercept. It is run with
+// go generate in the service package whenever ServiceAPI changes.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const output = "intercept_gen.go"
+
+type method struct {
+	name string
+	typ  *ast.FuncType
+	file *ast.File
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["service"]
+	if !ok {
+		log.Fatal("service package not found")
+	}
+
+	interfaces := make(map[string]*ast.InterfaceType)
+	files := make(map[string]*ast.File)
+	for _, f := range pkg.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if it, ok := spec.Type.(*ast.InterfaceType); ok {
+					interfaces[spec.Name.Name] = it
+					files[spec.Name.Name] = f
+				}
+			}
+			return true
+		})
+	}
+
+	var methods []method
+	seen := make(map[string]bool)
+	var collect func(name string)
+	collect = func(name string) {
+		it, ok := interfaces[name]
+		if !ok {
+			log.Fatalf("interface %s not found", name)
+		}
+		for _, field := range it.Methods.List {
+			switch t := field.Type.(type) {
+			case *ast.Ident:
+				collect(t.Name)
+			case *ast.FuncType:
+				for _, n := range field.Names {
+					if !seen[n.Name] {
+						seen[n.Name] = true
+						methods = append(methods, method{name: n.Name, typ: t, file: files[name]})
+					}
+				}
+			default:
+				log.Fatalf("unsupported interface element in %s", name)
+			}
+		}
+	}
+	collect("ServiceAPI")
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+	imports := map[string]string{"context": "context"}
+	var body bytes.Buffer
+	for _, m := range methods {
+		writeMethod(&body, fset, m, imports)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen-service-interceptor. DO NOT EDIT.\n\npackage service\n\nimport (\n")
+	paths := make([]string, 0, len(imports))
+	for _, path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if stdI, stdJ := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], "."); stdI != stdJ {
+			return stdI
+		}
+		return paths[i] < paths[j]
+	})
+	for i, path := range paths {
+		// Standard library imports come first, separated from the others by an empty line
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(path, ".") {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	out.WriteString(")\n\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, out.Bytes())
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeMethod(w *bytes.Buffer, fset *token.FileSet, m method, imports map[string]string) {
+	expr := func(e ast.Expr) string {
+		ast.Inspect(e, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if pkg, ok := sel.X.(*ast.Ident); ok {
+					imports[pkg.Name] = importPath(m.file, pkg.Name)
+				}
+			}
+			return true
+		})
+		var b bytes.Buffer
+		if err := printer.Fprint(&b, fset, e); err != nil {
+			log.Fatal(err)
+		}
+		return b.String()
+	}
+
+	var params, args []string
+	for _, field := range m.typ.Params.List {
+		typ := expr(field.Type)
+		n := max(len(field.Names), 1)
+		for i := 0; i < n; i++ {
+			name := "ctx"
+			switch len(params) {
+			case 0:
+			case 1:
+				name = "r"
+			default:
+				name = "p" + strconv.Itoa(len(params))
+			}
+			params = append(params, name+" "+typ)
+			arg := name
+			if strings.HasPrefix(typ, "...") {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+	}
+	if len(params) == 0 || !strings.HasSuffix(params[0], " context.Context") {
+		log.Fatalf("method %s does not take a context", m.name)
+	}
+
+	var results []string
+	if m.typ.Results != nil {
+		for _, field := range m.typ.Results.List {
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				results = append(results, expr(field.Type))
+			}
+		}
+	}
+	if len(results) == 0 || results[len(results)-1] != "error" || len(results) > 2 {
+		log.Fatalf("method %s must return an error and at most one other result", m.name)
+	}
+
+	request := "nil"
+	if len(args) > 1 {
+		request = strings.TrimSuffix(args[1], "...")
+	}
+	fmt.Fprintf(w, "func (s *interceptedService) %s(%s) (%s) {\n", m.name, strings.Join(params, ", "), strings.Join(results, ", "))
+	if len(results) == 1 {
+		fmt.Fprintf(w, "\treturn s.fn(ctx, Call{Method: %q, Request: %s}, func(ctx context.Context) error {\n", m.name, request)
+		fmt.Fprintf(w, "\t\treturn s.next.%s(%s)\n\t})\n}\n\n", m.name, strings.Join(args, ", "))
+		return
+	}
+	fmt.Fprintf(w, "\tvar res %s\n", results[0])
+	fmt.Fprintf(w, "\terr := s.fn(ctx, Call{Method: %q, Request: %s, Result: &res}, func(ctx context.Context) (err error) {\n", m.name, request)
+	fmt.Fprintf(w, "\t\tres, err = s.next.%s(%s)\n\t\treturn err\n\t})\n\treturn res, err\n}\n\n", m.name, strings.Join(args, ", "))
+}
+
+func importPath(f *ast.File, name string) string {
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil && spec.Name.Name == name || spec.Name == nil && path[strings.LastIndex(path, "/")+1:] == name {
+			return path
+		}
+	}
+	log.Fatalf("import of %s not found in %s", name, f.Name.Name)
+	return ""
+}
@@ -0,0 +1,57 @@
+package upcloud
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// benchmarkUnmarshal decodes the API response corpus file into a new value returned by newValue.
+// Run with `go test -run=^$ -bench=Unmarshal -benchmem ./upcloud` to compare allocations.
+func benchmarkUnmarshal[T any](b *testing.B, corpusFile string) {
+	b.Helper()
+
+	data, err := os.ReadFile(filepath.Join("upcloudtest", "corpus", "1.3", corpusFile))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalServerDetails(b *testing.B) {
+	benchmarkUnmarshal[ServerDetails](b, "server_details.json")
+}
+
+func BenchmarkUnmarshalServers(b *testing.B) {
+	benchmarkUnmarshal[Servers](b, "servers.json")
+}
+
+func BenchmarkUnmarshalStorageDetails(b *testing.B) {
+	benchmarkUnmarshal[StorageDetails](b, "storage_details.json")
+}
+
+func BenchmarkUnmarshalStorages(b *testing.B) {
+	benchmarkUnmarshal[Storages](b, "storages.json")
+}
+
+func BenchmarkUnmarshalIPAddresses(b *testing.B) {
+	benchmarkUnmarshal[IPAddresses](b, "ip_addresses.json")
+}
+
+func BenchmarkUnmarshalNetworks(b *testing.B) {
+	benchmarkUnmarshal[Networks](b, "networks.json")
+}
+
+func BenchmarkUnmarshalNetworkDetails(b *testing.B) {
+	benchmarkUnmarshal[Network](b, "network_details.json")
+}
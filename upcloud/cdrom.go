@@ -0,0 +1,95 @@
+package upcloud
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CDROM is a public CD-ROM storage with the name and version parsed from its title, e.g. "SystemRescueCd" and "10.01"
+// from "SystemRescueCd 10.01".
+type CDROM struct {
+	Storage
+
+	Name    string
+	Version string
+}
+
+// NewCDROM parses the name and version of the CD-ROM storage from its title. Version is the first word of the title
+// starting with a digit and name the words before it. If the title has no version, name is the whole title.
+func NewCDROM(storage Storage) CDROM {
+	c := CDROM{Storage: storage, Name: strings.TrimSpace(storage.Title)}
+	words := strings.Fields(storage.Title)
+	for i, word := range words {
+		if i > 0 && unicode.IsDigit(rune(word[0])) {
+			c.Name = strings.Join(words[:i], " ")
+			c.Version = word
+			break
+		}
+	}
+	return c
+}
+
+// CDROMs is a list of public CD-ROM storages
+type CDROMs []CDROM
+
+// ByName returns the CD-ROMs with the given name. Name is matched case-insensitively.
+func (c CDROMs) ByName(name string) CDROMs {
+	matches := make(CDROMs, 0)
+	for _, cdrom := range c {
+		if strings.EqualFold(cdrom.Name, name) {
+			matches = append(matches, cdrom)
+		}
+	}
+	return matches
+}
+
+// Latest returns the CD-ROM with the given name and the highest version, or nil if there is no such CD-ROM. Versions
+// are compared by their numeric dot separated components, e.g. "10.01" is newer than "9.06".
+func (c CDROMs) Latest(name string) *CDROM {
+	var latest *CDROM
+	for _, cdrom := range c.ByName(name) {
+		if latest == nil || compareVersions(cdrom.Version, latest.Version) > 0 {
+			cdrom := cdrom
+			latest = &cdrom
+		}
+	}
+	return latest
+}
+
+func compareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	var parts []int
+	for _, s := range strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' }) {
+		// Ignore non-numeric suffixes, e.g. "04lts"
+		end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end == 0 {
+			break
+		}
+		if end > 0 {
+			s = s[:end]
+		}
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,39 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCDROM(t *testing.T) {
+	for title, want := range map[string][2]string{
+		"SystemRescueCd 10.01":                {"SystemRescueCd", "10.01"},
+		"Ubuntu Server 22.04 LTS":             {"Ubuntu Server", "22.04"},
+		"Debian GNU/Linux 12 Installation CD": {"Debian GNU/Linux", "12"},
+		"Rescue CD":                           {"Rescue CD", ""},
+		"2023 tools":                          {"2023 tools", ""},
+	} {
+		c := NewCDROM(Storage{Title: title})
+		assert.Equal(t, want[0], c.Name, title)
+		assert.Equal(t, want[1], c.Version, title)
+	}
+}
+
+func TestCDROMsLatest(t *testing.T) {
+	var cdroms CDROMs
+	for _, title := range []string{"SystemRescueCd 9.06", "SystemRescueCd 10.01", "SystemRescueCd 10", "Ubuntu Server 24.04"} {
+		cdroms = append(cdroms, NewCDROM(Storage{Title: title, UUID: title}))
+	}
+
+	assert.Len(t, cdroms.ByName("systemrescuecd"), 3)
+	latest := cdroms.Latest("SystemRescueCd")
+	require.NotNil(t, latest)
+	assert.Equal(t, "SystemRescueCd 10.01", latest.UUID)
+	assert.Nil(t, cdroms.Latest("Windows"))
+
+	assert.Equal(t, 0, compareVersions("10", "10.0"))
+	assert.Equal(t, 1, compareVersions("22.04-1", "22.04"))
+	assert.Equal(t, -1, compareVersions("", "1"))
+}
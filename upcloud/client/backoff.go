@@ -0,0 +1,80 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy determines the delay before the next attempt of a retried request or a polling operation.
+type BackoffStrategy interface {
+	// Backoff returns the delay before the given attempt. Attempts are counted from zero and previous is the delay
+	// returned for the previous attempt, or zero for the first attempt.
+	Backoff(attempt int, previous time.Duration) time.Duration
+}
+
+// BackoffFunc is an adapter for using ordinary functions as backoff strategies.
+type BackoffFunc func(attempt int, previous time.Duration) time.Duration
+
+// Backoff calls f(attempt, previous).
+func (f BackoffFunc) Backoff(attempt int, previous time.Duration) time.Duration {
+	return f(attempt, previous)
+}
+
+// ConstantBackoff waits the same interval before each attempt.
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Backoff returns the constant interval.
+func (b ConstantBackoff) Backoff(int, time.Duration) time.Duration {
+	return b.Interval
+}
+
+// maxBackoffShift is the largest exponent of ExponentialBackoff, beyond which any positive base overflows
+const maxBackoffShift = 62
+
+// ExponentialBackoff doubles the delay after each attempt starting from Base. Delays are capped to Max, if set.
+type ExponentialBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Backoff returns Base * 2^attempt capped to Max. The delay saturates at the largest time.Duration instead of
+// overflowing at large attempt counts.
+func (b ExponentialBackoff) Backoff(attempt int, _ time.Duration) time.Duration {
+	if b.Base <= 0 {
+		return 0
+	}
+	shift := min(max(attempt, 0), maxBackoffShift)
+	if b.Base > math.MaxInt64>>shift {
+		return capBackoff(math.MaxInt64, b.Max)
+	}
+	return capBackoff(b.Base<<shift, b.Max)
+}
+
+// DecorrelatedJitterBackoff picks a random delay between Base and three times the previous delay. Delays are capped
+// to Max, if set. Randomizing the delays spreads the retries of concurrent clients over time.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Backoff returns a random delay between Base and previous * 3 capped to Max.
+func (b DecorrelatedJitterBackoff) Backoff(_ int, previous time.Duration) time.Duration {
+	if b.Base <= 0 {
+		return 0
+	}
+	upper := previous * 3
+	if upper <= b.Base {
+		return capBackoff(b.Base, b.Max)
+	}
+	return capBackoff(b.Base+time.Duration(rand.Int63n(int64(upper-b.Base))), b.Max) //nolint:gosec // jitter does not need a cryptographically secure random number
+}
+
+func capBackoff(delay, max time.Duration) time.Duration {
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := ConstantBackoff{Interval: time.Second}
+	assert.Equal(t, time.Second, b.Backoff(0, 0))
+	assert.Equal(t, time.Second, b.Backoff(10, time.Second))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := ExponentialBackoff{Base: time.Second, Max: 10 * time.Second}
+	assert.Equal(t, time.Second, b.Backoff(0, 0))
+	assert.Equal(t, 2*time.Second, b.Backoff(1, 0))
+	assert.Equal(t, 8*time.Second, b.Backoff(3, 0))
+	assert.Equal(t, 10*time.Second, b.Backoff(4, 0))
+	assert.Equal(t, 10*time.Second, b.Backoff(1000, 0))
+
+	unlimited := ExponentialBackoff{Base: time.Second}
+	assert.Positive(t, unlimited.Backoff(1000, 0))
+
+	// The delay saturates instead of overflowing
+	for _, attempt := range []int{34, 62, 63, 64, 1000, math.MaxInt} {
+		assert.Equal(t, time.Duration(math.MaxInt64), unlimited.Backoff(attempt, 0), attempt)
+		assert.Equal(t, 10*time.Second, b.Backoff(attempt, 0), attempt)
+	}
+	assert.Equal(t, time.Duration(1)<<62, ExponentialBackoff{Base: 1}.Backoff(62, 0))
+	assert.Equal(t, time.Duration(math.MaxInt64), ExponentialBackoff{Base: 3}.Backoff(62, 0))
+	assert.Equal(t, time.Duration(0), ExponentialBackoff{}.Backoff(10, 0))
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := DecorrelatedJitterBackoff{Base: time.Second, Max: 20 * time.Second}
+	assert.Equal(t, time.Second, b.Backoff(0, 0))
+
+	var previous time.Duration
+	for i := 0; i < 100; i++ {
+		delay := b.Backoff(i, previous)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 20*time.Second)
+		if previous > 0 {
+			assert.LessOrEqual(t, delay, max(previous*3, time.Second))
+		}
+		previous = delay
+	}
+}
+
+func TestBackoffFunc(t *testing.T) {
+	t.Parallel()
+
+	var b BackoffStrategy = BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		return time.Duration(attempt) * time.Millisecond
+	})
+	assert.Equal(t, 3*time.Millisecond, b.Backoff(3, 0))
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker of the client is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests with ErrCircuitOpen until the cool-down period has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through. The circuit closes if the probe succeeds and opens again if
+	// it fails.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrCircuitOpen while the breaker is open, instead of sending
+// requests to an API that is consistently failing. The same breaker can be shared by multiple clients.
+func WithCircuitBreaker(breaker *CircuitBreaker) ConfigFn {
+	return func(c *config) {
+		c.circuitBreaker = breaker
+	}
+}
+
+// CircuitBreaker opens after threshold consecutive request attempts have failed with a server error or without a
+// response, e.g. because of a network error. Client errors, such as 404 Not Found, and canceled requests do not count
+// as failures. Once the cool-down period has passed, the breaker lets a probe request through to test whether the API
+// has recovered.
+type CircuitBreaker struct {
+	threshold int
+	coolDown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a closed circuit breaker that opens after threshold consecutive failures and stays open
+// for the cool-down period
+func NewCircuitBreaker(threshold int, coolDown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		coolDown:  coolDown,
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.coolDown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow returns ErrCircuitOpen if the request must not be sent
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.coolDown {
+		b.state = CircuitHalfOpen
+	}
+	switch {
+	case b.state == CircuitOpen, b.state == CircuitHalfOpen && b.probing:
+		return ErrCircuitOpen
+	case b.state == CircuitHalfOpen:
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request that was let through
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	switch {
+	case isCircuitFailure(ctx, err):
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+			b.state = CircuitOpen
+			b.openedAt = b.now()
+		}
+	case err == nil || errorStatusCode(err) != 0:
+		// The API responded, so it is available
+		b.state = CircuitClosed
+		b.failures = 0
+	}
+}
+
+// isCircuitFailure reports whether err indicates that the API is failing
+func isCircuitFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrResponseTooLarge) {
+		return false
+	}
+	status := errorStatusCode(err)
+	return status == 0 || status >= 500
+}
+
+// recordCircuit records the outcome of request r in the circuit breaker of the client
+func (c *Client) recordCircuit(r *http.Request, err error) {
+	if c.config.circuitBreaker != nil {
+		c.config.circuitBreaker.record(r.Context(), err)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+	var calls atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+	c := New("", "", WithBaseURL(srv.URL), WithCircuitBreaker(breaker), WithRetry(5, ConstantBackoff{Interval: time.Millisecond}))
+
+	// The retries stop once the breaker opens
+	_, err := c.Get(context.Background(), "/server")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	_, err = c.Get(context.Background(), "/server")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// A failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	_, err = c.Get(ContextWithRetry(context.Background(), false), "/server")
+	var clientErr *Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusServiceUnavailable, clientErr.ErrorCode)
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.Equal(t, int32(4), calls.Load())
+
+	// A successful probe closes the breaker. Client errors do not count as failures.
+	now = now.Add(time.Minute)
+	status.Store(http.StatusNotFound)
+	_, err = c.Get(context.Background(), "/server")
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusNotFound, clientErr.ErrorCode)
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_singleProbe(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Second)
+	breaker.now = func() time.Time { return now }
+	require.NoError(t, breaker.allow())
+	breaker.record(context.Background(), &Error{ErrorCode: http.StatusBadGateway})
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen)
+
+	now = now.Add(time.Second)
+	require.NoError(t, breaker.allow())
+	assert.ErrorIs(t, breaker.allow(), ErrCircuitOpen, "only one probe at a time")
+
+	// A canceled probe neither opens nor closes the breaker
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	breaker.record(ctx, context.Canceled)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	require.NoError(t, breaker.allow())
+	breaker.record(context.Background(), nil)
+	assert.Equal(t, CircuitClosed, breaker.State())
+	assert.Equal(t, "closed", breaker.State().String())
+}
@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheInvalidations lists resource paths whose cached responses are affected by mutations of other resources.
+// For example, attaching a storage to a server changes both the server and the storage details.
+var defaultCacheInvalidations = map[string][]string{
+	"/server":     {"/storage", "/ip_address", "/server-group"},
+	"/storage":    {"/server"},
+	"/ip_address": {"/server"},
+	"/tag":        {"/server"},
+	"/network":    {"/server", "/router"},
+	"/router":     {"/network"},
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+type cacheConfig struct {
+	defaultTTL    time.Duration
+	ttls          map[string]time.Duration
+	invalidations map[string][]string
+}
+
+type CacheConfigFn func(c *cacheConfig)
+
+// WithCacheTTL sets the TTL of GET responses for paths starting with pathPrefix, e.g. "/zone". The longest matching
+// prefix is used. Zero TTL disables caching for the matching paths.
+func WithCacheTTL(pathPrefix string, ttl time.Duration) CacheConfigFn {
+	return func(c *cacheConfig) {
+		c.ttls[pathPrefix] = ttl
+	}
+}
+
+// WithCacheInvalidation configures mutations of resources under pathPrefix to also invalidate cached responses of
+// the specified resource paths, e.g. WithCacheInvalidation("/server", "/storage").
+func WithCacheInvalidation(pathPrefix string, invalidates ...string) CacheConfigFn {
+	return func(c *cacheConfig) {
+		c.invalidations[pathPrefix] = append(c.invalidations[pathPrefix], invalidates...)
+	}
+}
+
+// CachingClient is a client decorator that caches GET responses. Successful POST, PUT, PATCH and DELETE requests
+// invalidate cached responses of the mutated resource type (e.g. creating a server invalidates the servers list) as
+// well as the related resource types. It implements the same methods as Client and can be passed to service.New.
+type CachingClient struct {
+	*Client
+
+	config  cacheConfig
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// generation is incremented on every invalidation. Responses of GET requests that were in flight during an
+	// invalidation may be stale and are not cached.
+	generation uint64
+}
+
+// NewCachingClient returns a caching decorator for c. GET responses are cached for defaultTTL unless overridden for the
+// path with WithCacheTTL.
+func NewCachingClient(c *Client, defaultTTL time.Duration, fns ...CacheConfigFn) *CachingClient {
+	config := cacheConfig{
+		defaultTTL:    defaultTTL,
+		ttls:          make(map[string]time.Duration),
+		invalidations: make(map[string][]string),
+	}
+	for k, v := range defaultCacheInvalidations {
+		config.invalidations[k] = append([]string(nil), v...)
+	}
+	for _, fn := range fns {
+		fn(&config)
+	}
+	return &CachingClient{
+		Client:  c,
+		config:  config,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// Get returns the cached response body for the path, or performs a GET request and caches the result.
+func (c *CachingClient) Get(ctx context.Context, path string) ([]byte, error) {
+	body, generation, ok := c.lookup(path)
+	if ok {
+		return body, nil
+	}
+
+	body, err := c.Client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := c.ttl(path); ttl > 0 {
+		c.mu.Lock()
+		if c.generation == generation {
+			c.entries[path] = cacheEntry{body: body, expires: time.Now().Add(ttl)}
+		}
+		c.mu.Unlock()
+	}
+	return copyBytes(body), nil
+}
+
+// Post performs a POST request and invalidates the related cached responses.
+func (c *CachingClient) Post(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.mutate(path)(c.Client.Post(ctx, path, body))
+}
+
+// Put performs a PUT request and invalidates the related cached responses.
+func (c *CachingClient) Put(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.mutate(path)(c.Client.Put(ctx, path, body))
+}
+
+// Patch performs a PATCH request and invalidates the related cached responses.
+func (c *CachingClient) Patch(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.mutate(path)(c.Client.Patch(ctx, path, body))
+}
+
+// Delete performs a DELETE request and invalidates the related cached responses.
+func (c *CachingClient) Delete(ctx context.Context, path string) ([]byte, error) {
+	return c.mutate(path)(c.Client.Delete(ctx, path))
+}
+
+// Do performs the HTTP request without caching. Mutating requests to the API invalidate the related cached responses.
+func (c *CachingClient) Do(r *http.Request) ([]byte, error) {
+	if r.Method == http.MethodGet || !strings.HasPrefix(r.URL.String(), c.getBaseURL()) {
+		return c.Client.Do(r)
+	}
+	path := strings.TrimPrefix(r.URL.String(), c.getBaseURL())
+	return c.mutate(path)(c.Client.Do(r))
+}
+
+// Invalidate removes cached responses of paths starting with pathPrefix.
+func (c *CachingClient) Invalidate(pathPrefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for path := range c.entries {
+		if strings.HasPrefix(path, pathPrefix) {
+			delete(c.entries, path)
+		}
+	}
+}
+
+// InvalidateAll removes all cached responses.
+func (c *CachingClient) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]cacheEntry)
+}
+
+// lookup returns the cached response body for the path and the current cache generation
+func (c *CachingClient) lookup(path string) ([]byte, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[path]
+	if !ok {
+		return nil, c.generation, false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, path)
+		return nil, c.generation, false
+	}
+	return copyBytes(e.body), c.generation, true
+}
+
+func (c *CachingClient) ttl(path string) time.Duration {
+	ttl := c.config.defaultTTL
+	var longest int
+	for prefix, v := range c.config.ttls {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			ttl = v
+			longest = len(prefix)
+		}
+	}
+	return ttl
+}
+
+// mutate returns a function that invalidates the cached responses related to path if the request succeeded.
+func (c *CachingClient) mutate(path string) func([]byte, error) ([]byte, error) {
+	return func(body []byte, err error) ([]byte, error) {
+		if err != nil {
+			return body, err
+		}
+		root := resourceRoot(path)
+		c.Invalidate(root)
+		for _, related := range c.config.invalidations[root] {
+			c.Invalidate(related)
+		}
+		return body, nil
+	}
+}
+
+// InvalidatedResources returns the resource paths whose cached responses are affected by a mutation of path with the
+// default cache invalidations, e.g. "/server", "/storage", "/ip_address" and "/server-group" for "/server/uuid/start".
+func InvalidatedResources(path string) []string {
+	root := resourceRoot(path)
+	return append([]string{root}, defaultCacheInvalidations[root]...)
+}
+
+// resourceRoot returns the first segment of the path, e.g. "/server" for "/server/uuid/start".
+func resourceRoot(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	if i := strings.Index(path[min(1, len(path)):], "/"); i >= 0 {
+		return path[:i+1]
+	}
+	return path
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
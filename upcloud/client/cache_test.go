@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRoot(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/server", resourceRoot("/server"))
+	assert.Equal(t, "/server", resourceRoot("/server/uuid/start"))
+	assert.Equal(t, "/server", resourceRoot("/server?label=a"))
+	assert.Equal(t, "/storage", resourceRoot("/storage/uuid"))
+	assert.Equal(t, "", resourceRoot(""))
+	assert.Equal(t, []string{"/tag", "/server"}, InvalidatedResources("/tag/prod"))
+}
+
+func TestCachingClient(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			n := atomic.AddInt32(&gets, 1)
+			fmt.Fprintf(w, "%s %d", r.URL.Path, n)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+
+	c := NewCachingClient(New("", "", WithBaseURL(srv.URL)), time.Minute, WithCacheTTL("/zone", 0))
+	ctx := context.Background()
+
+	get := func(path string) string {
+		body, err := c.Get(ctx, path)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	servers := get("/server")
+	assert.Equal(t, servers, get("/server"))
+	storage := get("/storage/uuid")
+	assert.Equal(t, storage, get("/storage/uuid"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets))
+
+	// Zone listing is not cached
+	assert.NotEqual(t, get("/zone"), get("/zone"))
+
+	// Creating a server invalidates servers and related storages
+	_, err := c.Post(ctx, "/server", []byte("{}"))
+	require.NoError(t, err)
+	assert.NotEqual(t, servers, get("/server"))
+	assert.NotEqual(t, storage, get("/storage/uuid"))
+
+	tags := get("/tag")
+	c.Invalidate("/tag")
+	assert.NotEqual(t, tags, get("/tag"))
+
+	tags = get("/tag")
+	c.InvalidateAll()
+	assert.NotEqual(t, tags, get("/tag"))
+}
+
+func TestCachingClient_expiry(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, atomic.AddInt32(&gets, 1))
+	}))
+	defer srv.Close()
+
+	c := NewCachingClient(New("", "", WithBaseURL(srv.URL)), time.Hour, WithCacheTTL("/server", 10*time.Millisecond))
+	ctx := context.Background()
+
+	first, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	second, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestCachingClient_invalidatedDuringGet(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && atomic.AddInt32(&gets, 1) == 1 {
+			close(started)
+			<-release
+		}
+		fmt.Fprint(w, atomic.LoadInt32(&gets))
+	}))
+	defer srv.Close()
+
+	c := NewCachingClient(New("", "", WithBaseURL(srv.URL)), time.Minute)
+	ctx := context.Background()
+
+	done := make(chan []byte)
+	go func() {
+		body, err := c.Get(ctx, "/server")
+		assert.NoError(t, err)
+		done <- body
+	}()
+	<-started
+	_, err := c.Put(ctx, "/server/uuid", []byte("{}"))
+	require.NoError(t, err)
+	close(release)
+	stale := <-done
+
+	// The response of the GET that was in flight during the mutation is not cached
+	fresh, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	assert.NotEqual(t, stale, fresh)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets))
+}
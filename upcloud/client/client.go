@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
@@ -27,17 +28,55 @@ type config struct {
 	username   string
 	password   string
 	baseURL    string
+	userAgent  string
+	products   []string
 	httpClient *http.Client
+	coalesce   bool
+	retries    int
+	backoff    BackoffStrategy
+
+	retryMethods        []string
+	retryMaxElapsedTime time.Duration
+	retryBudget         *RetryBudget
+	rateLimitRetries    int
+	rateLimitMaxWait    time.Duration
+	rateLimiter         *RateLimiter
+	tokenSource         TokenSource
+	maxResponseSize     int64
+	responseReadTimeout time.Duration
+
+	codec Codec
+
+	requestHooks []RequestHook
+	retryNotify  RetryNotifyFunc
+	middleware   []Middleware
+	logger       *slog.Logger
+	metrics      MetricsObserver
+
+	circuitBreaker *CircuitBreaker
+
+	transportOptions []func(t *http.Transport)
 }
 
 // Client represents an API client
 type Client struct {
 	UserAgent string
 	config    config
+	flights   flightGroup
+	doer      Doer
 }
 
 // Get performs a GET request to the specified path and returns the response body.
 func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	if c.config.coalesce && coalescable(ctx) {
+		return c.flights.do(ctx, path, func(ctx context.Context) ([]byte, error) {
+			return c.get(ctx, path)
+		})
+	}
+	return c.get(ctx, path)
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
 	r, err := c.createRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
@@ -83,13 +122,55 @@ func (c *Client) Delete(ctx context.Context, path string) ([]byte, error) {
 
 // Do performs HTTP request and returns the response body.
 func (c *Client) Do(r *http.Request) ([]byte, error) {
+	if c.config.tokenSource != nil && r.Header.Get("Authorization") == "" && c.isAPIRequest(r) {
+		r = r.WithContext(context.WithValue(r.Context(), tokenAuthKey{}, true))
+	}
 	c.addDefaultHeaders(r)
-	response, err := c.config.httpClient.Do(r)
+	id := c.setRequestID(r)
+	body, err := c.doWithRetry(r)
 	if err != nil {
+		c.log(r.Context(), slog.LevelError, "api request failed", r, slog.Any("error", err))
+	}
+	return body, withRequestID(err, id)
+}
+
+func (c *Client) do(r *http.Request) ([]byte, error) {
+	// Custom transports might not check the context, so do not send requests whose context is already done
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
+	if c.config.rateLimiter != nil {
+		if err := c.config.rateLimiter.Wait(r.Context()); err != nil {
+			return nil, err
+		}
+	}
+	if tokenAuth, _ := r.Context().Value(tokenAuthKey{}).(bool); tokenAuth {
+		if err := c.setBearerToken(r); err != nil {
+			return nil, err
+		}
+	}
+	c.runRequestHooks(r)
+	if breaker := c.config.circuitBreaker; breaker != nil {
+		if err := breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+	c.log(r.Context(), slog.LevelDebug, "api request", r)
+	start := time.Now()
+	response, err := c.doer.Do(r)
+	if err != nil {
+		c.logResponse(r, 0, start, err)
+		c.observeRequest(r, 0, start, err)
+		c.recordCircuit(r, err)
 		return nil, err
 	}
 
-	return handleResponse(response)
+	recordResponse(r, response)
+	body, err := c.handleResponse(response)
+	c.logResponse(r, response.StatusCode, start, err)
+	c.observeRequest(r, response.StatusCode, start, err)
+	c.recordCircuit(r, err)
+	return body, err
 }
 
 func (c *Client) createRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
@@ -121,11 +202,16 @@ func (c *Client) addDefaultHeaders(r *http.Request) {
 	if _, ok := r.Header[userAgent]; !ok {
 		r.Header.Set(userAgent, c.UserAgent)
 	}
-	if _, ok := r.Header[authorization]; !ok && strings.HasPrefix(r.URL.String(), c.config.baseURL) {
+	if _, ok := r.Header[authorization]; !ok && c.config.tokenSource == nil && c.isAPIRequest(r) {
 		r.SetBasicAuth(c.config.username, c.config.password)
 	}
 }
 
+// isAPIRequest returns true if the request is sent to the API, and not e.g. to a storage import URL
+func (c *Client) isAPIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.String(), c.config.baseURL)
+}
+
 // createRequestURL creates and returns a complete request URL for the specified API location using a newer API version.
 func (c *Client) createRequestURL(location string) string {
 	return fmt.Sprintf("%s%s", c.getBaseURL(), location)
@@ -148,23 +234,50 @@ func WithBaseURL(baseURL string) ConfigFn {
 	}
 }
 
-// WithInsecureSkipVerify modifies the client's httpClient to skip verifying
-// the server's certificate chain and host name. This should be used only for testing.
-func WithInsecureSkipVerify() ConfigFn {
+// WithUserAgent replaces the default User-Agent header sent by the client
+func WithUserAgent(userAgent string) ConfigFn {
 	return func(c *config) {
-		if c.httpClient != nil {
-			if t, ok := c.httpClient.Transport.(*http.Transport); ok {
-				cfg := &tls.Config{InsecureSkipVerify: true} //nolint:gosec // allow setting InsecureSkipVerify to true as explicitly requested
-				if t.TLSClientConfig == nil {
-					t.TLSClientConfig = cfg
+		c.userAgent = userAgent
+	}
+}
 
-					return
-				}
+// WithUserAgentProduct appends a product token, e.g. "terraform-provider-upcloud/5.0.0", to the User-Agent header sent
+// by the client, so that the API can identify the application using the SDK.
+func WithUserAgentProduct(product string) ConfigFn {
+	return func(c *config) {
+		c.products = append(c.products, product)
+	}
+}
 
-				t.TLSClientConfig.InsecureSkipVerify = cfg.InsecureSkipVerify
-			}
+// WithInsecureSkipVerify modifies the client's httpClient to skip verifying
+// the server's certificate chain and host name. This should be used only for testing. The option is a transport
+// option, see New.
+func WithInsecureSkipVerify() ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // allow setting InsecureSkipVerify to true as explicitly requested
+			return
 		}
-	}
+		t.TLSClientConfig.InsecureSkipVerify = true
+	})
+}
+
+// WithTLSConfig sets the TLS configuration used for connecting to the API, e.g. to trust a private CA, pin
+// certificates or present a client certificate. A copy of cfg is used, so cfg is not modified by other options such as
+// WithInsecureSkipVerify. The option is a transport option, see New.
+func WithTLSConfig(cfg *tls.Config) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.TLSClientConfig = cfg.Clone()
+	})
+}
+
+// WithProxy sends the requests through the proxy at proxyURL instead of the proxy configured with the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables, which are honored by default. Nil proxyURL disables the proxy. The
+// option is a transport option, see New.
+func WithProxy(proxyURL *url.URL) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.Proxy = http.ProxyURL(proxyURL)
+	})
 }
 
 // WithHTTPClient replaces the client's default httpClient with the specified one
@@ -174,6 +287,17 @@ func WithHTTPClient(httpClient *http.Client) ConfigFn {
 	}
 }
 
+// WithTransport replaces the transport of the client's httpClient with the specified round tripper, keeping the other
+// settings of the httpClient, such as the timeout. The default transport returned by NewDefaultHTTPTransport can be
+// wrapped to add, for example, instrumentation to the requests.
+func WithTransport(transport http.RoundTripper) ConfigFn {
+	return func(c *config) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
 // WithTimeout modifies the client's httpClient timeout
 func WithTimeout(timeout time.Duration) ConfigFn {
 	return func(c *config) {
@@ -181,8 +305,21 @@ func WithTimeout(timeout time.Duration) ConfigFn {
 	}
 }
 
+// WithRequestCoalescing makes concurrent GET requests to the same path share a single HTTP request and its response.
+// Requests whose context carries per-request settings, i.e. contexts returned by ContextWithResponseInfo,
+// ContextWithRequestID and ContextWithRetry, are sent on their own.
+func WithRequestCoalescing() ConfigFn {
+	return func(c *config) {
+		c.coalesce = true
+	}
+}
+
 // New creates and returns a new client configured with the specified user and password and optional
 // config functions.
+//
+// Transport options, such as WithProxy, WithTLSConfig and WithKeepAlives, are applied after all other options to a
+// copy of the transport of the httpClient, so the transport set with WithHTTPClient or WithTransport is not modified.
+// New panics if transport options are given and the transport is not a *http.Transport.
 func New(username, password string, c ...ConfigFn) *Client {
 	config := config{
 		username:   username,
@@ -191,22 +328,32 @@ func New(username, password string, c ...ConfigFn) *Client {
 		httpClient: NewDefaultHTTPClient(),
 	}
 
-	// If set, replace http client transport with one skipping tls verification
-	if os.Getenv(EnvDebugSkipCertificateVerify) == "1" {
-		c = append(c, WithInsecureSkipVerify())
-	}
-
 	for _, fn := range c {
 		fn(&config)
 	}
-	return &Client{
-		UserAgent: userAgent(),
+	// If set, replace http client transport with one skipping tls verification. Custom transports are left as is.
+	if os.Getenv(EnvDebugSkipCertificateVerify) == "1" {
+		if _, ok := config.httpClient.Transport.(*http.Transport); ok || config.httpClient.Transport == nil {
+			WithInsecureSkipVerify()(&config)
+		}
+	}
+	config.applyTransportOptions()
+	if config.userAgent == "" {
+		config.userAgent = userAgent()
+	}
+	if len(config.products) > 0 {
+		config.userAgent = strings.Join(append([]string{config.userAgent}, config.products...), " ")
+	}
+	client := &Client{
+		UserAgent: config.userAgent,
 		config:    config,
 	}
+	client.doer = client.buildDoer()
+	return client
 }
 
 func userAgent() string {
-	return fmt.Sprintf("upcloud-go-api/%s", Version)
+	return fmt.Sprintf("upcloud-go-sdk/%s", Version)
 }
 
 func clientBaseURL(URL string) string {
@@ -222,12 +369,12 @@ func clientBaseURL(URL string) string {
 }
 
 // Parses the response and returns either the response body or an error
-func handleResponse(response *http.Response) ([]byte, error) {
+func (c *Client) handleResponse(response *http.Response) ([]byte, error) {
 	defer response.Body.Close()
 
 	// Return an error on unsuccessful requests
 	if response.StatusCode < 200 || response.StatusCode > 299 {
-		errorBody, _ := io.ReadAll(response.Body)
+		errorBody, _ := c.readBody(response)
 		var errorType ErrorType
 		switch response.Header.Get("Content-Type") {
 		case "application/problem+json":
@@ -235,12 +382,17 @@ func handleResponse(response *http.Response) ([]byte, error) {
 		default:
 			errorType = ErrorTypeError
 		}
-		return nil, &Error{response.StatusCode, response.Status, errorBody, errorType}
+		return nil, &Error{
+			ErrorCode:     response.StatusCode,
+			ErrorMessage:  response.Status,
+			ResponseBody:  errorBody,
+			Type:          errorType,
+			RetryAfter:    parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+			CorrelationID: correlationID(response.Header),
+		}
 	}
 
-	responseBody, err := io.ReadAll(response.Body)
-
-	return responseBody, err
+	return c.readBody(response)
 }
 
 // NewDefaultHTTPClient returns new default http.Client.
@@ -256,7 +408,7 @@ func NewDefaultHTTPTransport() http.RoundTripper {
 	return &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
+			Timeout:   defaultDialTimeout,
 			KeepAlive: 30 * time.Second,
 			DualStack: true,
 		}).DialContext,
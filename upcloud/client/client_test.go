@@ -2,12 +2,16 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -79,7 +83,20 @@ func TestClientUserAgent(t *testing.T) {
 
 	var u, p string
 	c1 := New(u, p)
-	assert.Equal(t, fmt.Sprintf("upcloud-go-api/%s", Version), c1.UserAgent)
+	assert.Equal(t, fmt.Sprintf("upcloud-go-sdk/%s", Version), c1.UserAgent)
+
+	c1 = New(u, p, WithUserAgentProduct("terraform-provider-upcloud/5.0.0"), WithUserAgentProduct("my-tool/1.0"))
+	assert.Equal(t, fmt.Sprintf("upcloud-go-sdk/%s terraform-provider-upcloud/5.0.0 my-tool/1.0", Version), c1.UserAgent)
+
+	var got string
+	c2 := New(u, p, WithUserAgent("my-tool/1.0"), WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.Header.Get("User-Agent")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}))
+	_, err := c2.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "my-tool/1.0", c2.UserAgent)
+	assert.Equal(t, "my-tool/1.0", got)
 }
 
 func TestClientGet(t *testing.T) {
@@ -226,3 +243,105 @@ func ExampleWithHTTPClient() {
 	}
 	New(os.Getenv("UPCLOUD_USERNAME"), os.Getenv("UPCLOUD_PASSWORD"), WithHTTPClient(httpClient))
 }
+
+func TestClientWithTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	// Server certificate is not trusted by default
+	_, err := New("", "", WithBaseURL(srv.URL)).Get(context.Background(), "/account")
+	assert.Error(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	body, err := New("", "", WithBaseURL(srv.URL), WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})).Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	// The configuration and the transport of the caller are not modified by the options, whatever their order
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	transport := &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS13}}
+	c := New("", "", WithTLSConfig(cfg), WithInsecureSkipVerify(), WithTransport(transport))
+	applied, ok := c.config.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.True(t, applied.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, uint16(tls.VersionTLS12), applied.TLSClientConfig.MinVersion)
+	assert.False(t, cfg.InsecureSkipVerify)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestClientWithProxy(t *testing.T) {
+	t.Parallel()
+
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.URL.Host
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	c := New("", "", WithBaseURL("http://api.example.invalid"), WithProxy(proxyURL))
+	body, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, "api.example.invalid", host)
+
+	c = New("", "", WithProxy(nil))
+	transport, ok := c.config.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	u, err := transport.Proxy(httptest.NewRequest(http.MethodGet, APIBaseURL, nil))
+	require.NoError(t, err)
+	assert.Nil(t, u)
+}
+
+func TestClientWithTransport(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	})
+	c := New("", "", WithTimeout(time.Minute), WithTransport(transport))
+	body, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, []string{"/1.3/account"}, paths)
+	assert.Equal(t, time.Minute, c.config.httpClient.Timeout)
+}
+
+func ExampleWithTransport() {
+	transport := NewDefaultHTTPTransport()
+	New(os.Getenv("UPCLOUD_USERNAME"), os.Getenv("UPCLOUD_PASSWORD"), WithTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		// instrument the request
+		return transport.RoundTrip(r)
+	})))
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	c := New("", "", WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Get(ctx, "/account")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, calls)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
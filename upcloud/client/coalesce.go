@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// flight is a GET request in progress whose result is shared by all callers requesting the same path.
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// coalescable reports whether a request made with ctx can share the request of another caller. The shared request is
+// made with the context of the first caller, so the requests with per-request settings in their context are not
+// coalesced.
+func coalescable(ctx context.Context) bool {
+	for _, key := range []any{responseInfoKey{}, requestIDKey{}, retryOverrideKey{}} {
+		if ctx.Value(key) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// flightGroup deduplicates concurrent GET requests to the same path.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do calls fn once for concurrent callers with the same key and returns a copy of the shared result to each caller.
+// The shared request is not cancelled when the context of the first caller is done, but each caller stops waiting
+// when its own context is done. The shared request is cancelled once all callers have stopped waiting.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[key]
+	if !ok {
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = f
+		go func() {
+			defer cancel()
+			f.body, f.err = fn(sharedCtx)
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return copyBytes(f.body), f.err
+	case <-ctx.Done():
+		g.leave(key, f)
+		return nil, ctx.Err()
+	}
+}
+
+// leave removes a waiter from the flight and cancels the flight when no one is waiting for it anymore
+func (g *flightGroup) leave(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+	f.cancel()
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRequestCoalescing(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprintf(w, "%s %d", r.URL.Path, n)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRequestCoalescing())
+
+	const callers = 10
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body, err := c.Get(context.Background(), "/server")
+			assert.NoError(t, err)
+			bodies[i] = string(body)
+		}(i)
+	}
+
+	// Wait for the shared request to reach the server before releasing it
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) > 0 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for _, body := range bodies {
+		assert.Equal(t, "/1.3/server 1", body)
+	}
+
+	// Requests made after the shared request has completed are not coalesced
+	body, err := c.Get(context.Background(), "/server")
+	require.NoError(t, err)
+	assert.Equal(t, "/1.3/server 2", string(body))
+}
+
+func TestClientRequestCoalescing_callerContext(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := New("", "", WithBaseURL(srv.URL), WithRequestCoalescing())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, "/server")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientRequestCoalescing_lastCallerCancels(t *testing.T) {
+	t.Parallel()
+
+	cancelled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRequestCoalescing())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, "/server")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shared request was not cancelled after the last caller left")
+	}
+}
+
+func TestClientRequestCoalescing_requestContext(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, r.Header.Get(RequestIDHeader))
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRequestCoalescing())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := c.Get(context.Background(), "/server")
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, time.Millisecond)
+
+	// Requests with per-request settings are sent on their own
+	ctx, info := ContextWithResponseInfo(ContextWithRequestID(context.Background(), "my-id"))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		body, err := c.Get(ctx, "/server")
+		assert.NoError(t, err)
+		assert.Equal(t, "my-id", string(body))
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 2 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, http.StatusOK, info.StatusCode)
+	assert.Equal(t, "my-id", info.RequestID)
+}
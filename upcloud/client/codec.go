@@ -0,0 +1,40 @@
+package client
+
+import "encoding/json"
+
+// Codec marshals request bodies and unmarshals response bodies. Implementations must support the json.Marshaler and
+// json.Unmarshaler interfaces, because the SDK types implement them to deal with the API envelopes.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the default codec that uses the encoding/json package of the standard library.
+type JSONCodec struct{}
+
+// Marshal returns the JSON encoding of v.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal parses the JSON encoded data and stores the result in the value pointed to by v.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// WithCodec replaces the default JSON codec used by the service for encoding requests and decoding responses,
+// including the error responses and the documents checked in strict decoding mode. The client itself does not decode
+// the responses; the service gets the codec from the client with Codec.
+func WithCodec(codec Codec) ConfigFn {
+	return func(c *config) {
+		c.codec = codec
+	}
+}
+
+// Codec returns the codec configured for the client.
+func (c *Client) Codec() Codec {
+	if c.config.codec == nil {
+		return JSONCodec{}
+	}
+	return c.config.codec
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCodec struct {
+	JSONCodec
+}
+
+func TestClientCodec(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, JSONCodec{}, New("", "").Codec())
+	assert.Equal(t, testCodec{}, New("", "", WithCodec(testCodec{})).Codec())
+}
+
+func TestJSONCodec(t *testing.T) {
+	t.Parallel()
+
+	var codec Codec = JSONCodec{}
+	b, err := codec.Marshal(map[string]int{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(b))
+
+	var v map[string]int
+	require.NoError(t, codec.Unmarshal(b, &v))
+	assert.Equal(t, map[string]int{"a": 1}, v)
+}
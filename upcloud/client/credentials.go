@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by ResolveCredentials
+const (
+	EnvUsername   string = "UPCLOUD_USERNAME"
+	EnvPassword   string = "UPCLOUD_PASSWORD"
+	EnvToken      string = "UPCLOUD_TOKEN"
+	EnvConfigFile string = "UPCLOUD_CONFIG"
+)
+
+// Credentials sources reported in Credentials.Source
+const (
+	CredentialsSourceExplicit    = "explicit"
+	CredentialsSourceEnvironment = "environment"
+	CredentialsSourceConfigFile  = "config file"
+)
+
+// ErrNoCredentials is returned by ResolveCredentials when none of the sources provides credentials
+var ErrNoCredentials = errors.New("no UpCloud API credentials found")
+
+// Credentials are the username and password or the API token used for authenticating to the API
+type Credentials struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Token    string `yaml:"token"`
+	// Source tells where the credentials were resolved from
+	Source string `yaml:"-"`
+}
+
+// String returns the credentials with the password and token redacted
+func (c Credentials) String() string {
+	if c.Token != "" {
+		return fmt.Sprintf("Credentials{Token: <redacted>, Source: %s}", c.Source)
+	}
+	return fmt.Sprintf("Credentials{Username: %s, Password: <redacted>, Source: %s}", c.Username, c.Source)
+}
+
+// GoString returns the credentials with the password and token redacted for the %#v verb
+func (c Credentials) GoString() string {
+	return c.String()
+}
+
+// complete returns true if the credentials contain a token or both username and password
+func (c Credentials) complete() bool {
+	return c.Token != "" || (c.Username != "" && c.Password != "")
+}
+
+// DefaultConfigFile returns the path of the config file read by ResolveCredentials: the path in UPCLOUD_CONFIG
+// environment variable if set, otherwise upcloud/config.yaml in the user config directory, e.g.
+// ~/.config/upcloud/config.yaml on Linux.
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "upcloud", "config.yaml"), nil
+}
+
+// ResolveCredentials returns the first complete credentials from, in order of precedence, the explicit credentials,
+// the UPCLOUD_TOKEN or UPCLOUD_USERNAME and UPCLOUD_PASSWORD environment variables and the config file returned by
+// DefaultConfigFile. The config file is a YAML document with username and password, or token, keys. Credentials
+// are complete when they contain a token or both username and password; sources are not merged.
+func ResolveCredentials(explicit Credentials) (Credentials, error) {
+	if explicit.complete() {
+		explicit.Source = CredentialsSourceExplicit
+		return explicit, nil
+	}
+
+	env := Credentials{
+		Username: os.Getenv(EnvUsername),
+		Password: os.Getenv(EnvPassword),
+		Token:    os.Getenv(EnvToken),
+		Source:   CredentialsSourceEnvironment,
+	}
+	if env.complete() {
+		return env, nil
+	}
+
+	path, err := DefaultConfigFile()
+	if err != nil {
+		return Credentials{}, ErrNoCredentials
+	}
+	file, err := readCredentialsFile(path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if file.complete() {
+		return file, nil
+	}
+	return Credentials{}, ErrNoCredentials
+}
+
+func readCredentialsFile(path string) (Credentials, error) {
+	var c Credentials
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("reading credentials from %s: %w", path, err)
+	}
+	c.Source = CredentialsSourceConfigFile
+	return c, nil
+}
+
+// NewFromCredentials creates and returns a new client that authenticates with the token of the credentials, or with
+// the username and password if the token is not set.
+func NewFromCredentials(credentials Credentials, c ...ConfigFn) *Client {
+	if credentials.Token != "" {
+		return NewWithToken(credentials.Token, c...)
+	}
+	return New(credentials.Username, credentials.Password, c...)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCredentials(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("username: file-user\npassword: file-pass\n"), 0o600))
+	t.Setenv(EnvConfigFile, config)
+	t.Setenv(EnvUsername, "")
+	t.Setenv(EnvPassword, "")
+	t.Setenv(EnvToken, "")
+
+	c, err := ResolveCredentials(Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "file-user", Password: "file-pass", Source: CredentialsSourceConfigFile}, c)
+
+	// Incomplete environment credentials are not merged with the other sources
+	t.Setenv(EnvUsername, "env-user")
+	c, err = ResolveCredentials(Credentials{})
+	require.NoError(t, err)
+	assert.Equal(t, "file-user", c.Username)
+
+	t.Setenv(EnvToken, "env-token")
+	c, err = ResolveCredentials(Credentials{Username: "explicit-user"})
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "env-user", Token: "env-token", Source: CredentialsSourceEnvironment}, c)
+
+	c, err = ResolveCredentials(Credentials{Username: "explicit-user", Password: "explicit-pass"})
+	require.NoError(t, err)
+	assert.Equal(t, CredentialsSourceExplicit, c.Source)
+	assert.Equal(t, "explicit-user", c.Username)
+
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+	_, err = ResolveCredentials(Credentials{})
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	require.NoError(t, os.WriteFile(config, []byte("username: [\n"), 0o600))
+	t.Setenv(EnvConfigFile, config)
+	_, err = ResolveCredentials(Credentials{})
+	assert.ErrorContains(t, err, "reading credentials from "+config)
+}
+
+func TestCredentialsRedacted(t *testing.T) {
+	t.Parallel()
+
+	c := Credentials{Username: "user", Password: "secret-pass", Token: "secret-token"}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, c), "secret")
+	}
+	c.Token = ""
+	assert.Equal(t, "Credentials{Username: user, Password: <redacted>, Source: }", c.String())
+}
+
+func TestNewFromCredentials(t *testing.T) {
+	t.Parallel()
+
+	c := NewFromCredentials(Credentials{Token: "token"})
+	assert.NotNil(t, c.config.tokenSource)
+	c = NewFromCredentials(Credentials{Username: "user", Password: "pass"})
+	assert.Nil(t, c.config.tokenSource)
+	assert.Equal(t, "user", c.config.username)
+}
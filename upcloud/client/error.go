@@ -1,6 +1,10 @@
 package client
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type ErrorType int
 
@@ -15,9 +19,70 @@ type Error struct {
 	ErrorMessage string
 	ResponseBody []byte
 	Type         ErrorType
+	// RetryAfter is the delay requested by the Retry-After header of the response, or zero if the header is not set
+	RetryAfter time.Duration
+	// RequestID is the client-side ID sent in the X-Request-ID header of the request
+	RequestID string
+	// CorrelationID is the API-side ID of the request returned in the response headers, see CorrelationIDHeaders
+	CorrelationID string
 }
 
 // Error implements the Error interface
 func (e *Error) Error() string {
 	return fmt.Sprintf("%d: %s", e.ErrorCode, e.ErrorMessage)
 }
+
+// RetryError is returned when a request fails after it has been retried. It wraps the error of the last attempt, so
+// errors.As can be used to inspect both the retries and the last error.
+type RetryError struct {
+	// Attempts is the number of times the request was sent
+	Attempts int
+	// StatusCodes are the response status codes of the attempts in order. Zero means that the attempt failed without
+	// a response, e.g. because of a network error.
+	StatusCodes []int
+	// Elapsed is the time from the first attempt until giving up
+	Elapsed time.Duration
+	// Err is the error of the last attempt
+	Err error
+}
+
+// Error implements the Error interface
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s (%d attempts in %s)", e.Err, e.Attempts, e.Elapsed.Round(time.Millisecond))
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// newRetryError wraps err in RetryError if the request was retried, otherwise err is returned as is
+func newRetryError(err error, statusCodes []int, start time.Time) error {
+	if len(statusCodes) < 2 {
+		return err
+	}
+	return &RetryError{
+		Attempts:    len(statusCodes),
+		StatusCodes: statusCodes,
+		Elapsed:     time.Since(start),
+		Err:         err,
+	}
+}
+
+// errorRetryAfter returns the Retry-After delay of the error, or zero if the response did not request one
+func errorRetryAfter(err error) time.Duration {
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		return clientErr.RetryAfter
+	}
+	return 0
+}
+
+// errorStatusCode returns the response status code of the error, or zero if the request failed without a response
+func errorStatusCode(err error) int {
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		return clientErr.ErrorCode
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestHook is called with the request context before each attempt of a request is sent. Hooks can, for example,
+// attach correlation IDs carried in the context to the request headers.
+type RequestHook func(ctx context.Context, r *http.Request)
+
+// RetryNotifyFunc is called with the request context before a failed request is retried. The attempt is counted from
+// zero and err is the error of the failed attempt.
+type RetryNotifyFunc func(ctx context.Context, attempt int, err error, delay time.Duration)
+
+// WithRequestHook adds a hook that is called before each request attempt. Hooks are called in the order they are added.
+func WithRequestHook(hook RequestHook) ConfigFn {
+	return func(c *config) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithRetryNotify sets a function that is called before each retry of a failed request.
+func WithRetryNotify(fn RetryNotifyFunc) ConfigFn {
+	return func(c *config) {
+		c.retryNotify = fn
+	}
+}
+
+func (c *Client) runRequestHooks(r *http.Request) {
+	for _, hook := range c.config.requestHooks {
+		hook(r.Context(), r)
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type correlationIDKey struct{}
+
+func TestClientRequestHook(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("X-Correlation-ID"))
+		if atomic.AddInt32(&requests, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var notified []string
+	c := New("", "", WithBaseURL(srv.URL),
+		WithRetry(1, ConstantBackoff{Interval: time.Millisecond}),
+		WithRequestHook(func(ctx context.Context, r *http.Request) {
+			if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
+				r.Header.Set("X-Correlation-ID", id)
+			}
+		}),
+		WithRetryNotify(func(ctx context.Context, attempt int, err error, delay time.Duration) {
+			notified = append(notified, ctx.Value(correlationIDKey{}).(string))
+			assert.Equal(t, 0, attempt)
+			assert.Error(t, err)
+			assert.Equal(t, time.Millisecond, delay)
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), correlationIDKey{}, "abc")
+	_, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc", "abc"}, ids)
+	assert.Equal(t, []string{"abc"}, notified)
+}
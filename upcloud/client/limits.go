@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrResponseTooLarge is returned when the response body exceeds the size set with WithMaxResponseSize
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// WithMaxResponseSize limits the size of the response bodies read by the client. Reading stops and ErrResponseTooLarge
+// is returned once the body exceeds size bytes. Non-positive size does not limit the response size.
+func WithMaxResponseSize(size int64) ConfigFn {
+	return func(c *config) {
+		c.maxResponseSize = size
+	}
+}
+
+// WithResponseReadTimeout limits the time spent reading the response body after the response headers have been
+// received, so that stalled responses do not block the caller. Unlike WithTimeout, the timeout does not include
+// connecting and waiting for the response headers. Non-positive timeout disables the limit.
+func WithResponseReadTimeout(timeout time.Duration) ConfigFn {
+	return func(c *config) {
+		c.responseReadTimeout = timeout
+	}
+}
+
+// readBody reads the response body with the configured size and time limits
+func (c *Client) readBody(response *http.Response) ([]byte, error) {
+	var timedOut atomic.Bool
+	if timeout := c.config.responseReadTimeout; timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			response.Body.Close()
+		})
+		defer timer.Stop()
+	}
+
+	var body []byte
+	var err error
+	if size := c.config.maxResponseSize; size > 0 {
+		body, err = io.ReadAll(io.LimitReader(response.Body, size+1))
+		if err == nil && int64(len(body)) > size {
+			return nil, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, size)
+		}
+	} else {
+		body, err = io.ReadAll(response.Body)
+	}
+	if err != nil && timedOut.Load() {
+		return nil, fmt.Errorf("reading response body timed out after %s: %w", c.config.responseReadTimeout, err)
+	}
+	return body, err
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMaxResponseSize(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithMaxResponseSize(100), WithRetry(2, ConstantBackoff{Interval: time.Millisecond}))
+	body, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Len(t, body, 100)
+
+	c = New("", "", WithBaseURL(srv.URL), WithMaxResponseSize(99), WithRetry(2, ConstantBackoff{Interval: time.Millisecond}))
+	_, err = c.Get(context.Background(), "/account")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.EqualError(t, err, "response body too large: exceeds 99 bytes")
+	// Too large responses are not retried
+	assert.Equal(t, 2, calls)
+}
+
+func TestClientResponseReadTimeout(t *testing.T) {
+	t.Parallel()
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	c := New("", "", WithBaseURL(srv.URL), WithResponseReadTimeout(50*time.Millisecond))
+	_, err := c.Get(context.Background(), "/account")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading response body timed out after 50ms")
+}
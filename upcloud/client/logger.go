@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs the activity of the client to logger. Each attempt of a request is logged at debug level when it is
+// sent and when its response is received, retries are logged at info level and failed requests at error level.
+// The events share the attributes method, path and request_id; responses add status and duration, and retries
+// attempt, delay and error.
+func WithLogger(logger *slog.Logger) ConfigFn {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// log logs an event of request r if the client has a logger
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, r *http.Request, attrs ...slog.Attr) {
+	if c.config.logger == nil || !c.config.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs = append([]slog.Attr{
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.String("request_id", RequestID(r)),
+	}, attrs...)
+	c.config.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logResponse logs the outcome of a single attempt of request r
+func (c *Client) logResponse(r *http.Request, status int, start time.Time, err error) {
+	attrs := []slog.Attr{slog.Int("status", status), slog.Duration("duration", time.Since(start))}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	c.log(r.Context(), slog.LevelDebug, "api response", r, attrs...)
+}
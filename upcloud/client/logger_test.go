@@ -0,0 +1,53 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithLogger(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1.3/server/missing" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"https://developers.upcloud.com/1.3/errors#ERROR_SERVER_NOT_FOUND","title":"Server not found","status":404}`))
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := New("user", "pass", WithBaseURL(srv.URL), WithLogger(logger), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}))
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	_, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	_, err = c.Get(ContextWithRequestID(context.Background(), "req-2"), "/server/missing")
+	require.Error(t, err)
+
+	out := logs.String()
+	assert.Contains(t, out, `level=DEBUG msg="api request" method=GET path=/1.3/server request_id=req-1`)
+	assert.Contains(t, out, `level=DEBUG msg="api response" method=GET path=/1.3/server request_id=req-1 status=503`)
+	assert.Contains(t, out, `level=INFO msg="api request retry" method=GET path=/1.3/server request_id=req-1 attempt=0 delay=1ms`)
+	assert.Contains(t, out, `level=DEBUG msg="api response" method=GET path=/1.3/server request_id=req-1 status=200`)
+	assert.Contains(t, out, `level=ERROR msg="api request failed" method=GET path=/1.3/server/missing request_id=req-2`)
+	assert.NotContains(t, out, "pass")
+}
@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Error classes of RequestMetric
+const (
+	ErrorClassNone        = ""
+	ErrorClassCanceled    = "canceled"
+	ErrorClassTimeout     = "timeout"
+	ErrorClassNetwork     = "network"
+	ErrorClassRateLimited = "rate_limited"
+	ErrorClassNotFound    = "not_found"
+	ErrorClassConflict    = "conflict"
+	ErrorClassClient      = "client"
+	ErrorClassServer      = "server"
+)
+
+// RequestMetric describes a single attempt of a request
+type RequestMetric struct {
+	Method string
+	// Endpoint is the request path without the API version and with resource identifiers replaced by placeholders,
+	// e.g. "/server/{uuid}/start"
+	Endpoint string
+	// Status is the response status code, or zero if the attempt failed without a response
+	Status   int
+	Duration time.Duration
+	// ErrorClass is one of the ErrorClass constants, ErrorClassNone if the attempt succeeded
+	ErrorClass string
+}
+
+// MetricsObserver receives the measurements of the requests of the client
+type MetricsObserver interface {
+	// ObserveRequest is called after each attempt of each request
+	ObserveRequest(ctx context.Context, m RequestMetric)
+	// ObserveRetry is called before a failed request is retried
+	ObserveRetry(ctx context.Context, method, endpoint string)
+}
+
+// WithMetrics reports the measurements of the requests to observer. Metrics implements the observer for exposing
+// the measurements to Prometheus; other monitoring systems can be supported by implementing MetricsObserver.
+func WithMetrics(observer MetricsObserver) ConfigFn {
+	return func(c *config) {
+		c.metrics = observer
+	}
+}
+
+// DefaultMetricsBuckets are the upper bounds in seconds of the request duration histogram buckets used by default
+var DefaultMetricsBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type metricsRequestKey struct {
+	method, endpoint, errorClass string
+	status                       int
+}
+
+type metricsEndpointKey struct {
+	method, endpoint string
+}
+
+type metricsHistogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Metrics collects request counts, durations, error classes and retries per endpoint. Metrics implements
+// http.Handler, which serves the metrics in the Prometheus text exposition format, so it can be scraped directly or
+// added to an existing metrics endpoint with WriteTo:
+//
+//	upcloud_api_requests_total{method,endpoint,status,error_class}
+//	upcloud_api_request_duration_seconds{method,endpoint}
+//	upcloud_api_retries_total{method,endpoint}
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[metricsRequestKey]uint64
+	durations map[metricsEndpointKey]*metricsHistogram
+	retries   map[metricsEndpointKey]uint64
+}
+
+// NewMetrics returns new metrics with the duration histogram buckets, or DefaultMetricsBuckets if none are given
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets:   buckets,
+		requests:  make(map[metricsRequestKey]uint64),
+		durations: make(map[metricsEndpointKey]*metricsHistogram),
+		retries:   make(map[metricsEndpointKey]uint64),
+	}
+}
+
+// ObserveRequest implements MetricsObserver
+func (m *Metrics) ObserveRequest(_ context.Context, r RequestMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[metricsRequestKey{method: r.Method, endpoint: r.Endpoint, errorClass: r.ErrorClass, status: r.Status}]++
+
+	key := metricsEndpointKey{method: r.Method, endpoint: r.Endpoint}
+	h, ok := m.durations[key]
+	if !ok {
+		h = &metricsHistogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[key] = h
+	}
+	seconds := r.Duration.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ObserveRetry implements MetricsObserver
+func (m *Metrics) ObserveRetry(_ context.Context, method, endpoint string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[metricsEndpointKey{method: method, endpoint: endpoint}]++
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.mu.Lock()
+
+	requests := make([]metricsRequestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requests = append(requests, key)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.status != b.status {
+			return a.status < b.status
+		}
+		return a.errorClass < b.errorClass
+	})
+	b.WriteString("# HELP upcloud_api_requests_total Number of UpCloud API request attempts.\n")
+	b.WriteString("# TYPE upcloud_api_requests_total counter\n")
+	for _, key := range requests {
+		fmt.Fprintf(&b, "upcloud_api_requests_total{%s,status=\"%d\",error_class=\"%s\"} %d\n",
+			metricsLabels(key.method, key.endpoint), key.status, key.errorClass, m.requests[key])
+	}
+
+	durations := sortedEndpointKeys(m.durations)
+	b.WriteString("# HELP upcloud_api_request_duration_seconds Duration of UpCloud API request attempts.\n")
+	b.WriteString("# TYPE upcloud_api_request_duration_seconds histogram\n")
+	for _, key := range durations {
+		h := m.durations[key]
+		labels := metricsLabels(key.method, key.endpoint)
+		for i, le := range m.buckets {
+			fmt.Fprintf(&b, "upcloud_api_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "upcloud_api_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&b, "upcloud_api_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "upcloud_api_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+
+	retries := sortedEndpointKeys(m.retries)
+	b.WriteString("# HELP upcloud_api_retries_total Number of retried UpCloud API requests.\n")
+	b.WriteString("# TYPE upcloud_api_retries_total counter\n")
+	for _, key := range retries {
+		fmt.Fprintf(&b, "upcloud_api_retries_total{%s} %d\n", metricsLabels(key.method, key.endpoint), m.retries[key])
+	}
+
+	m.mu.Unlock()
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func metricsLabels(method, endpoint string) string {
+	return fmt.Sprintf(`method="%s",endpoint="%s"`, metricsLabelEscaper.Replace(method), metricsLabelEscaper.Replace(endpoint))
+}
+
+func sortedEndpointKeys[V any](m map[metricsEndpointKey]V) []metricsEndpointKey {
+	keys := make([]metricsEndpointKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+	return keys
+}
+
+var metricsUUID = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// MetricsEndpoint returns the endpoint of the request path: the API version is removed and UUIDs, numeric IDs and IP
+// addresses are replaced with {uuid}, {id} and {ip} to keep the number of endpoints bounded.
+func MetricsEndpoint(path string) string {
+	path = strings.TrimPrefix(path, "/"+APIVersion)
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "":
+		case metricsUUID.MatchString(segment):
+			segments[i] = "{uuid}"
+		case strings.Trim(segment, "0123456789") == "":
+			segments[i] = "{id}"
+		case net.ParseIP(segment) != nil:
+			segments[i] = "{ip}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// errorClass returns the ErrorClass constant describing err
+func errorClass(ctx context.Context, err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+	switch status := errorStatusCode(err); {
+	case status == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case status == http.StatusNotFound:
+		return ErrorClassNotFound
+	case status == http.StatusConflict:
+		return ErrorClassConflict
+	case status >= 500:
+		return ErrorClassServer
+	case status >= 400:
+		return ErrorClassClient
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		return ErrorClassCanceled
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	return ErrorClassNetwork
+}
+
+// observeRequest reports an attempt of request r to the metrics observer of the client
+func (c *Client) observeRequest(r *http.Request, status int, start time.Time, err error) {
+	if c.config.metrics == nil {
+		return
+	}
+	c.config.metrics.ObserveRequest(r.Context(), RequestMetric{
+		Method:     r.Method,
+		Endpoint:   MetricsEndpoint(r.URL.Path),
+		Status:     status,
+		Duration:   time.Since(start),
+		ErrorClass: errorClass(r.Context(), err),
+	})
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]string{
+		"/1.3/server": "/server",
+		"/1.3/server/00798b85-efdc-41ca-8021-f6ef457b8531/start": "/server/{uuid}/start",
+		"/1.3/ip_address/94.237.32.10":                           "/ip_address/{ip}",
+		"/1.3/ip_address/2a04:3540:1000::1":                      "/ip_address/{ip}",
+		"/1.3/host/7653311107":                                   "/host/{id}",
+		"/1.3/storage/template":                                  "/storage/template",
+		"/1.3/load-balancer/uuid/frontends/":                     "/load-balancer/uuid/frontends/",
+	} {
+		assert.Equal(t, want, MetricsEndpoint(path), path)
+	}
+}
+
+func TestClientWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/start"):
+			w.WriteHeader(http.StatusConflict)
+		case calls.Add(1) == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	metrics := NewMetrics(0.5, 0.1)
+	c := New("user", "pass", WithBaseURL(srv.URL), WithMetrics(metrics), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}))
+	_, err := c.Get(context.Background(), "/server/00798b85-efdc-41ca-8021-f6ef457b8531")
+	require.NoError(t, err)
+	_, err = c.Post(context.Background(), "/server/00798b85-efdc-41ca-8021-f6ef457b8531/start", nil)
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	out := rec.Body.String()
+	for _, line := range []string{
+		`upcloud_api_requests_total{method="GET",endpoint="/server/{uuid}",status="200",error_class=""} 1`,
+		`upcloud_api_requests_total{method="GET",endpoint="/server/{uuid}",status="503",error_class="server"} 1`,
+		`upcloud_api_requests_total{method="POST",endpoint="/server/{uuid}/start",status="409",error_class="conflict"} 1`,
+		`upcloud_api_request_duration_seconds_bucket{method="GET",endpoint="/server/{uuid}",le="0.1"} 2`,
+		`upcloud_api_request_duration_seconds_bucket{method="GET",endpoint="/server/{uuid}",le="0.5"} 2`,
+		`upcloud_api_request_duration_seconds_bucket{method="GET",endpoint="/server/{uuid}",le="+Inf"} 2`,
+		`upcloud_api_request_duration_seconds_count{method="GET",endpoint="/server/{uuid}"} 2`,
+		`upcloud_api_retries_total{method="GET",endpoint="/server/{uuid}"} 1`,
+	} {
+		assert.Contains(t, out, line+"\n")
+	}
+	assert.NotContains(t, out, `upcloud_api_retries_total{method="POST"`)
+}
+
+func TestErrorClass(t *testing.T) {
+	t.Parallel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, ErrorClassNone, errorClass(context.Background(), nil))
+	assert.Equal(t, ErrorClassRateLimited, errorClass(context.Background(), &Error{ErrorCode: http.StatusTooManyRequests}))
+	assert.Equal(t, ErrorClassNotFound, errorClass(context.Background(), &Error{ErrorCode: http.StatusNotFound}))
+	assert.Equal(t, ErrorClassClient, errorClass(context.Background(), &Error{ErrorCode: http.StatusBadRequest}))
+	assert.Equal(t, ErrorClassServer, errorClass(context.Background(), &Error{ErrorCode: http.StatusBadGateway}))
+	assert.Equal(t, ErrorClassCanceled, errorClass(canceled, context.Canceled))
+	assert.Equal(t, ErrorClassTimeout, errorClass(context.Background(), context.DeadlineExceeded))
+	assert.Equal(t, ErrorClassNetwork, errorClass(context.Background(), assert.AnError))
+}
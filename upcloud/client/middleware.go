@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/http"
+)
+
+// Doer sends an HTTP request and returns the response
+type Doer interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a function implementing Doer
+type DoerFunc func(r *http.Request) (*http.Response, error)
+
+// Do calls f(r)
+func (f DoerFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Middleware wraps the Doer that sends the requests of the client. Middleware can, for example, log or measure
+// requests, refresh credentials or modify headers, and see the response before the client handles it.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the client, see Client.Use
+func WithMiddleware(middleware ...Middleware) ConfigFn {
+	return func(c *config) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// Use adds middleware that is called for each attempt of each request, after the default headers and credentials
+// have been set. Middleware added earlier is closer to the caller, i.e. it sees the requests first. Use must not be
+// called concurrently with requests.
+func (c *Client) Use(middleware ...Middleware) {
+	c.config.middleware = append(c.config.middleware, middleware...)
+	c.doer = c.buildDoer()
+}
+
+// buildDoer wraps the HTTP client of the client with the middleware
+func (c *Client) buildDoer() Doer {
+	var doer Doer = c.config.httpClient
+	for i := len(c.config.middleware) - 1; i >= 0; i-- {
+		doer = c.config.middleware[i](doer)
+	}
+	return doer
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUse(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("X-Tenant")))
+	}))
+	defer srv.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				response, err := next.Do(r)
+				if err == nil {
+					order = append(order, name+" "+response.Status)
+				}
+				return response, err
+			})
+		}
+	}
+
+	c := New("user", "pass", WithBaseURL(srv.URL), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}), WithMiddleware(trace("outer")))
+	c.Use(trace("inner"), func(next Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			// Credentials are set before the middleware is called
+			_, _, ok := r.BasicAuth()
+			assert.True(t, ok)
+			r.Header.Set("X-Tenant", "acme")
+			return next.Do(r)
+		})
+	})
+
+	body, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", string(body))
+	// Each attempt passes through the middleware
+	assert.Equal(t, []string{
+		"outer", "inner", "inner 503 Service Unavailable", "outer 503 Service Unavailable",
+		"outer", "inner", "inner 200 OK", "outer 200 OK",
+	}, order)
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimiter limits the rate of requests sent by the client, including retries, with the limiter. The same
+// limiter can be shared by multiple clients to limit their combined request rate.
+func WithRateLimiter(limiter *RateLimiter) ConfigFn {
+	return func(c *config) {
+		c.rateLimiter = limiter
+	}
+}
+
+// RateLimiter is a token bucket that limits the rate of requests. Requests wait for a token when the bucket is empty
+// instead of failing, so callers stay below the API rate limits instead of reacting to 429 responses.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter returns a rate limiter that allows requestsPerSecond requests per second on average and bursts of up
+// to burst requests. Burst is at least one.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	b := float64(max(burst, 1))
+	return &RateLimiter{
+		rate:    requestsPerSecond,
+		burst:   b,
+		tokens:  b,
+		updated: time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done. Non-positive rate does not limit requests.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.updated).Seconds()*l.rate)
+	l.updated = now
+	// Reserve a token even if the bucket is empty, so that waiting requests are served in order
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the reserved token
+		l.mu.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := NewRateLimiter(50, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+	}
+	// Burst of two is immediate, the next two wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+}
+
+func TestRateLimiter_cancel(t *testing.T) {
+	t.Parallel()
+
+	l := NewRateLimiter(0.1, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+
+	// Unlimited
+	assert.NoError(t, NewRateLimiter(0, 0).Wait(context.Background()))
+}
+
+func TestClientRateLimiter_shared(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	limiter := NewRateLimiter(100, 1)
+	a := New("", "", WithBaseURL(srv.URL), WithRateLimiter(limiter))
+	b := New("", "", WithBaseURL(srv.URL), WithRateLimiter(limiter))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, c := range []*Client{a, b, a, b, a} {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			_, err := c.Get(context.Background(), "/server")
+			assert.NoError(t, err)
+		}(c)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+}
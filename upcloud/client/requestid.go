@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+)
+
+// RequestIDHeader is the request header carrying the client-side ID of the request
+const RequestIDHeader = "X-Request-ID"
+
+// CorrelationIDHeaders are the response headers in which the API returns its ID of the request, in order of
+// preference. The ID is stored in Error.CorrelationID and ResponseInfo.CorrelationID.
+var CorrelationIDHeaders = []string{"X-Correlation-ID", "X-Request-ID"}
+
+type requestIDKey struct{}
+
+type responseInfoKey struct{}
+
+// ResponseInfo records the response of the last attempt of the requests made with the context returned by
+// ContextWithResponseInfo
+type ResponseInfo struct {
+	StatusCode int
+	// RequestID is the client-side ID sent in the X-Request-ID header of the request
+	RequestID string
+	// CorrelationID is the API-side ID of the request, see CorrelationIDHeaders
+	CorrelationID string
+}
+
+// ContextWithResponseInfo returns a context that records the responses of the requests made with it in the returned
+// ResponseInfo, so that the IDs of successful requests can be referenced as well. The context must not be used for
+// concurrent requests.
+func ContextWithResponseInfo(ctx context.Context) (context.Context, *ResponseInfo) {
+	info := &ResponseInfo{}
+	return context.WithValue(ctx, responseInfoKey{}, info), info
+}
+
+// recordResponse sets the response info of the request context, if any
+func recordResponse(r *http.Request, response *http.Response) {
+	if info, ok := r.Context().Value(responseInfoKey{}).(*ResponseInfo); ok {
+		*info = ResponseInfo{
+			StatusCode:    response.StatusCode,
+			RequestID:     RequestID(r),
+			CorrelationID: correlationID(response.Header),
+		}
+	}
+}
+
+// correlationID returns the API-side ID of the request from the response headers
+func correlationID(header http.Header) string {
+	for _, name := range CorrelationIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ContextWithRequestID sets the ID sent in the X-Request-ID header of the requests made with the returned context.
+// By default, a random ID is generated for each request.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the client-side ID of the request, or an empty string if the request has no ID
+func RequestID(r *http.Request) string {
+	return r.Header.Get(RequestIDHeader)
+}
+
+// setRequestID sets the X-Request-ID header of API requests that do not have one. All attempts of a retried request
+// share the same ID.
+func (c *Client) setRequestID(r *http.Request) string {
+	if id := r.Header.Get(RequestIDHeader); id != "" || !c.isAPIRequest(r) {
+		return id
+	}
+	id, _ := r.Context().Value(requestIDKey{}).(string)
+	if id == "" {
+		id = newRequestID()
+	}
+	r.Header.Set(RequestIDHeader, id)
+	return id
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RequestError wraps errors of requests that failed without a response, such as network errors, with the ID of
+// the request. The error message is that of the wrapped error.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+// Error implements the Error interface
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorRequestID returns the ID of the request that caused err, or an empty string if err does not carry one
+func ErrorRequestID(err error) string {
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		return clientErr.RequestID
+	}
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.RequestID
+	}
+	return ""
+}
+
+// ErrorCorrelationID returns the API-side ID of the request that caused err, or an empty string if the response did
+// not include one
+func ErrorCorrelationID(err error) string {
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		return clientErr.CorrelationID
+	}
+	return ""
+}
+
+// withRequestID adds the request ID to errors. Errors with a response carry the ID in the RequestID field, other
+// errors are wrapped in RequestError.
+func withRequestID(err error, id string) error {
+	if err == nil || id == "" {
+		return err
+	}
+	var clientErr *Error
+	if errors.As(err, &clientErr) {
+		clientErr.RequestID = id
+		return err
+	}
+	return &RequestError{RequestID: id, Err: err}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRequestID(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var ids []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		n := len(ids)
+		mu.Unlock()
+		if n == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}))
+	_, err := c.Get(context.Background(), "/server")
+	var clientErr *Error
+	require.ErrorAs(t, err, &clientErr)
+	require.Len(t, ids, 1)
+	assert.Len(t, ids[0], 32)
+	assert.Equal(t, ids[0], clientErr.RequestID)
+	assert.Equal(t, ids[0], ErrorRequestID(err))
+
+	// Retries share the ID of the request
+	_, err = c.Get(ContextWithRequestID(context.Background(), "my-id"), "/server")
+	assert.Equal(t, "my-id", ErrorRequestID(err))
+	require.Len(t, ids, 3)
+	assert.Equal(t, []string{"my-id", "my-id"}, ids[1:])
+}
+
+func TestClientRequestID_networkError(t *testing.T) {
+	t.Parallel()
+
+	c := New("", "", WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}))
+	_, err := c.Get(ContextWithRequestID(context.Background(), "my-id"), "/server")
+	var requestErr *RequestError
+	require.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "my-id", requestErr.RequestID)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Empty(t, ErrorRequestID(errors.New("error")))
+}
+
+func TestClientCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-ID", "api-"+r.URL.Path[len("/1.3/"):])
+		if r.URL.Path == "/1.3/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL))
+	ctx, info := ContextWithResponseInfo(ContextWithRequestID(context.Background(), "my-id"))
+	_, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	assert.Equal(t, ResponseInfo{StatusCode: http.StatusOK, RequestID: "my-id", CorrelationID: "api-server"}, *info)
+
+	_, err = c.Get(ctx, "/missing")
+	var clientErr *Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, "api-missing", clientErr.CorrelationID)
+	assert.Equal(t, "api-missing", ErrorCorrelationID(err))
+	assert.Equal(t, ResponseInfo{StatusCode: http.StatusNotFound, RequestID: "my-id", CorrelationID: "api-missing"}, *info)
+	assert.Empty(t, ErrorCorrelationID(errors.New("error")))
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header that marks a request safe to retry regardless of its method.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// defaultRetryMethods are the idempotent methods that are retried unless configured otherwise.
+var defaultRetryMethods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}
+
+type retryOverrideKey struct{}
+
+// WithRetry makes the client retry failed idempotent requests up to retries times. Requests are retried on network
+// errors and on 429, 500, 502, 503 and 504 responses, waiting the delay returned by backoff between the attempts, or
+// the delay given in the Retry-After header of the response if it is longer.
+//
+// GET, HEAD, PUT and DELETE requests are retried by default. Other requests, such as POST requests creating
+// resources, are retried only if their method is enabled with WithRetryMethods, if they carry an Idempotency-Key
+// header or if retries are enabled for their context with ContextWithRetry. Requests passed to Do with a body but
+// without GetBody are never retried, because their body can not be sent again.
+func WithRetry(retries int, backoff BackoffStrategy) ConfigFn {
+	return func(c *config) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// RetryPolicy describes how failed requests are retried. Delays grow exponentially from BaseDelay and are capped to
+// MaxDelay, if set. Jitter is the fraction, between 0 and 1, of each delay that is randomized so that concurrent
+// clients do not retry in lockstep.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+}
+
+// Backoff returns the exponential delay before the given attempt with the jitter applied.
+func (p RetryPolicy) Backoff(attempt int, previous time.Duration) time.Duration {
+	delay := ExponentialBackoff{Base: p.BaseDelay, Max: p.MaxDelay}.Backoff(attempt, previous)
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || delay <= 0 {
+		return delay
+	}
+	return delay - time.Duration(rand.Float64()*jitter*float64(delay)) //nolint:gosec // jitter does not need a cryptographically secure random number
+}
+
+// WithRetryPolicy makes the client retry failed requests according to the policy. MaxAttempts includes the first
+// attempt, so a policy with MaxAttempts of 1 or less disables retries. See WithRetry for the requests that are retried.
+func WithRetryPolicy(policy RetryPolicy) ConfigFn {
+	return WithRetry(policy.MaxAttempts-1, policy)
+}
+
+// WithRateLimitRetry makes the client wait and retry requests that are rejected with 429 Too Many Requests response
+// up to retries times. The client waits the delay given in the Retry-After header of the response, or the delay of
+// the backoff strategy set with WithRetry if the header is missing. Requests are not retried if the requested delay
+// exceeds maxWait, unless maxWait is zero.
+//
+// Rate limited requests are retried regardless of their method, as the API did not process them.
+func WithRateLimitRetry(retries int, maxWait time.Duration) ConfigFn {
+	return func(c *config) {
+		c.rateLimitRetries = retries
+		c.rateLimitMaxWait = maxWait
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header given either in seconds or as a HTTP date. Zero is
+// returned if the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// WithRetryMethods sets the HTTP methods that are retried, replacing the default GET, HEAD, PUT and DELETE.
+func WithRetryMethods(methods ...string) ConfigFn {
+	return func(c *config) {
+		c.retryMethods = methods
+	}
+}
+
+// ContextWithRetry overrides the retry policy of the client for the requests made with the returned context. Retries
+// are enabled for any method if retry is true and disabled if it is false. The client still needs to be configured
+// with WithRetry for requests to be retried.
+func ContextWithRetry(ctx context.Context, retry bool) context.Context {
+	return context.WithValue(ctx, retryOverrideKey{}, retry)
+}
+
+// WithRetryMaxElapsedTime limits the total time spent on a single request including the retries. A retry is not
+// attempted if waiting for it would exceed the limit.
+func WithRetryMaxElapsedTime(d time.Duration) ConfigFn {
+	return func(c *config) {
+		c.retryMaxElapsedTime = d
+	}
+}
+
+// WithRetryBudget limits the retries of the client to the tokens available in the shared budget. The same budget
+// can be shared by multiple clients.
+func WithRetryBudget(budget *RetryBudget) ConfigFn {
+	return func(c *config) {
+		c.retryBudget = budget
+	}
+}
+
+// RetryBudget is a token bucket that limits the number of retries across concurrent requests, so that retries do
+// not amplify the load on the API during an outage. Each retry consumes one token and tokens are refilled at a
+// constant rate up to the budget capacity.
+type RetryBudget struct {
+	mu       sync.Mutex
+	capacity float64
+	rate     float64
+	tokens   float64
+	updated  time.Time
+}
+
+// NewRetryBudget returns a full retry budget with capacity tokens that are refilled at refillPerSecond rate.
+func NewRetryBudget(capacity int, refillPerSecond float64) *RetryBudget {
+	return &RetryBudget{
+		capacity: float64(capacity),
+		rate:     refillPerSecond,
+		tokens:   float64(capacity),
+		updated:  time.Now(),
+	}
+}
+
+// Take consumes a token from the budget and reports whether a token was available.
+func (b *RetryBudget) Take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.capacity, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// doWithRetry performs the request and retries it according to the client retry configuration.
+func (c *Client) doWithRetry(r *http.Request) ([]byte, error) {
+	// A request whose body can not be read again would be retried without the body
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return c.do(r)
+	}
+	retry := c.config.retries > 0 && c.config.backoff != nil && c.isRetryableRequest(r)
+	if !retry && c.config.rateLimitRetries <= 0 {
+		return c.do(r)
+	}
+
+	start := time.Now()
+	var delay time.Duration
+	var statusCodes []int
+	var retries, rateLimitRetries int
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && r.GetBody != nil {
+			reqBody, err := r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = reqBody
+		}
+		body, err := c.do(r)
+		if err == nil {
+			return body, nil
+		}
+		statusCodes = append(statusCodes, errorStatusCode(err))
+
+		retryAfter := errorRetryAfter(err)
+		budgeted := false
+		switch {
+		case errorStatusCode(err) == http.StatusTooManyRequests && rateLimitRetries < c.config.rateLimitRetries && r.Context().Err() == nil:
+			// The request was not processed, so it can be retried regardless of its method
+			rateLimitRetries++
+			delay = retryAfter
+			if delay <= 0 && c.config.backoff != nil {
+				delay = c.config.backoff.Backoff(rateLimitRetries-1, delay)
+			}
+			if c.config.rateLimitMaxWait > 0 && delay > c.config.rateLimitMaxWait {
+				return body, newRetryError(err, statusCodes, start)
+			}
+		case retry && retries < c.config.retries && isRetryableError(r.Context(), err):
+			delay = c.config.backoff.Backoff(retries, delay)
+			retries++
+			delay = max(delay, retryAfter)
+			budgeted = true
+		default:
+			return body, newRetryError(err, statusCodes, start)
+		}
+
+		if c.config.retryMaxElapsedTime > 0 && time.Since(start)+delay > c.config.retryMaxElapsedTime {
+			return body, newRetryError(err, statusCodes, start)
+		}
+		if budgeted && c.config.retryBudget != nil && !c.config.retryBudget.Take() {
+			return body, newRetryError(err, statusCodes, start)
+		}
+		if c.config.retryNotify != nil {
+			c.config.retryNotify(r.Context(), attempt, err, delay)
+		}
+		c.log(r.Context(), slog.LevelInfo, "api request retry", r,
+			slog.Int("attempt", attempt), slog.Duration("delay", delay), slog.Any("error", err))
+		if c.config.metrics != nil {
+			c.config.metrics.ObserveRetry(r.Context(), r.Method, MetricsEndpoint(r.URL.Path))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, newRetryError(err, statusCodes, start)
+		}
+	}
+}
+
+func (c *Client) isRetryableRequest(r *http.Request) bool {
+	if retry, ok := r.Context().Value(retryOverrideKey{}).(bool); ok {
+		return retry
+	}
+	if r.Header.Get(IdempotencyKeyHeader) != "" {
+		return true
+	}
+	methods := c.config.retryMethods
+	if methods == nil {
+		methods = defaultRetryMethods
+	}
+	return slices.Contains(methods, r.Method)
+}
+
+func isRetryableError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrResponseTooLarge) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var clientErr *Error
+	if !errors.As(err, &clientErr) {
+		// Network error
+		return true
+	}
+
+	switch clientErr.ErrorCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
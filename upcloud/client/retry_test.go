@@ -0,0 +1,327 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetry(3, ConstantBackoff{Interval: time.Millisecond}))
+	body, err := c.Get(context.Background(), "/server")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestClientRetry_exhausted(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	backoff := BackoffFunc(func(attempt int, previous time.Duration) time.Duration {
+		delays = append(delays, previous)
+		return time.Duration(attempt+1) * time.Millisecond
+	})
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetry(2, backoff))
+	_, err := c.Get(context.Background(), "/server")
+	var clientErr *Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusBadGateway, clientErr.ErrorCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Equal(t, []time.Duration{0, time.Millisecond}, delays)
+
+	var retryErr *RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.Equal(t, []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, retryErr.StatusCodes)
+	assert.Positive(t, retryErr.Elapsed)
+	assert.Contains(t, err.Error(), "502 Bad Gateway (3 attempts in ")
+}
+
+func TestClientRetry_notRetried(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetry(3, ConstantBackoff{Interval: time.Millisecond}))
+
+	// Client errors are not retried
+	_, err := c.Get(context.Background(), "/server/uuid")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	var retryErr *RetryError
+	assert.False(t, errors.As(err, &retryErr))
+
+	// Non-idempotent requests are not retried
+	_, err = c.Post(context.Background(), "/server", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestClientRetry_methods(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	var bodies []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}))
+	count := func() int32 { return atomic.SwapInt32(&requests, 0) }
+
+	// Idempotent requests are retried with the same body
+	_, err := c.Put(context.Background(), "/server/uuid", []byte(`{"server":{}}`))
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), count())
+	assert.Equal(t, []string{`{"server":{}}`, `{"server":{}}`}, bodies)
+	_, err = c.Delete(context.Background(), "/server/uuid")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), count())
+
+	// POST and PATCH requests are not retried by default
+	_, err = c.Post(context.Background(), "/server", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), count())
+	_, err = c.Patch(context.Background(), "/server/uuid", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), count())
+
+	// Context overrides the method policy both ways
+	_, err = c.Post(ContextWithRetry(context.Background(), true), "/server", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), count())
+	_, err = c.Get(ContextWithRetry(context.Background(), false), "/server")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), count())
+
+	// Requests with an idempotency key are retried
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/1.3/server", nil)
+	require.NoError(t, err)
+	r.Header.Set(IdempotencyKeyHeader, "key")
+	_, err = c.Do(r)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), count())
+
+	// Requests whose body can not be read again are not retried
+	r, err = http.NewRequestWithContext(context.Background(), http.MethodPut, srv.URL+"/1.3/server/uuid", io.NopCloser(strings.NewReader(`{"server":{}}`)))
+	require.NoError(t, err)
+	_, err = c.Do(r)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), count())
+
+	// Retried methods can be configured
+	c = New("", "", WithBaseURL(srv.URL), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}), WithRetryMethods(http.MethodGet, http.MethodPost))
+	_, err = c.Post(context.Background(), "/server", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), count())
+	_, err = c.Put(context.Background(), "/server/uuid", nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), count())
+}
+
+func TestClientRetry_maxElapsedTime(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL),
+		WithRetry(10, ConstantBackoff{Interval: 40 * time.Millisecond}),
+		WithRetryMaxElapsedTime(100*time.Millisecond),
+	)
+	_, err := c.Get(context.Background(), "/server")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestClientRetry_budget(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	budget := NewRetryBudget(3, 0)
+	opts := []ConfigFn{WithBaseURL(srv.URL), WithRetry(2, ConstantBackoff{Interval: time.Millisecond}), WithRetryBudget(budget)}
+	a, b := New("", "", opts...), New("", "", opts...)
+
+	// First request uses two tokens, second one the last token
+	_, err := a.Get(context.Background(), "/server")
+	assert.Error(t, err)
+	_, err = b.Get(context.Background(), "/server")
+	assert.Error(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+
+	// Budget is exhausted, so requests are not retried
+	_, err = a.Get(context.Background(), "/server")
+	assert.Error(t, err)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&requests))
+}
+
+func TestRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewRetryBudget(2, 1000)
+	assert.True(t, budget.Take())
+	assert.True(t, budget.Take())
+	assert.Eventually(t, budget.Take, time.Second, time.Millisecond)
+
+	empty := NewRetryBudget(1, 0)
+	assert.True(t, empty.Take())
+	assert.False(t, empty.Take())
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(0, 0))
+	assert.Equal(t, 400*time.Millisecond, p.Backoff(2, 0))
+	assert.Equal(t, time.Second, p.Backoff(10, 0))
+
+	p.Jitter = 0.5
+	for attempt := 0; attempt < 5; attempt++ {
+		delay := p.Backoff(attempt, 0)
+		upper := ExponentialBackoff{Base: p.BaseDelay, Max: p.MaxDelay}.Backoff(attempt, 0)
+		assert.LessOrEqual(t, delay, upper)
+		assert.GreaterOrEqual(t, delay, upper/2)
+	}
+}
+
+func TestClientRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 1}))
+	_, err := c.Get(context.Background(), "/server")
+	var retryErr *RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&requests, 0)
+	c = New("", "", WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1, BaseDelay: time.Millisecond}))
+	_, err = c.Get(context.Background(), "/server")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-3", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Mon, 01 Jan 2024 11:00:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestClientRateLimitRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	// POST requests are retried on 429 even though they are not retried on other errors
+	c := New("", "", WithBaseURL(srv.URL), WithRateLimitRetry(2, time.Second))
+	body, err := c.Post(context.Background(), "/server", []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&requests, 0)
+	c = New("", "", WithBaseURL(srv.URL), WithRateLimitRetry(1, time.Second))
+	_, err = c.Post(context.Background(), "/server", []byte("{}"))
+	var retryErr *RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, []int{http.StatusTooManyRequests, http.StatusTooManyRequests}, retryErr.StatusCodes)
+}
+
+func TestClientRateLimitRetry_maxWait(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := New("", "", WithBaseURL(srv.URL), WithRateLimitRetry(3, time.Minute))
+	_, err := c.Get(context.Background(), "/server")
+	var clientErr *Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, 2*time.Minute, clientErr.RetryAfter)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultTokenExpiryDelta is how long before expiry ReuseTokenSource refreshes the token by default.
+const DefaultTokenExpiryDelta = time.Minute
+
+// Token is an API token used for bearer authentication
+type Token struct {
+	AccessToken string
+	// Expiry is the expiration time of the token. Zero value means that the token does not expire.
+	Expiry time.Time
+}
+
+// Valid returns true if the token is set and does not expire within delta
+func (t *Token) Valid(delta time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Until(t.Expiry) > delta
+}
+
+// String returns the token with the access token redacted, so that tokens do not leak to logs
+func (t Token) String() string {
+	if t.Expiry.IsZero() {
+		return "Token{AccessToken: <redacted>}"
+	}
+	return fmt.Sprintf("Token{AccessToken: <redacted>, Expiry: %s}", t.Expiry.Format(time.RFC3339))
+}
+
+// GoString returns the token with the access token redacted for the %#v verb
+func (t Token) GoString() string {
+	return t.String()
+}
+
+// TokenSource returns tokens used for authenticating API requests. Client calls Token before each request, including
+// retries, so implementations should cache tokens, see ReuseTokenSource.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc is an adapter that allows using a function as TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token implements TokenSource
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a token source that always returns the same token
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{AccessToken: token}, nil
+	})
+}
+
+// ReuseTokenSource returns a token source that caches the token from src and fetches a new one when the cached token
+// expires within expiryDelta. Zero expiryDelta defaults to DefaultTokenExpiryDelta. Concurrent requests share the
+// refresh.
+func ReuseTokenSource(src TokenSource, expiryDelta time.Duration) TokenSource {
+	if expiryDelta == 0 {
+		expiryDelta = DefaultTokenExpiryDelta
+	}
+	return &reuseTokenSource{src: src, expiryDelta: expiryDelta}
+}
+
+type reuseTokenSource struct {
+	mu          sync.Mutex
+	src         TokenSource
+	expiryDelta time.Duration
+	token       *Token
+}
+
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid(s.expiryDelta) {
+		return s.token, nil
+	}
+	t, err := s.src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || t.AccessToken == "" {
+		return nil, errors.New("token source returned an empty token")
+	}
+	s.token = t
+	return t, nil
+}
+
+// NewWithToken creates and returns a new client that authenticates with the specified API token instead of username and
+// password.
+func NewWithToken(token string, c ...ConfigFn) *Client {
+	return New("", "", append([]ConfigFn{WithTokenSource(StaticTokenSource(token))}, c...)...)
+}
+
+// WithTokenSource authenticates requests with bearer tokens from the token source instead of username and password.
+// Token is fetched separately for each request, so refreshed tokens are used also when retrying requests.
+func WithTokenSource(ts TokenSource) ConfigFn {
+	return func(c *config) {
+		c.tokenSource = ts
+	}
+}
+
+type tokenAuthKey struct{}
+
+// setBearerToken sets the Authorization header of the request to the current token of the token source
+func (c *Client) setBearerToken(r *http.Request) error {
+	t, err := c.config.tokenSource.Token(r.Context())
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Authorization", "Bearer "+t.AccessToken)
+	return nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReuseTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	expiry := time.Now().Add(time.Hour)
+	src := ReuseTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		calls++
+		return &Token{AccessToken: fmt.Sprintf("token-%d", calls), Expiry: expiry}, nil
+	}), 10*time.Minute)
+
+	tok, err := src.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tok.AccessToken)
+	tok, err = src.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tok.AccessToken)
+
+	// Token is refreshed before it expires
+	expiry = time.Now().Add(5 * time.Minute)
+	src.(*reuseTokenSource).token.Expiry = expiry
+	tok, err = src.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", tok.AccessToken)
+	assert.Equal(t, 2, calls)
+
+	_, err = ReuseTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return &Token{}, nil
+	}), 0).Token(context.Background())
+	assert.EqualError(t, err, "token source returned an empty token")
+}
+
+func TestClientTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		auth = append(auth, r.Header.Get("Authorization"))
+		if len(auth) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	var n int
+	ts := TokenSourceFunc(func(context.Context) (*Token, error) {
+		n++
+		return &Token{AccessToken: fmt.Sprintf("token-%d", n)}, nil
+	})
+	c := New("user", "pass", WithBaseURL(srv.URL), WithTokenSource(ts), WithRetry(1, ConstantBackoff{Interval: time.Millisecond}))
+
+	// Token is fetched for each attempt
+	_, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, auth)
+
+	// Explicit authorization header is not overridden
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/1.3/account", nil)
+	require.NoError(t, err)
+	r.Header.Set("Authorization", "Bearer custom")
+	_, err = c.Do(r)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer custom", auth[2])
+
+	// Token source errors are returned
+	c = New("", "", WithBaseURL(srv.URL), WithTokenSource(TokenSourceFunc(func(context.Context) (*Token, error) {
+		return nil, errors.New("token expired")
+	})))
+	_, err = c.Get(context.Background(), "/account")
+	assert.EqualError(t, err, "token expired")
+	assert.Len(t, auth, 3)
+}
+
+func TestNewWithToken(t *testing.T) {
+	t.Parallel()
+
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	c := NewWithToken("ucat_secret", WithBaseURL(srv.URL))
+	_, err := c.Get(context.Background(), "/account")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ucat_secret", auth)
+}
+
+func TestTokenRedacted(t *testing.T) {
+	t.Parallel()
+
+	token := Token{AccessToken: "ucat_secret"}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, token), "ucat_secret")
+		assert.NotContains(t, fmt.Sprintf(format, &token), "ucat_secret")
+	}
+	token.Expiry = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Token{AccessToken: <redacted>, Expiry: 2030-01-01T00:00:00Z}", token.String())
+}
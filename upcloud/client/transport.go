@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// defaultDialTimeout is the connection timeout of the default transport
+const defaultDialTimeout = 30 * time.Second
+
+// WithKeepAlives enables or disables HTTP keep-alives, i.e. reusing the connections to the API between requests. The
+// default transport disables them, so that each request opens a new connection. The option, like the other transport
+// options, applies to a copy of the default transport or the *http.Transport set with WithHTTPClient or WithTransport,
+// regardless of the order of the options, see New.
+func WithKeepAlives(enabled bool) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.DisableKeepAlives = !enabled
+	})
+}
+
+// WithTCPKeepAlive sets the interval between the TCP keep-alive probes of the connections to the API. Zero uses the
+// net package default of 15 seconds and a negative value disables the probes.
+func WithTCPKeepAlive(interval time.Duration) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.DialContext = (&net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: interval,
+		}).DialContext
+	})
+}
+
+// WithMaxIdleConns limits the number of idle connections kept for reuse in total and per host. Zero means no limit
+// in total and the net/http default of two per host. Idle connections are kept only if keep-alives are enabled, see
+// WithKeepAlives.
+func WithMaxIdleConns(total, perHost int) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.MaxIdleConns = total
+		t.MaxIdleConnsPerHost = perHost
+	})
+}
+
+// WithMaxConnsPerHost limits the number of connections per host, including the connections in use. Requests wait for
+// a connection once the limit has been reached. Zero means no limit.
+func WithMaxConnsPerHost(n int) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.MaxConnsPerHost = n
+	})
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept for reuse before it is closed. Zero means no limit.
+func WithIdleConnTimeout(timeout time.Duration) ConfigFn {
+	return withHTTPTransport(func(t *http.Transport) {
+		t.IdleConnTimeout = timeout
+	})
+}
+
+// withHTTPTransport adds fn to the options applied to the transport of the client's httpClient once all other options
+// have been applied, see applyTransportOptions
+func withHTTPTransport(fn func(t *http.Transport)) ConfigFn {
+	return func(c *config) {
+		c.transportOptions = append(c.transportOptions, fn)
+	}
+}
+
+// applyTransportOptions applies the transport options to a clone of the transport of the client's httpClient, so that
+// the options apply regardless of their order relative to WithHTTPClient and WithTransport, and the transport and
+// httpClient given by the caller are not modified. A nil transport is replaced with a clone of http.DefaultTransport.
+// The options can only be applied to a *http.Transport; New panics for other transports.
+func (c *config) applyTransportOptions() {
+	if len(c.transportOptions) == 0 {
+		return
+	}
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t, ok := transport.(*http.Transport)
+	if !ok {
+		panic(fmt.Sprintf("client: transport options require a *http.Transport transport, got %T", transport))
+	}
+	t = t.Clone()
+	for _, fn := range c.transportOptions {
+		fn(t)
+	}
+	httpClient := *c.httpClient
+	httpClient.Transport = t
+	c.httpClient = &httpClient
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTransportOptions(t *testing.T) {
+	t.Parallel()
+
+	c := New("", "",
+		WithKeepAlives(true),
+		WithTCPKeepAlive(time.Minute),
+		WithMaxIdleConns(50, 10),
+		WithMaxConnsPerHost(20),
+		WithIdleConnTimeout(time.Minute),
+	)
+	transport, ok := c.config.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.False(t, transport.DisableKeepAlives)
+	assert.NotNil(t, transport.DialContext)
+	assert.Equal(t, 50, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 20, transport.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+
+	// Options can not be applied to other transports
+	assert.Panics(t, func() {
+		New("", "", WithTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })), WithKeepAlives(true))
+	})
+}
+
+func TestClientTransportOptions_httpClient(t *testing.T) {
+	t.Parallel()
+
+	// Transport options apply to a copy of the transport of the HTTP client, even if set before it
+	transport := &http.Transport{MaxConnsPerHost: 5}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+	c := New("", "", WithMaxConnsPerHost(20), WithProxy(nil), WithHTTPClient(httpClient))
+	applied, ok := c.config.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 20, applied.MaxConnsPerHost)
+	assert.Equal(t, time.Minute, c.config.httpClient.Timeout)
+	assert.Equal(t, 5, transport.MaxConnsPerHost)
+	assert.Nil(t, transport.Proxy)
+	assert.Same(t, transport, httpClient.Transport)
+
+	// A nil transport is replaced with a copy of the default transport
+	c = New("", "", WithHTTPClient(&http.Client{}), WithMaxConnsPerHost(20))
+	applied, ok = c.config.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 20, applied.MaxConnsPerHost)
+	assert.NotSame(t, http.DefaultTransport, applied)
+}
+
+func TestClientWithKeepAlives(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		keepAlives bool
+		conns      int32
+	}{
+		{false, 3},
+		{true, 1},
+	} {
+		var conns atomic.Int32
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				conns.Add(1)
+			}
+		}
+		srv.Start()
+
+		c := New("", "", WithBaseURL(srv.URL), WithKeepAlives(test.keepAlives), WithMaxIdleConns(10, 10))
+		for i := 0; i < 3; i++ {
+			_, err := c.Get(context.Background(), "/account")
+			require.NoError(t, err)
+		}
+		srv.Close()
+		assert.Equal(t, test.conns, conns.Load(), "keep-alives %t", test.keepAlives)
+	}
+}
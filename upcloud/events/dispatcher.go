@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Handler handles an event. Returning an error causes the event to be delivered again on the next poll, so handlers
+// should be idempotent.
+type Handler func(ctx context.Context, e Event) error
+
+// Filter selects the events a handler is subscribed to. Zero value fields match all events.
+type Filter struct {
+	EventTypes    []EventType
+	ResourceTypes []ResourceType
+	Zone          string
+	// Tags that the resource must have
+	Tags []string
+	// Labels that the resource must have with the specified values
+	Labels map[string]string
+}
+
+// Matches reports whether the event matches the filter
+func (f Filter) Matches(e Event) bool {
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, e.Type) {
+		return false
+	}
+	if len(f.ResourceTypes) > 0 && !slices.Contains(f.ResourceTypes, e.Resource.Type) {
+		return false
+	}
+	if f.Zone != "" && f.Zone != e.Resource.Zone {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(e.Resource.Tags, tag) {
+			return false
+		}
+	}
+	for k, v := range f.Labels {
+		if value, ok := e.Resource.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// HandlerPanicError is returned when a handler panics while handling an event
+type HandlerPanicError struct {
+	Event Event
+	Value any
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("events: handler panicked while handling %s event of %s: %v", e.Event.Type, e.Event.Resource.Key(), e.Value)
+}
+
+type subscription struct {
+	filter  Filter
+	handler Handler
+}
+
+// Dispatcher delivers events to the handlers subscribed to them
+type Dispatcher struct {
+	mu            sync.RWMutex
+	subscriptions map[int]subscription
+	nextID        int
+}
+
+// NewDispatcher returns a dispatcher without subscriptions
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{subscriptions: make(map[int]subscription)}
+}
+
+// Subscribe registers handler for the events matching filter. The returned function removes the subscription.
+func (d *Dispatcher) Subscribe(filter Filter, handler Handler) func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := d.nextID
+	d.nextID++
+	d.subscriptions[id] = subscription{filter: filter, handler: handler}
+	return func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		delete(d.subscriptions, id)
+	}
+}
+
+// Dispatch delivers the event to all matching handlers. Panics are recovered per handler, so a failing handler does
+// not prevent the delivery to other handlers. The errors of the failed handlers are returned joined.
+func (d *Dispatcher) Dispatch(ctx context.Context, e Event) error {
+	d.mu.RLock()
+	ids := make([]int, 0, len(d.subscriptions))
+	for id := range d.subscriptions {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	subscriptions := make([]subscription, 0, len(ids))
+	for _, id := range ids {
+		if s := d.subscriptions[id]; s.filter.Matches(e) {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscriptions {
+		if err := handle(ctx, s.handler, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run polls the resources with poller until the context is done and dispatches the events to the subscribed
+// handlers. The snapshot is saved only after all handlers have handled the events of the poll successfully, which
+// gives at-least-once delivery: if any handler fails, the events of the poll are delivered again on the next poll.
+// Errors are passed to the error handler of the poller. Run returns when the context is done.
+func (d *Dispatcher) Run(ctx context.Context, poller *Poller) error {
+	ticker := time.NewTicker(poller.config.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.runOnce(ctx, poller); err != nil && ctx.Err() == nil && poller.config.errorHandler != nil {
+			poller.config.errorHandler(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *Dispatcher) runOnce(ctx context.Context, poller *Poller) error {
+	events, current, err := poller.diff(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, e := range events {
+		if err := d.Dispatch(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return poller.config.store.Save(ctx, current)
+}
+
+func handle(ctx context.Context, h Handler, e Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &HandlerPanicError{Event: e, Value: r}
+		}
+	}()
+	return h(ctx, e)
+}
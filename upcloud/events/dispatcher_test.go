@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	e := Event{
+		Type: EventTypeCreated,
+		Resource: Resource{
+			Type:   ResourceTypeServer,
+			ID:     "a",
+			Zone:   "fi-hel1",
+			Tags:   []string{"web", "prod"},
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+
+	assert.True(t, Filter{}.Matches(e))
+	assert.True(t, Filter{EventTypes: []EventType{EventTypeCreated, EventTypeDeleted}}.Matches(e))
+	assert.False(t, Filter{EventTypes: []EventType{EventTypeDeleted}}.Matches(e))
+	assert.True(t, Filter{ResourceTypes: []ResourceType{ResourceTypeServer}}.Matches(e))
+	assert.False(t, Filter{ResourceTypes: []ResourceType{ResourceTypeStorage}}.Matches(e))
+	assert.True(t, Filter{Zone: "fi-hel1"}.Matches(e))
+	assert.False(t, Filter{Zone: "de-fra1"}.Matches(e))
+	assert.True(t, Filter{Tags: []string{"prod"}}.Matches(e))
+	assert.False(t, Filter{Tags: []string{"prod", "db"}}.Matches(e))
+	assert.True(t, Filter{Labels: map[string]string{"env": "prod"}}.Matches(e))
+	assert.False(t, Filter{Labels: map[string]string{"env": "dev"}}.Matches(e))
+	assert.False(t, Filter{Labels: map[string]string{"team": ""}}.Matches(e))
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher()
+	var created, all []string
+	d.Subscribe(Filter{EventTypes: []EventType{EventTypeCreated}}, func(_ context.Context, e Event) error {
+		created = append(created, e.Resource.ID)
+		return nil
+	})
+	d.Subscribe(Filter{}, func(_ context.Context, e Event) error {
+		panic("boom")
+	})
+	unsubscribe := d.Subscribe(Filter{}, func(_ context.Context, e Event) error {
+		all = append(all, e.Resource.ID)
+		return nil
+	})
+
+	err := d.Dispatch(context.Background(), Event{Type: EventTypeCreated, Resource: Resource{Type: ResourceTypeServer, ID: "a"}})
+	var panicErr *HandlerPanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, []string{"a"}, created)
+	assert.Equal(t, []string{"a"}, all)
+
+	unsubscribe()
+	_ = d.Dispatch(context.Background(), Event{Type: EventTypeDeleted, Resource: Resource{Type: ResourceTypeServer, ID: "b"}})
+	assert.Equal(t, []string{"a"}, created)
+	assert.Equal(t, []string{"a"}, all)
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{servers: []upcloud.Server{{UUID: "a", Zone: "fi-hel1"}}}
+	store := NewMemoryStore()
+	require.NoError(t, store.Save(context.Background(), Snapshot{}))
+
+	var mu sync.Mutex
+	var deliveries int
+	fail := true
+	done := make(chan struct{})
+
+	d := NewDispatcher()
+	d.Subscribe(Filter{Zone: "fi-hel1"}, func(_ context.Context, e Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		deliveries++
+		if fail {
+			fail = false
+			return errors.New("temporary failure")
+		}
+		close(done)
+		return nil
+	})
+
+	var errs []error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	poller := NewPoller(source, WithStore(store), WithInterval(time.Millisecond), WithErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	result := make(chan error)
+	go func() {
+		result <- d.Run(ctx, poller)
+	}()
+
+	<-done
+	cancel()
+	assert.ErrorIs(t, <-result, context.Canceled)
+
+	// Failed event is delivered again and the snapshot is saved after successful delivery
+	assert.Equal(t, 2, deliveries)
+	assert.Len(t, errs, 1)
+	s, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, s, 1)
+}
@@ -0,0 +1,137 @@
+// Package events polls the account resources and emits typed events when resources are created, deleted or change
+// their state. The poller compares the current resources with the last seen snapshot, which can be persisted with a
+// custom Store to avoid missing changes over restarts.
+package events
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+// ResourceType is the type of resource an event is about
+type ResourceType string
+
+const (
+	ResourceTypeServer    ResourceType = "server"
+	ResourceTypeStorage   ResourceType = "storage"
+	ResourceTypeIPAddress ResourceType = "ip_address"
+)
+
+// EventType is the kind of change an event represents
+type EventType string
+
+const (
+	EventTypeCreated      EventType = "created"
+	EventTypeDeleted      EventType = "deleted"
+	EventTypeStateChanged EventType = "state_changed"
+)
+
+// Resource is the state of a resource as seen by the poller
+type Resource struct {
+	Type ResourceType `json:"type"`
+	// ID is the UUID of the resource or the address of an IP address
+	ID     string            `json:"id"`
+	Title  string            `json:"title,omitempty"`
+	Zone   string            `json:"zone,omitempty"`
+	State  string            `json:"state,omitempty"`
+	Tags   []string          `json:"tags,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Key returns the key identifying the resource in a snapshot
+func (r Resource) Key() string {
+	return fmt.Sprintf("%s/%s", r.Type, r.ID)
+}
+
+// Event represents a change of a resource
+type Event struct {
+	Type EventType
+	// Resource is the current state of the resource, or the last seen state of a deleted resource
+	Resource Resource
+	// Previous is the last seen state of a resource whose state changed
+	Previous *Resource
+	Time     time.Time
+}
+
+// Snapshot contains the resources seen by the poller mapped by their keys
+type Snapshot map[string]Resource
+
+// Add adds the resource to the snapshot
+func (s Snapshot) Add(r Resource) {
+	s[r.Key()] = r
+}
+
+// Diff returns the events that have happened between the previous and the current snapshot. Events are sorted by
+// resource type and ID.
+func Diff(previous, current Snapshot, now time.Time) []Event {
+	events := make([]Event, 0)
+	for key, cur := range current {
+		prev, ok := previous[key]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventTypeCreated, Resource: cur, Time: now})
+		case prev.State != cur.State:
+			prev := prev
+			events = append(events, Event{Type: EventTypeStateChanged, Resource: cur, Previous: &prev, Time: now})
+		}
+	}
+	for key, prev := range previous {
+		if _, ok := current[key]; !ok {
+			events = append(events, Event{Type: EventTypeDeleted, Resource: prev, Time: now})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Resource.Key() < events[j].Resource.Key()
+	})
+	return events
+}
+
+// ServerResource returns the server as a resource
+func ServerResource(s upcloud.Server) Resource {
+	return Resource{
+		Type:   ResourceTypeServer,
+		ID:     s.UUID,
+		Title:  s.Title,
+		Zone:   s.Zone,
+		State:  s.State,
+		Tags:   slices.Clone([]string(s.Tags)),
+		Labels: labelMap(s.Labels),
+	}
+}
+
+// StorageResource returns the storage as a resource
+func StorageResource(s upcloud.Storage) Resource {
+	return Resource{
+		Type:   ResourceTypeStorage,
+		ID:     s.UUID,
+		Title:  s.Title,
+		Zone:   s.Zone,
+		State:  s.State,
+		Labels: labelMap(s.Labels),
+	}
+}
+
+// IPAddressResource returns the IP address as a resource
+func IPAddressResource(ip upcloud.IPAddress) Resource {
+	return Resource{
+		Type: ResourceTypeIPAddress,
+		ID:   ip.Address,
+		Zone: ip.Zone,
+	}
+}
+
+func labelMap(labels []upcloud.Label) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(labels))
+	for _, l := range labels {
+		m[l.Key] = l.Value
+	}
+	return m
+}
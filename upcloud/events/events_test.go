@@ -0,0 +1,53 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	previous := Snapshot{}
+	previous.Add(Resource{Type: ResourceTypeServer, ID: "a", State: upcloud.ServerStateStarted})
+	previous.Add(Resource{Type: ResourceTypeServer, ID: "b", State: upcloud.ServerStateStarted})
+	previous.Add(Resource{Type: ResourceTypeStorage, ID: "c", State: upcloud.StorageStateOnline})
+
+	current := Snapshot{}
+	current.Add(Resource{Type: ResourceTypeServer, ID: "a", State: upcloud.ServerStateStopped})
+	current.Add(Resource{Type: ResourceTypeStorage, ID: "c", State: upcloud.StorageStateOnline})
+	current.Add(Resource{Type: ResourceTypeIPAddress, ID: "10.0.0.1"})
+
+	events := Diff(previous, current, now)
+	assert.Equal(t, []Event{
+		{Type: EventTypeCreated, Resource: Resource{Type: ResourceTypeIPAddress, ID: "10.0.0.1"}, Time: now},
+		{
+			Type:     EventTypeStateChanged,
+			Resource: Resource{Type: ResourceTypeServer, ID: "a", State: upcloud.ServerStateStopped},
+			Previous: &Resource{Type: ResourceTypeServer, ID: "a", State: upcloud.ServerStateStarted},
+			Time:     now,
+		},
+		{Type: EventTypeDeleted, Resource: Resource{Type: ResourceTypeServer, ID: "b", State: upcloud.ServerStateStarted}, Time: now},
+	}, events)
+
+	assert.Empty(t, Diff(current, current, now))
+}
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+
+	server := ServerResource(upcloud.Server{UUID: "a", Title: "t", Zone: "fi-hel1", State: "started", Tags: upcloud.ServerTagSlice{"x"}})
+	assert.Equal(t, Resource{Type: ResourceTypeServer, ID: "a", Title: "t", Zone: "fi-hel1", State: "started", Tags: []string{"x"}}, server)
+	assert.Equal(t, "server/a", server.Key())
+
+	storage := StorageResource(upcloud.Storage{UUID: "b", State: "online", Labels: []upcloud.Label{{Key: "k", Value: "v"}}})
+	assert.Equal(t, map[string]string{"k": "v"}, storage.Labels)
+
+	ip := IPAddressResource(upcloud.IPAddress{Address: "10.0.0.1", Zone: "fi-hel1"})
+	assert.Equal(t, "ip_address/10.0.0.1", ip.Key())
+}
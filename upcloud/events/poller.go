@@ -0,0 +1,183 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// DefaultInterval is the default interval between polls
+const DefaultInterval = time.Minute
+
+// Source is the subset of the service used for listing the account resources
+type Source interface {
+	GetServers(ctx context.Context) (*upcloud.Servers, error)
+	GetStorages(ctx context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error)
+	GetIPAddresses(ctx context.Context) (*upcloud.IPAddresses, error)
+}
+
+type config struct {
+	interval      time.Duration
+	store         Store
+	resourceTypes []ResourceType
+	errorHandler  func(error)
+}
+
+type ConfigFn func(c *config)
+
+// WithInterval sets the interval between polls
+func WithInterval(interval time.Duration) ConfigFn {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithStore sets the store used to persist the last seen snapshot. Defaults to an in-memory store.
+func WithStore(store Store) ConfigFn {
+	return func(c *config) {
+		c.store = store
+	}
+}
+
+// WithResourceTypes limits the polled resources to the specified types. By default, all resource types are polled.
+func WithResourceTypes(types ...ResourceType) ConfigFn {
+	return func(c *config) {
+		c.resourceTypes = types
+	}
+}
+
+// WithErrorHandler sets a function that is called with the errors encountered by Run
+func WithErrorHandler(fn func(error)) ConfigFn {
+	return func(c *config) {
+		c.errorHandler = fn
+	}
+}
+
+// Poller periodically lists the account resources and emits events for the changes since the previous poll
+type Poller struct {
+	source Source
+	config config
+}
+
+// NewPoller returns a new poller that lists the resources from source
+func NewPoller(source Source, c ...ConfigFn) *Poller {
+	p := &Poller{
+		source: source,
+		config: config{
+			interval:      DefaultInterval,
+			store:         NewMemoryStore(),
+			resourceTypes: []ResourceType{ResourceTypeServer, ResourceTypeStorage, ResourceTypeIPAddress},
+		},
+	}
+	for _, fn := range c {
+		fn(&p.config)
+	}
+	return p
+}
+
+// Poll lists the resources once and returns the events since the last saved snapshot. The first poll without a saved
+// snapshot only saves the current resources as the baseline and returns no events.
+func (p *Poller) Poll(ctx context.Context) ([]Event, error) {
+	events, current, err := p.diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, p.config.store.Save(ctx, current)
+}
+
+// diff returns the events since the last saved snapshot and the current snapshot without saving it
+func (p *Poller) diff(ctx context.Context) ([]Event, Snapshot, error) {
+	current, err := p.Snapshot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := p.config.store.Load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if previous == nil {
+		return nil, current, nil
+	}
+	return Diff(previous, current, time.Now()), current, nil
+}
+
+// Snapshot lists the current resources
+func (p *Poller) Snapshot(ctx context.Context) (Snapshot, error) {
+	s := make(Snapshot)
+	for _, t := range p.config.resourceTypes {
+		switch t {
+		case ResourceTypeServer:
+			servers, err := p.source.GetServers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, server := range servers.Servers {
+				s.Add(ServerResource(server))
+			}
+		case ResourceTypeStorage:
+			storages, err := p.source.GetStorages(ctx, &request.GetStoragesRequest{Access: upcloud.StorageAccessPrivate})
+			if err != nil {
+				return nil, err
+			}
+			for _, storage := range storages.Storages {
+				s.Add(StorageResource(storage))
+			}
+		case ResourceTypeIPAddress:
+			ips, err := p.source.GetIPAddresses(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, ip := range ips.IPAddresses {
+				s.Add(IPAddressResource(ip))
+			}
+		}
+	}
+	return s, nil
+}
+
+// Run polls the resources every interval until the context is done and sends the events to the returned channel.
+// The channel is closed when the context is done. The snapshot is saved only after all events of a poll have been
+// received, so events that could not be delivered are sent again on the next poll. Errors are passed to the error
+// handler and the failed poll is retried on the next interval.
+func (p *Poller) Run(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(p.config.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := p.runOnce(ctx, ch); err != nil && ctx.Err() == nil && p.config.errorHandler != nil {
+				p.config.errorHandler(err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (p *Poller) runOnce(ctx context.Context, ch chan<- Event) error {
+	events, current, err := p.diff(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return p.config.store.Save(ctx, current)
+}
@@ -0,0 +1,157 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+type fakeSource struct {
+	mu       sync.Mutex
+	servers  []upcloud.Server
+	storages []upcloud.Storage
+	ips      []upcloud.IPAddress
+	err      error
+}
+
+func (f *fakeSource) GetServers(context.Context) (*upcloud.Servers, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &upcloud.Servers{Servers: append([]upcloud.Server(nil), f.servers...)}, f.err
+}
+
+func (f *fakeSource) GetStorages(_ context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Access != upcloud.StorageAccessPrivate {
+		return nil, errors.New("expected private storages to be requested")
+	}
+	return &upcloud.Storages{Storages: append([]upcloud.Storage(nil), f.storages...)}, f.err
+}
+
+func (f *fakeSource) GetIPAddresses(context.Context) (*upcloud.IPAddresses, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &upcloud.IPAddresses{IPAddresses: append([]upcloud.IPAddress(nil), f.ips...)}, f.err
+}
+
+func (f *fakeSource) setServers(servers ...upcloud.Server) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.servers = servers
+}
+
+func TestPoller_Poll(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{
+		servers:  []upcloud.Server{{UUID: "a", State: upcloud.ServerStateStarted}},
+		storages: []upcloud.Storage{{UUID: "b", State: upcloud.StorageStateOnline}},
+		ips:      []upcloud.IPAddress{{Address: "10.0.0.1"}},
+	}
+	p := NewPoller(source)
+	ctx := context.Background()
+
+	// First poll is the baseline
+	events, err := p.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	source.setServers(upcloud.Server{UUID: "a", State: upcloud.ServerStateStopped}, upcloud.Server{UUID: "c", State: upcloud.ServerStateStarted})
+	source.storages = nil
+
+	events, err = p.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, EventTypeStateChanged, events[0].Type)
+	assert.Equal(t, "a", events[0].Resource.ID)
+	assert.Equal(t, upcloud.ServerStateStarted, events[0].Previous.State)
+	assert.Equal(t, EventTypeCreated, events[1].Type)
+	assert.Equal(t, "c", events[1].Resource.ID)
+	assert.Equal(t, EventTypeDeleted, events[2].Type)
+	assert.Equal(t, ResourceTypeStorage, events[2].Resource.Type)
+
+	events, err = p.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestPoller_resourceTypes(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{
+		servers: []upcloud.Server{{UUID: "a"}},
+		ips:     []upcloud.IPAddress{{Address: "10.0.0.1"}},
+	}
+	s, err := NewPoller(source, WithResourceTypes(ResourceTypeIPAddress)).Snapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Snapshot{"ip_address/10.0.0.1": {Type: ResourceTypeIPAddress, ID: "10.0.0.1"}}, s)
+}
+
+func TestPoller_Run(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{servers: []upcloud.Server{{UUID: "a", State: upcloud.ServerStateStarted}}}
+	store := NewMemoryStore()
+	require.NoError(t, store.Save(context.Background(), Snapshot{}))
+
+	var errs []error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := NewPoller(source, WithStore(store), WithInterval(time.Millisecond), WithErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})).Run(ctx)
+
+	e := <-ch
+	assert.Equal(t, EventTypeCreated, e.Type)
+	assert.Equal(t, "a", e.Resource.ID)
+
+	source.setServers()
+	e = <-ch
+	assert.Equal(t, EventTypeDeleted, e.Type)
+
+	cancel()
+	for range ch {
+	}
+	assert.Empty(t, errs)
+}
+
+func TestPoller_RunUndelivered(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{servers: []upcloud.Server{{UUID: "a"}}}
+	store := NewMemoryStore()
+	require.NoError(t, store.Save(context.Background(), Snapshot{}))
+
+	// Stop before the event is received, so that the snapshot is not saved
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewPoller(source, WithStore(store), WithInterval(time.Hour)).Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	for range ch {
+	}
+
+	s, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, s)
+
+	events, err := NewPoller(source, WithStore(store)).Poll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestPoller_error(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{err: errors.New("boom")}
+	_, err := NewPoller(source).Poll(context.Background())
+	assert.EqualError(t, err, "boom")
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// Store persists the last seen snapshot between polls
+type Store interface {
+	// Load returns the last saved snapshot, or nil if no snapshot has been saved
+	Load(ctx context.Context) (Snapshot, error)
+	// Save replaces the saved snapshot
+	Save(ctx context.Context, s Snapshot) error
+}
+
+// MemoryStore keeps the last seen snapshot in memory
+type MemoryStore struct {
+	mu       sync.Mutex
+	snapshot Snapshot
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Load returns a copy of the saved snapshot
+func (m *MemoryStore) Load(context.Context) (Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return maps.Clone(m.snapshot), nil
+}
+
+// Save stores a copy of the snapshot
+func (m *MemoryStore) Save(_ context.Context, s Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.snapshot = maps.Clone(s)
+	return nil
+}
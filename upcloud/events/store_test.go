@@ -0,0 +1,30 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	s, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	saved := Snapshot{}
+	saved.Add(Resource{Type: ResourceTypeServer, ID: "a"})
+	require.NoError(t, store.Save(ctx, saved))
+
+	// Modifying the saved snapshot does not modify the store
+	saved.Add(Resource{Type: ResourceTypeServer, ID: "b"})
+	s, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, s, 1)
+}
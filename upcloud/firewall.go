@@ -1,6 +1,11 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
 
 // Constants
 const (
@@ -38,7 +43,9 @@ func (s *FirewallRules) UnmarshalJSON(b []byte) error {
 	}
 
 	for _, f := range v.FirewallRules.FirewallRules {
-		s.FirewallRules = append(s.FirewallRules, FirewallRule(f))
+		rule := FirewallRule(f)
+		rule.parsePorts()
+		s.FirewallRules = append(s.FirewallRules, rule)
 	}
 
 	return nil
@@ -61,6 +68,26 @@ type FirewallRule struct {
 	SourceAddressEnd        string `json:"source_address_end,omitempty"`
 	SourcePortStart         string `json:"source_port_start,omitempty"`
 	SourcePortEnd           string `json:"source_port_end,omitempty"`
+
+	// DestinationPorts and SourcePorts are the typed port ranges of the rule; nil means that the range is not set.
+	// They are filled when rules are read from the API. When set, they take precedence over the port string fields,
+	// which are kept for compatibility.
+	DestinationPorts *PortRange `json:"-"`
+	SourcePorts      *PortRange `json:"-"`
+}
+
+// PortRange is an inclusive range of TCP or UDP ports
+type PortRange struct {
+	Start int
+	End   int
+}
+
+// NewPortRange returns a port range from start to end. Zero end means a range of the start port only.
+func NewPortRange(start, end int) *PortRange {
+	if end == 0 {
+		end = start
+	}
+	return &PortRange{Start: start, End: end}
 }
 
 // UnmarshalJSON is a custom unmarshaller that deals with
@@ -77,6 +104,152 @@ func (s *FirewallRule) UnmarshalJSON(b []byte) error {
 	}
 
 	(*s) = FirewallRule(v.FirewallRule)
+	s.parsePorts()
+
+	return nil
+}
+
+// MarshalJSON is a custom marshaller that writes the typed port ranges, if set, into the port fields.
+func (s FirewallRule) MarshalJSON() ([]byte, error) {
+	type localFirewallRule FirewallRule
+	return json.Marshal(localFirewallRule(s.withPortStrings()))
+}
+
+// SetDestinationPorts sets the destination port range of the rule. Zero start port unsets the range.
+func (s *FirewallRule) SetDestinationPorts(start, end int) {
+	s.DestinationPorts = nil
+	if start != 0 {
+		s.DestinationPorts = NewPortRange(start, end)
+	}
+	s.DestinationPortStart, s.DestinationPortEnd = formatPortRange(s.DestinationPorts)
+}
+
+// SetSourcePorts sets the source port range of the rule. Zero start port unsets the range.
+func (s *FirewallRule) SetSourcePorts(start, end int) {
+	s.SourcePorts = nil
+	if start != 0 {
+		s.SourcePorts = NewPortRange(start, end)
+	}
+	s.SourcePortStart, s.SourcePortEnd = formatPortRange(s.SourcePorts)
+}
+
+// parsePorts fills the typed port ranges from the port fields
+func (s *FirewallRule) parsePorts() {
+	s.DestinationPorts = parsePortRange(s.DestinationPortStart, s.DestinationPortEnd)
+	s.SourcePorts = parsePortRange(s.SourcePortStart, s.SourcePortEnd)
+}
+
+// withPortStrings returns a copy of the rule with the typed port ranges, if set, written into the port fields
+func (s FirewallRule) withPortStrings() FirewallRule {
+	if s.DestinationPorts != nil {
+		s.DestinationPortStart, s.DestinationPortEnd = formatPortRange(s.DestinationPorts)
+	}
+	if s.SourcePorts != nil {
+		s.SourcePortStart, s.SourcePortEnd = formatPortRange(s.SourcePorts)
+	}
+	return s
+}
+
+// Validate checks that the rule is something the API accepts and interprets as intended: ports are only used with
+// TCP and UDP, ICMP type only with ICMP, and port ranges and ICMP types are within their valid ranges. The rules are
+// not validated automatically when they are sent to the API; call Validate to catch mistakes before sending.
+func (s *FirewallRule) Validate() error {
+	var errs []error
+	r := s.withPortStrings()
 
+	switch r.Action {
+	case FirewallRuleActionAccept, FirewallRuleActionReject, FirewallRuleActionDrop:
+	default:
+		errs = append(errs, fmt.Errorf("invalid action %q", r.Action))
+	}
+
+	switch r.Direction {
+	case FirewallRuleDirectionIn, FirewallRuleDirectionOut:
+	default:
+		errs = append(errs, fmt.Errorf("invalid direction %q", r.Direction))
+	}
+
+	switch r.Family {
+	case "", IPAddressFamilyIPv4, IPAddressFamilyIPv6:
+	default:
+		errs = append(errs, fmt.Errorf("invalid family %q", r.Family))
+	}
+
+	hasPorts := r.DestinationPortStart != "" || r.DestinationPortEnd != "" || r.SourcePortStart != "" || r.SourcePortEnd != ""
+	switch r.Protocol {
+	case FirewallRuleProtocolTCP, FirewallRuleProtocolUDP:
+		if r.ICMPType != "" {
+			errs = append(errs, fmt.Errorf("ICMP type cannot be used with protocol %s", r.Protocol))
+		}
+		if err := validatePortRange("destination", r.DestinationPortStart, r.DestinationPortEnd); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validatePortRange("source", r.SourcePortStart, r.SourcePortEnd); err != nil {
+			errs = append(errs, err)
+		}
+	case FirewallRuleProtocolICMP:
+		if hasPorts {
+			errs = append(errs, errors.New("ports cannot be used with protocol icmp"))
+		}
+		if r.ICMPType != "" {
+			if v, err := strconv.Atoi(r.ICMPType); err != nil || v < 0 || v > 255 {
+				errs = append(errs, fmt.Errorf("invalid ICMP type %q", r.ICMPType))
+			}
+		}
+	case "":
+		if hasPorts {
+			errs = append(errs, errors.New("ports require protocol tcp or udp"))
+		}
+		if r.ICMPType != "" {
+			errs = append(errs, errors.New("ICMP type requires protocol icmp"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid protocol %q", r.Protocol))
+	}
+
+	if r.Protocol != "" && r.Family == "" {
+		errs = append(errs, fmt.Errorf("protocol %s requires family", r.Protocol))
+	}
+
+	return errors.Join(errs...)
+}
+
+func formatPortRange(p *PortRange) (string, string) {
+	if p == nil {
+		return "", ""
+	}
+	return strconv.Itoa(p.Start), strconv.Itoa(p.End)
+}
+
+// parsePortRange returns the port range of the port fields, or nil if the range is not set or is not numeric
+func parsePortRange(start, end string) *PortRange {
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return nil
+	}
+	if end == "" {
+		return NewPortRange(s, s)
+	}
+	e, err := strconv.Atoi(end)
+	if err != nil {
+		return nil
+	}
+	return NewPortRange(s, e)
+}
+
+func validatePortRange(name, start, end string) error {
+	if start == "" && end == "" {
+		return nil
+	}
+	if start == "" {
+		return fmt.Errorf("%s port end requires %s port start", name, name)
+	}
+	p := parsePortRange(start, end)
+	if p == nil || p.Start < 1 || p.Start > 65535 || p.End < 1 || p.End > 65535 {
+		return fmt.Errorf("invalid %s port range %q-%q", name, start, end)
+	}
+	if p.End < p.Start {
+		return fmt.Errorf("%s port end %d is before start %d", name, p.End, p.Start)
+	}
 	return nil
 }
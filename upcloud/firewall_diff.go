@@ -0,0 +1,80 @@
+package upcloud
+
+import "fmt"
+
+// FirewallRulesDiff describes the changes needed to turn live firewall rules into desired rules. Rules are matched by
+// their Comment field, which works as a stable key of the rule.
+type FirewallRulesDiff struct {
+	// Added are the desired rules that do not exist
+	Added []FirewallRule
+	// Removed are the live rules that are not desired
+	Removed []FirewallRule
+	// Changed are the desired rules whose live counterparts differ
+	Changed []FirewallRule
+	// Reordered is set when the rules that exist in both sets are in a different order
+	Reordered bool
+}
+
+// Empty returns true if the live rules already match the desired rules
+func (d *FirewallRulesDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && !d.Reordered
+}
+
+// DiffFirewallRules compares the live rules to the desired rules using the rule comments as keys. Positions of the
+// rules are ignored, only their order matters. Desired rules must have unique, non-empty comments.
+func DiffFirewallRules(live, desired []FirewallRule) (*FirewallRulesDiff, error) {
+	desiredByComment := make(map[string]FirewallRule, len(desired))
+	for i, rule := range desired {
+		if rule.Comment == "" {
+			return nil, fmt.Errorf("desired firewall rule %d has no comment", i+1)
+		}
+		if _, ok := desiredByComment[rule.Comment]; ok {
+			return nil, fmt.Errorf("desired firewall rules have duplicate comment %q", rule.Comment)
+		}
+		desiredByComment[rule.Comment] = rule
+	}
+
+	diff := &FirewallRulesDiff{}
+	liveByComment := make(map[string]FirewallRule, len(live))
+	var liveOrder []string
+	for _, rule := range live {
+		_, desired := desiredByComment[rule.Comment]
+		_, seen := liveByComment[rule.Comment]
+		if !desired || seen {
+			diff.Removed = append(diff.Removed, rule)
+			continue
+		}
+		liveByComment[rule.Comment] = rule
+		liveOrder = append(liveOrder, rule.Comment)
+	}
+
+	var desiredOrder []string
+	for _, rule := range desired {
+		current, ok := liveByComment[rule.Comment]
+		if !ok {
+			diff.Added = append(diff.Added, rule)
+			continue
+		}
+		desiredOrder = append(desiredOrder, rule.Comment)
+		if !equalFirewallRules(current, rule) {
+			diff.Changed = append(diff.Changed, rule)
+		}
+	}
+	for i := range liveOrder {
+		if liveOrder[i] != desiredOrder[i] {
+			diff.Reordered = true
+			break
+		}
+	}
+	return diff, nil
+}
+
+// equalFirewallRules compares the rules ignoring their positions. Typed port ranges are compared through the port
+// fields they are sent as.
+func equalFirewallRules(a, b FirewallRule) bool {
+	a, b = a.withPortStrings(), b.withPortStrings()
+	a.Position, b.Position = 0, 0
+	a.DestinationPorts, b.DestinationPorts = nil, nil
+	a.SourcePorts, b.SourcePorts = nil, nil
+	return a == b
+}
@@ -0,0 +1,45 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFirewallRules(t *testing.T) {
+	ssh := FirewallRule{Action: FirewallRuleActionAccept, Direction: FirewallRuleDirectionIn, DestinationPortStart: "22", DestinationPortEnd: "22", Comment: "SSH"}
+	web := FirewallRule{Action: FirewallRuleActionAccept, Direction: FirewallRuleDirectionIn, DestinationPortStart: "443", DestinationPortEnd: "443", Comment: "HTTPS"}
+	drop := FirewallRule{Action: FirewallRuleActionDrop, Direction: FirewallRuleDirectionIn, Comment: "Drop"}
+	withPosition := func(rule FirewallRule, position int) FirewallRule {
+		rule.Position = position
+		return rule
+	}
+	live := []FirewallRule{withPosition(ssh, 1), withPosition(web, 2), withPosition(drop, 3)}
+
+	diff, err := DiffFirewallRules(live, []FirewallRule{ssh, web, drop})
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	changed := web
+	changed.DestinationPortStart, changed.DestinationPortEnd = "8443", "8443"
+	dns := FirewallRule{Action: FirewallRuleActionAccept, Direction: FirewallRuleDirectionIn, DestinationPortStart: "53", DestinationPortEnd: "53", Comment: "DNS"}
+	diff, err = DiffFirewallRules(live, []FirewallRule{changed, dns, drop})
+	require.NoError(t, err)
+	assert.Equal(t, &FirewallRulesDiff{
+		Added:   []FirewallRule{dns},
+		Removed: []FirewallRule{withPosition(ssh, 1)},
+		Changed: []FirewallRule{changed},
+	}, diff)
+
+	diff, err = DiffFirewallRules(live, []FirewallRule{web, ssh, drop})
+	require.NoError(t, err)
+	assert.True(t, diff.Reordered)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Changed)
+
+	_, err = DiffFirewallRules(live, []FirewallRule{ssh, {Action: FirewallRuleActionDrop}})
+	assert.Error(t, err)
+	_, err = DiffFirewallRules(live, []FirewallRule{ssh, ssh})
+	assert.Error(t, err)
+}
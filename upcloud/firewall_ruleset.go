@@ -0,0 +1,133 @@
+package upcloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// FirewallRulesetVersion is the current version of the firewall ruleset document format
+const FirewallRulesetVersion = 1
+
+// FirewallRuleset is a stable document format for firewall rules that can be stored in version control and applied
+// to multiple servers. Rules are applied in the order they are listed. The struct has both JSON and YAML tags so the
+// document can be encoded with either format.
+type FirewallRuleset struct {
+	Version int                   `json:"version" yaml:"version"`
+	Rules   []FirewallRulesetRule `json:"rules" yaml:"rules"`
+}
+
+// FirewallRulesetRule is a single firewall rule in a FirewallRuleset. Zero port means that the port is not set.
+type FirewallRulesetRule struct {
+	Action                  string `json:"action" yaml:"action"`
+	Direction               string `json:"direction" yaml:"direction"`
+	Family                  string `json:"family,omitempty" yaml:"family,omitempty"`
+	Protocol                string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	ICMPType                string `json:"icmp_type,omitempty" yaml:"icmp_type,omitempty"`
+	SourceAddressStart      string `json:"source_address_start,omitempty" yaml:"source_address_start,omitempty"`
+	SourceAddressEnd        string `json:"source_address_end,omitempty" yaml:"source_address_end,omitempty"`
+	SourcePortStart         int    `json:"source_port_start,omitempty" yaml:"source_port_start,omitempty"`
+	SourcePortEnd           int    `json:"source_port_end,omitempty" yaml:"source_port_end,omitempty"`
+	DestinationAddressStart string `json:"destination_address_start,omitempty" yaml:"destination_address_start,omitempty"`
+	DestinationAddressEnd   string `json:"destination_address_end,omitempty" yaml:"destination_address_end,omitempty"`
+	DestinationPortStart    int    `json:"destination_port_start,omitempty" yaml:"destination_port_start,omitempty"`
+	DestinationPortEnd      int    `json:"destination_port_end,omitempty" yaml:"destination_port_end,omitempty"`
+	Comment                 string `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+// NewFirewallRuleset converts firewall rules into a ruleset document. The rules are ordered by their position.
+func NewFirewallRuleset(rules []FirewallRule) (*FirewallRuleset, error) {
+	ordered := make([]FirewallRule, 0, len(rules))
+	for _, r := range rules {
+		ordered = append(ordered, r.withPortStrings())
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+	rs := &FirewallRuleset{Version: FirewallRulesetVersion, Rules: make([]FirewallRulesetRule, 0, len(ordered))}
+	for _, r := range ordered {
+		rule := FirewallRulesetRule{
+			Action:                  r.Action,
+			Direction:               r.Direction,
+			Family:                  r.Family,
+			Protocol:                r.Protocol,
+			ICMPType:                r.ICMPType,
+			SourceAddressStart:      r.SourceAddressStart,
+			SourceAddressEnd:        r.SourceAddressEnd,
+			DestinationAddressStart: r.DestinationAddressStart,
+			DestinationAddressEnd:   r.DestinationAddressEnd,
+			Comment:                 r.Comment,
+		}
+		var err error
+		if rule.SourcePortStart, rule.SourcePortEnd, err = rulesetPorts(r.SourcePortStart, r.SourcePortEnd); err != nil {
+			return nil, err
+		}
+		if rule.DestinationPortStart, rule.DestinationPortEnd, err = rulesetPorts(r.DestinationPortStart, r.DestinationPortEnd); err != nil {
+			return nil, err
+		}
+		rs.Rules = append(rs.Rules, rule)
+	}
+	return rs, nil
+}
+
+// ParseFirewallRuleset parses and validates a JSON encoded ruleset document.
+func ParseFirewallRuleset(data []byte) (*FirewallRuleset, error) {
+	rs := FirewallRuleset{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&rs); err != nil {
+		return nil, err
+	}
+	return &rs, rs.Validate()
+}
+
+// Validate checks the document version and the rules of the ruleset.
+func (s *FirewallRuleset) Validate() error {
+	if s.Version != FirewallRulesetVersion {
+		return fmt.Errorf("unsupported firewall ruleset version %d", s.Version)
+	}
+	for i, r := range s.FirewallRules() {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// FirewallRules converts the ruleset into firewall rules positioned in the order of the document.
+func (s *FirewallRuleset) FirewallRules() []FirewallRule {
+	rules := make([]FirewallRule, 0, len(s.Rules))
+	for i, r := range s.Rules {
+		rule := FirewallRule{
+			Action:                  r.Action,
+			Direction:               r.Direction,
+			Family:                  r.Family,
+			Protocol:                r.Protocol,
+			ICMPType:                r.ICMPType,
+			SourceAddressStart:      r.SourceAddressStart,
+			SourceAddressEnd:        r.SourceAddressEnd,
+			DestinationAddressStart: r.DestinationAddressStart,
+			DestinationAddressEnd:   r.DestinationAddressEnd,
+			Comment:                 r.Comment,
+			Position:                i + 1,
+		}
+		rule.SetSourcePorts(r.SourcePortStart, r.SourcePortEnd)
+		rule.SetDestinationPorts(r.DestinationPortStart, r.DestinationPortEnd)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func rulesetPorts(start, end string) (int, int, error) {
+	if start == "" && end == "" {
+		return 0, 0, nil
+	}
+	p := parsePortRange(start, end)
+	if p == nil {
+		return 0, 0, fmt.Errorf("invalid port range %q-%q", start, end)
+	}
+	if p.Start == p.End {
+		return p.Start, 0, nil
+	}
+	return p.Start, p.End, nil
+}
@@ -0,0 +1,72 @@
+package upcloud
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirewallRuleset(t *testing.T) {
+	rules := []FirewallRule{
+		{
+			Action:    FirewallRuleActionDrop,
+			Direction: FirewallRuleDirectionIn,
+			Position:  2,
+		},
+		{
+			Action:               FirewallRuleActionAccept,
+			Direction:            FirewallRuleDirectionIn,
+			Family:               IPAddressFamilyIPv4,
+			Protocol:             FirewallRuleProtocolTCP,
+			DestinationPortStart: "22",
+			DestinationPortEnd:   "22",
+			DestinationPorts:     NewPortRange(22, 0),
+			SourceAddressStart:   "203.0.113.0",
+			SourceAddressEnd:     "203.0.113.255",
+			Comment:              "SSH",
+			Position:             1,
+		},
+	}
+
+	rs, err := NewFirewallRuleset(rules)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(rs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"rules": [
+			{
+				"action": "accept",
+				"direction": "in",
+				"family": "IPv4",
+				"protocol": "tcp",
+				"source_address_start": "203.0.113.0",
+				"source_address_end": "203.0.113.255",
+				"destination_port_start": 22,
+				"comment": "SSH"
+			},
+			{"action": "drop", "direction": "in"}
+		]
+	}`, string(b))
+
+	parsed, err := ParseFirewallRuleset(b)
+	require.NoError(t, err)
+	assert.Equal(t, []FirewallRule{rules[1], rules[0]}, parsed.FirewallRules())
+}
+
+func TestParseFirewallRuleset_invalid(t *testing.T) {
+	_, err := ParseFirewallRuleset([]byte(`{"version": 2, "rules": []}`))
+	assert.ErrorContains(t, err, "unsupported firewall ruleset version 2")
+
+	_, err = ParseFirewallRuleset([]byte(`{"version": 1, "rules": [{"action": "accept", "direction": "in", "port": 22}]}`))
+	assert.Error(t, err)
+
+	_, err = ParseFirewallRuleset([]byte(`{"version": 1, "rules": [{"action": "accept", "direction": "in", "protocol": "icmp", "family": "IPv4", "destination_port_start": 22}]}`))
+	assert.ErrorContains(t, err, "rule 1: ports cannot be used with protocol icmp")
+
+	_, err = NewFirewallRuleset([]FirewallRule{{DestinationPortStart: "ssh"}})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestUnmarshalFirewallRules tests the FirewallRules and FirewallRule are unmarshaled correctly
@@ -113,6 +114,7 @@ func TestUnmarshalFirewallRules(t *testing.T) {
 			Comment:              "Allow HTTP from anywhere",
 			DestinationPortStart: "80",
 			DestinationPortEnd:   "80",
+			DestinationPorts:     &PortRange{Start: 80, End: 80},
 			Direction:            FirewallRuleDirectionIn,
 			Family:               IPAddressFamilyIPv4,
 			Position:             1,
@@ -122,6 +124,7 @@ func TestUnmarshalFirewallRules(t *testing.T) {
 			Comment:              "Allow SSH from a specific network only",
 			DestinationPortStart: "22",
 			DestinationPortEnd:   "22",
+			DestinationPorts:     &PortRange{Start: 22, End: 22},
 			Direction:            FirewallRuleDirectionIn,
 			Family:               IPAddressFamilyIPv4,
 			Position:             2,
@@ -134,6 +137,7 @@ func TestUnmarshalFirewallRules(t *testing.T) {
 			Comment:              "Allow SSH over IPv6 from this range",
 			DestinationPortStart: "22",
 			DestinationPortEnd:   "22",
+			DestinationPorts:     &PortRange{Start: 22, End: 22},
 			Direction:            FirewallRuleDirectionIn,
 			Family:               IPAddressFamilyIPv6,
 			Position:             3,
@@ -195,6 +199,7 @@ func TestUnmarshalFirewallRule(t *testing.T) {
 		Comment:              "Allow HTTP from anywhere",
 		DestinationPortStart: "80",
 		DestinationPortEnd:   "80",
+		DestinationPorts:     &PortRange{Start: 80, End: 80},
 		Direction:            FirewallRuleDirectionIn,
 		Family:               IPAddressFamilyIPv4,
 		Position:             1,
@@ -202,3 +207,76 @@ func TestUnmarshalFirewallRule(t *testing.T) {
 
 	assert.Equal(t, expectedRule, actualRule)
 }
+
+// TestFirewallRuleValidate tests that invalid protocol, port and ICMP type combinations are rejected
+func TestFirewallRuleValidate(t *testing.T) {
+	base := FirewallRule{Action: FirewallRuleActionAccept, Direction: FirewallRuleDirectionIn, Family: IPAddressFamilyIPv4}
+	rule := func(fn func(r *FirewallRule)) FirewallRule {
+		r := base
+		fn(&r)
+		return r
+	}
+
+	valid := []FirewallRule{
+		base,
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP; r.SetDestinationPorts(22, 0) }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolUDP; r.SetSourcePorts(1024, 65535) }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolICMP; r.ICMPType = "8" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolICMP; r.Family = IPAddressFamilyIPv6 }),
+		{Action: FirewallRuleActionDrop, Direction: FirewallRuleDirectionOut},
+	}
+	for _, r := range valid {
+		assert.NoError(t, r.Validate(), "%+v", r)
+	}
+
+	invalid := []FirewallRule{
+		rule(func(r *FirewallRule) { r.Action = "allow" }),
+		rule(func(r *FirewallRule) { r.Direction = "" }),
+		rule(func(r *FirewallRule) { r.Family = "IPv5" }),
+		rule(func(r *FirewallRule) { r.Protocol = "sctp" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP; r.Family = "" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolICMP; r.SetDestinationPorts(22, 22) }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolICMP; r.ICMPType = "256" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP; r.ICMPType = "8" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP; r.DestinationPortEnd = "80" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolTCP; r.SetDestinationPorts(443, 80) }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolUDP; r.SourcePortStart = "http" }),
+		rule(func(r *FirewallRule) { r.Protocol = FirewallRuleProtocolUDP; r.SetSourcePorts(0x10000, 0) }),
+		rule(func(r *FirewallRule) { r.SetDestinationPorts(80, 80) }),
+		rule(func(r *FirewallRule) { r.ICMPType = "8" }),
+	}
+	for _, r := range invalid {
+		assert.Error(t, r.Validate(), "%+v", r)
+	}
+}
+
+// TestFirewallRulePorts tests the typed port ranges
+func TestFirewallRulePorts(t *testing.T) {
+	r := FirewallRule{}
+	assert.Nil(t, r.DestinationPorts)
+
+	r.SetDestinationPorts(80, 0)
+	assert.Equal(t, "80", r.DestinationPortStart)
+	assert.Equal(t, "80", r.DestinationPortEnd)
+	assert.Equal(t, &PortRange{Start: 80, End: 80}, r.DestinationPorts)
+
+	r.SetSourcePorts(1024, 2048)
+	assert.Equal(t, &PortRange{Start: 1024, End: 2048}, r.SourcePorts)
+
+	r.SetDestinationPorts(0, 0)
+	assert.Nil(t, r.DestinationPorts)
+	assert.Empty(t, r.DestinationPortStart)
+	assert.Empty(t, r.DestinationPortEnd)
+
+	// Typed port ranges take precedence over the port fields when the rule is sent
+	r = FirewallRule{Action: FirewallRuleActionAccept, DestinationPortStart: "22", DestinationPorts: NewPortRange(443, 0)}
+	b, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"action":"accept","direction":"","destination_port_start":"443","destination_port_end":"443"}`, string(b))
+
+	err = json.Unmarshal([]byte(`{"firewall_rule":{"destination_port_start":"1024","destination_port_end":"2048"}}`), &r)
+	require.NoError(t, err)
+	assert.Equal(t, &PortRange{Start: 1024, End: 2048}, r.DestinationPorts)
+	assert.Nil(t, r.SourcePorts)
+}
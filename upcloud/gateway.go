@@ -139,6 +139,28 @@ type GatewayTunnel struct {
 	UpdatedAt        time.Time                     `json:"updated_at,omitempty"`
 }
 
+// Established returns true if the tunnel is up. Tunnel is considered up also while its keys are being renewed.
+func (t GatewayTunnel) Established() bool {
+	switch t.OperationalState {
+	case GatewayTunnelOperationalStateEstabilished, GatewayTunnelOperationalStateRekeying, GatewayTunnelOperationalStateRekeyed:
+		return true
+	}
+	return false
+}
+
+// TunnelsEstablished returns true if the connection has tunnels and all of them are up.
+func (c GatewayConnection) TunnelsEstablished() bool {
+	if len(c.Tunnels) == 0 {
+		return false
+	}
+	for _, t := range c.Tunnels {
+		if !t.Established() {
+			return false
+		}
+	}
+	return true
+}
+
 type GatewayTunnelLocalAddress struct {
 	// Name of the UpCloud gateway address; should correspond to the name of one of the gateway address structs
 	Name string `json:"name,omitempty"`
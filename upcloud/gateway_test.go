@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -233,3 +234,20 @@ func TestGatewayPlan(t *testing.T) {
 
 	testJSON(t, &GatewayPlan{}, plan, jsonStr)
 }
+
+func TestGatewayConnectionTunnelsEstablished(t *testing.T) {
+	t.Parallel()
+
+	c := GatewayConnection{}
+	assert.False(t, c.TunnelsEstablished())
+
+	c.Tunnels = []GatewayTunnel{
+		{Name: "tunnel-1", OperationalState: GatewayTunnelOperationalStateEstabilished},
+		{Name: "tunnel-2", OperationalState: GatewayTunnelOperationalStateConnecting},
+	}
+	assert.True(t, c.Tunnels[0].Established())
+	assert.False(t, c.TunnelsEstablished())
+
+	c.Tunnels[1].OperationalState = GatewayTunnelOperationalStateRekeying
+	assert.True(t, c.TunnelsEstablished())
+}
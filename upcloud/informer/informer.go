@@ -0,0 +1,154 @@
+package informer
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// Index names of the server and storage informers
+const (
+	IndexHostname string = "hostname"
+	IndexTag      string = "tag"
+	IndexZone     string = "zone"
+)
+
+// DefaultResyncPeriod is the default interval between listing the resources
+const DefaultResyncPeriod = time.Minute
+
+// EventHandler is notified about the changes in the informer store. Nil functions are ignored.
+type EventHandler[T any] struct {
+	OnAdd    func(item T)
+	OnUpdate func(oldItem, newItem T)
+	OnDelete func(item T)
+}
+
+// ListFunc lists all resources of the informer
+type ListFunc[T any] func(ctx context.Context) ([]T, error)
+
+// Informer keeps a store of resources in sync by listing them every resync period and notifies the registered
+// handlers about the changes.
+type Informer[T any] struct {
+	store        *Store[T]
+	list         ListFunc[T]
+	resyncPeriod time.Duration
+	handlers     []EventHandler[T]
+	errorHandler func(error)
+	synced       atomic.Bool
+}
+
+// New returns an informer that lists the resources with list into store every resync period
+func New[T any](list ListFunc[T], store *Store[T], resyncPeriod time.Duration) *Informer[T] {
+	if resyncPeriod <= 0 {
+		resyncPeriod = DefaultResyncPeriod
+	}
+	return &Informer[T]{
+		store:        store,
+		list:         list,
+		resyncPeriod: resyncPeriod,
+	}
+}
+
+// AddEventHandler registers a handler for the changes in the store. Handlers must be added before calling Run.
+func (i *Informer[T]) AddEventHandler(h EventHandler[T]) {
+	i.handlers = append(i.handlers, h)
+}
+
+// SetErrorHandler sets the function that is called with the listing errors. Must be called before Run.
+func (i *Informer[T]) SetErrorHandler(fn func(error)) {
+	i.errorHandler = fn
+}
+
+// Store returns the store of the informer
+func (i *Informer[T]) Store() *Store[T] {
+	return i.store
+}
+
+// HasSynced reports whether the store has been populated by the first successful listing
+func (i *Informer[T]) HasSynced() bool {
+	return i.synced.Load()
+}
+
+// Resync lists the resources once, updates the store and notifies the handlers
+func (i *Informer[T]) Resync(ctx context.Context) error {
+	items, err := i.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range i.store.Replace(items) {
+		for _, h := range i.handlers {
+			switch {
+			case c.Old == nil && h.OnAdd != nil:
+				h.OnAdd(*c.New)
+			case c.New == nil && h.OnDelete != nil:
+				h.OnDelete(*c.Old)
+			case c.Old != nil && c.New != nil && h.OnUpdate != nil:
+				h.OnUpdate(*c.Old, *c.New)
+			}
+		}
+	}
+	i.synced.Store(true)
+	return nil
+}
+
+// Run resyncs the store every resync period until the context is done
+func (i *Informer[T]) Run(ctx context.Context) error {
+	ticker := time.NewTicker(i.resyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := i.Resync(ctx); err != nil && ctx.Err() == nil && i.errorHandler != nil {
+			i.errorHandler(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ServerLister is the subset of the service used by the server informer
+type ServerLister interface {
+	GetServers(ctx context.Context) (*upcloud.Servers, error)
+}
+
+// NewServerInformer returns an informer for servers mapped by UUID and indexed by hostname, tag and zone
+func NewServerInformer(svc ServerLister, resyncPeriod time.Duration) *Informer[upcloud.Server] {
+	store := NewStore(func(s upcloud.Server) string { return s.UUID }, Indexers[upcloud.Server]{
+		IndexHostname: func(s upcloud.Server) []string { return []string{s.Hostname} },
+		IndexTag:      func(s upcloud.Server) []string { return s.Tags },
+		IndexZone:     func(s upcloud.Server) []string { return []string{s.Zone} },
+	})
+	return New(func(ctx context.Context) ([]upcloud.Server, error) {
+		servers, err := svc.GetServers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return servers.Servers, nil
+	}, store, resyncPeriod)
+}
+
+// StorageLister is the subset of the service used by the storage informer
+type StorageLister interface {
+	GetStorages(ctx context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error)
+}
+
+// NewStorageInformer returns an informer for private storages mapped by UUID and indexed by zone
+func NewStorageInformer(svc StorageLister, resyncPeriod time.Duration) *Informer[upcloud.Storage] {
+	store := NewStore(func(s upcloud.Storage) string { return s.UUID }, Indexers[upcloud.Storage]{
+		IndexZone: func(s upcloud.Storage) []string { return []string{s.Zone} },
+	})
+	return New(func(ctx context.Context) ([]upcloud.Storage, error) {
+		storages, err := svc.GetStorages(ctx, &request.GetStoragesRequest{Access: upcloud.StorageAccessPrivate})
+		if err != nil {
+			return nil, err
+		}
+		return storages.Storages, nil
+	}, store, resyncPeriod)
+}
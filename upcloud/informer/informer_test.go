@@ -0,0 +1,113 @@
+package informer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+type fakeLister struct {
+	mu       sync.Mutex
+	servers  []upcloud.Server
+	storages []upcloud.Storage
+	err      error
+}
+
+func (f *fakeLister) GetServers(context.Context) (*upcloud.Servers, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &upcloud.Servers{Servers: append([]upcloud.Server(nil), f.servers...)}, f.err
+}
+
+func (f *fakeLister) GetStorages(_ context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Access != upcloud.StorageAccessPrivate {
+		return nil, errors.New("expected private storages to be requested")
+	}
+	return &upcloud.Storages{Storages: append([]upcloud.Storage(nil), f.storages...)}, f.err
+}
+
+func TestServerInformer(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{servers: []upcloud.Server{
+		{UUID: "a", Hostname: "web-1", Zone: "fi-hel1", State: upcloud.ServerStateStarted, Tags: upcloud.ServerTagSlice{"web"}},
+		{UUID: "b", Hostname: "db-1", Zone: "de-fra1", State: upcloud.ServerStateStarted},
+	}}
+	inf := NewServerInformer(lister, time.Hour)
+
+	var added, deleted []string
+	var updated [][2]string
+	inf.AddEventHandler(EventHandler[upcloud.Server]{
+		OnAdd:    func(s upcloud.Server) { added = append(added, s.UUID) },
+		OnUpdate: func(o, n upcloud.Server) { updated = append(updated, [2]string{o.State, n.State}) },
+		OnDelete: func(s upcloud.Server) { deleted = append(deleted, s.UUID) },
+	})
+
+	assert.False(t, inf.HasSynced())
+	require.NoError(t, inf.Resync(context.Background()))
+	assert.True(t, inf.HasSynced())
+	assert.Equal(t, []string{"a", "b"}, added)
+
+	store := inf.Store()
+	s, ok := store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "web-1", s.Hostname)
+	assert.Len(t, store.ByIndex(IndexHostname, "db-1"), 1)
+	assert.Len(t, store.ByIndex(IndexTag, "web"), 1)
+	assert.Len(t, store.ByIndex(IndexZone, "fi-hel1"), 1)
+
+	lister.mu.Lock()
+	lister.servers = []upcloud.Server{{UUID: "a", Hostname: "web-1", Zone: "fi-hel1", State: upcloud.ServerStateStopped, Tags: upcloud.ServerTagSlice{"web"}}}
+	lister.mu.Unlock()
+
+	require.NoError(t, inf.Resync(context.Background()))
+	assert.Equal(t, [][2]string{{upcloud.ServerStateStarted, upcloud.ServerStateStopped}}, updated)
+	assert.Equal(t, []string{"b"}, deleted)
+	assert.Empty(t, store.ByIndex(IndexHostname, "db-1"))
+}
+
+func TestStorageInformer_Run(t *testing.T) {
+	t.Parallel()
+
+	lister := &fakeLister{err: errors.New("temporary failure")}
+	inf := NewStorageInformer(lister, time.Millisecond)
+
+	errs := make(chan error, 1)
+	inf.SetErrorHandler(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result := make(chan error)
+	go func() {
+		result <- inf.Run(ctx)
+	}()
+
+	assert.EqualError(t, <-errs, "temporary failure")
+	assert.False(t, inf.HasSynced())
+
+	lister.mu.Lock()
+	lister.err = nil
+	lister.storages = []upcloud.Storage{{UUID: "a", Zone: "fi-hel1"}}
+	lister.mu.Unlock()
+
+	assert.Eventually(t, inf.HasSynced, time.Second, time.Millisecond)
+	assert.Len(t, inf.Store().ByIndex(IndexZone, "fi-hel1"), 1)
+
+	cancel()
+	assert.ErrorIs(t, <-result, context.Canceled)
+}
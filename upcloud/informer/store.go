@@ -0,0 +1,168 @@
+// Package informer provides informers that keep an indexed in-memory cache of account resources up to date by
+// listing the resources periodically and notifying handlers about the added, updated and deleted resources.
+package informer
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// IndexFunc returns the index values of an item
+type IndexFunc[T any] func(item T) []string
+
+// Indexers maps index names to index functions
+type Indexers[T any] map[string]IndexFunc[T]
+
+// Store is a thread-safe cache of items mapped by their keys and indexed with the configured index functions
+type Store[T any] struct {
+	mu       sync.RWMutex
+	keyFn    func(T) string
+	indexers Indexers[T]
+	items    map[string]T
+	// indices maps index name to index value to item keys
+	indices map[string]map[string]map[string]struct{}
+}
+
+// NewStore returns an empty store that maps the items with keyFn and indexes them with indexers
+func NewStore[T any](keyFn func(T) string, indexers Indexers[T]) *Store[T] {
+	s := &Store[T]{
+		keyFn:    keyFn,
+		indexers: indexers,
+		items:    make(map[string]T),
+		indices:  make(map[string]map[string]map[string]struct{}),
+	}
+	for name := range indexers {
+		s.indices[name] = make(map[string]map[string]struct{})
+	}
+	return s
+}
+
+// Get returns the item with the key
+func (s *Store[T]) Get(key string) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[key]
+	return item, ok
+}
+
+// List returns all items sorted by their keys
+func (s *Store[T]) List() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	return s.byKeys(keys)
+}
+
+// ByIndex returns the items whose index matches the value sorted by their keys. Nil is returned if the index does
+// not exist.
+func (s *Store[T]) ByIndex(index, value string) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.indices[index][value]))
+	for key := range s.indices[index][value] {
+		keys = append(keys, key)
+	}
+	return s.byKeys(keys)
+}
+
+// Len returns the number of items in the store
+func (s *Store[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.items)
+}
+
+// Change represents a change of an item in the store. Old is nil for added items and New is nil for deleted items.
+type Change[T any] struct {
+	Old *T
+	New *T
+}
+
+// Replace replaces the contents of the store with items and returns the changes sorted by item keys
+func (s *Store[T]) Replace(items []T) []Change[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := make(map[string]Change[T])
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		item := item
+		key := s.keyFn(item)
+		seen[key] = struct{}{}
+
+		old, ok := s.items[key]
+		switch {
+		case !ok:
+			changes[key] = Change[T]{New: &item}
+		case !reflect.DeepEqual(old, item):
+			changes[key] = Change[T]{Old: &old, New: &item}
+		default:
+			continue
+		}
+		s.set(key, item)
+	}
+	for key, old := range s.items {
+		if _, ok := seen[key]; !ok {
+			old := old
+			changes[key] = Change[T]{Old: &old}
+			s.delete(key)
+		}
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]Change[T], 0, len(keys))
+	for _, key := range keys {
+		result = append(result, changes[key])
+	}
+	return result
+}
+
+func (s *Store[T]) set(key string, item T) {
+	s.delete(key)
+	s.items[key] = item
+	for name, fn := range s.indexers {
+		for _, value := range fn(item) {
+			if s.indices[name][value] == nil {
+				s.indices[name][value] = make(map[string]struct{})
+			}
+			s.indices[name][value][key] = struct{}{}
+		}
+	}
+}
+
+func (s *Store[T]) delete(key string) {
+	item, ok := s.items[key]
+	if !ok {
+		return
+	}
+	delete(s.items, key)
+	for name, fn := range s.indexers {
+		for _, value := range fn(item) {
+			delete(s.indices[name][value], key)
+			if len(s.indices[name][value]) == 0 {
+				delete(s.indices[name], value)
+			}
+		}
+	}
+}
+
+func (s *Store[T]) byKeys(keys []string) []T {
+	sort.Strings(keys)
+	items := make([]T, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, s.items[key])
+	}
+	return items
+}
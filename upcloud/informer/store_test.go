@@ -0,0 +1,62 @@
+package informer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID    string
+	Group string
+	Value int
+}
+
+func newItemStore() *Store[item] {
+	return NewStore(func(i item) string { return i.ID }, Indexers[item]{
+		"group": func(i item) []string { return []string{i.Group} },
+	})
+}
+
+func TestStore_Replace(t *testing.T) {
+	t.Parallel()
+
+	s := newItemStore()
+	changes := s.Replace([]item{{ID: "b", Group: "x"}, {ID: "a", Group: "x"}})
+	require.Len(t, changes, 2)
+	assert.Nil(t, changes[0].Old)
+	assert.Equal(t, "a", changes[0].New.ID)
+	assert.Equal(t, 2, s.Len())
+
+	changes = s.Replace([]item{{ID: "a", Group: "y", Value: 1}, {ID: "c", Group: "x"}})
+	require.Len(t, changes, 3)
+	assert.Equal(t, Change[item]{Old: &item{ID: "a", Group: "x"}, New: &item{ID: "a", Group: "y", Value: 1}}, changes[0])
+	assert.Equal(t, Change[item]{Old: &item{ID: "b", Group: "x"}}, changes[1])
+	assert.Equal(t, Change[item]{New: &item{ID: "c", Group: "x"}}, changes[2])
+
+	assert.Empty(t, s.Replace([]item{{ID: "a", Group: "y", Value: 1}, {ID: "c", Group: "x"}}))
+}
+
+func TestStore_Get(t *testing.T) {
+	t.Parallel()
+
+	s := newItemStore()
+	s.Replace([]item{{ID: "b", Group: "x"}, {ID: "a", Group: "y"}, {ID: "c", Group: "x"}})
+
+	i, ok := s.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "y", i.Group)
+	_, ok = s.Get("d")
+	assert.False(t, ok)
+
+	assert.Equal(t, []item{{ID: "a", Group: "y"}, {ID: "b", Group: "x"}, {ID: "c", Group: "x"}}, s.List())
+	assert.Equal(t, []item{{ID: "b", Group: "x"}, {ID: "c", Group: "x"}}, s.ByIndex("group", "x"))
+	assert.Empty(t, s.ByIndex("group", "z"))
+	assert.Empty(t, s.ByIndex("missing", "x"))
+
+	// Index is updated when items change
+	s.Replace([]item{{ID: "b", Group: "y"}})
+	assert.Empty(t, s.ByIndex("group", "x"))
+	assert.Equal(t, []item{{ID: "b", Group: "y"}}, s.ByIndex("group", "y"))
+}
@@ -0,0 +1,34 @@
+package upcloud
+
+import "time"
+
+// Inventory is a snapshot of the resources of an account. The listings are fetched concurrently, so the snapshot
+// spans the time between StartedAt and CompletedAt.
+type Inventory struct {
+	// Zone is the zone the inventory is limited to, or empty for all zones
+	Zone        string
+	StartedAt   time.Time
+	CompletedAt time.Time
+
+	Servers     []Server
+	Storages    []Storage
+	IPAddresses []IPAddress
+	Networks    []Network
+	Tags        []Tag
+}
+
+// Duration returns how long gathering the inventory took
+func (i *Inventory) Duration() time.Duration {
+	return i.CompletedAt.Sub(i.StartedAt)
+}
+
+// ServerIPAddresses returns the IP addresses assigned to the server
+func (i *Inventory) ServerIPAddresses(serverUUID string) []IPAddress {
+	var addresses []IPAddress
+	for _, a := range i.IPAddresses {
+		if a.ServerUUID == serverUUID {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
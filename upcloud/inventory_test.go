@@ -0,0 +1,27 @@
+package upcloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventory(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	i := Inventory{
+		StartedAt:   started,
+		CompletedAt: started.Add(1500 * time.Millisecond),
+		IPAddresses: []IPAddress{
+			{Address: "94.237.0.1", ServerUUID: "server1"},
+			{Address: "94.237.0.2", ServerUUID: "server2"},
+			{Address: "94.237.0.3", ServerUUID: "server1"},
+			{Address: "94.237.0.4", Floating: True},
+		},
+	}
+	assert.Equal(t, 1500*time.Millisecond, i.Duration())
+	assert.Equal(t, []IPAddress{i.IPAddresses[0], i.IPAddresses[2]}, i.ServerIPAddresses("server1"))
+	assert.Empty(t, i.ServerIPAddresses("server3"))
+}
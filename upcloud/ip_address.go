@@ -1,6 +1,9 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"slices"
+)
 
 // Constants
 const (
@@ -33,6 +36,7 @@ func (s *IPAddresses) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	s.IPAddresses = slices.Grow(s.IPAddresses, len(v.IPAddresses.IPAddresses))
 	for _, ip := range v.IPAddresses.IPAddresses {
 		s.IPAddresses = append(s.IPAddresses, IPAddress(ip))
 	}
@@ -56,6 +60,7 @@ func (i *IPAddressSlice) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	(*i) = slices.Grow((*i), len(v.IPAddresses))
 	for _, ip := range v.IPAddresses {
 		(*i) = append((*i), IPAddress(ip))
 	}
@@ -63,6 +68,57 @@ func (i *IPAddressSlice) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// Filter returns the IP addresses matching the family and access. Empty family or access matches any family or access.
+func (i IPAddressSlice) Filter(family, access string) IPAddressSlice {
+	var filtered IPAddressSlice
+	for _, ip := range i {
+		if (family == "" || ip.Family == family) && (access == "" || ip.Access == access) {
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
+
+// Floating returns the floating IP addresses
+func (i IPAddressSlice) Floating() IPAddressSlice {
+	var filtered IPAddressSlice
+	for _, ip := range i {
+		if ip.Floating.Bool() {
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
+
+// PTRRecords returns the PTR records of the IP addresses keyed by address. Addresses without a PTR record are omitted.
+func (i IPAddressSlice) PTRRecords() map[string]string {
+	records := make(map[string]string)
+	for _, ip := range i {
+		if ip.PTRRecord != "" {
+			records[ip.Address] = ip.PTRRecord
+		}
+	}
+	return records
+}
+
+// AllAddresses returns the addresses matching the family and access, e.g. IPAddressFamilyIPv4 and
+// IPAddressAccessPublic. Empty family or access matches any family or access.
+func (i IPAddressSlice) AllAddresses(family, access string) []string {
+	var addresses []string
+	for _, ip := range i.Filter(family, access) {
+		addresses = append(addresses, ip.Address)
+	}
+	return addresses
+}
+
+// Address returns the first address matching the family and access, or an empty string if there is none
+func (i IPAddressSlice) Address(family, access string) string {
+	if addresses := i.AllAddresses(family, access); len(addresses) > 0 {
+		return addresses[0]
+	}
+	return ""
+}
+
 // IPAddress represents an IP address
 type IPAddress struct {
 	Access     string  `json:"access"`
@@ -81,15 +137,10 @@ type IPAddress struct {
 func (s *IPAddress) UnmarshalJSON(b []byte) error {
 	type localIPAddress IPAddress
 
+	*s = IPAddress{}
 	v := struct {
-		IPAddress localIPAddress `json:"ip_address"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		IPAddress *localIPAddress `json:"ip_address"`
+	}{(*localIPAddress)(s)}
 
-	(*s) = IPAddress(v.IPAddress)
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
@@ -154,3 +154,40 @@ func TestUnmarshalIPAddress(t *testing.T) {
 	assert.Equal(t, "94-237-104-58.fi-hel2.upcloud.host", ipAddress.PTRRecord)
 	assert.Equal(t, "0028ab30-491a-4696-a601-91e810d154a8", ipAddress.ServerUUID)
 }
+
+// TestIPAddressSliceAllAddresses tests that addresses can be filtered by family and access
+func TestIPAddressSliceAllAddresses(t *testing.T) {
+	ips := IPAddressSlice{
+		{Address: "10.0.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessUtility},
+		{Address: "94.237.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessPublic},
+		{Address: "2a04:3540::1", Family: IPAddressFamilyIPv6, Access: IPAddressAccessPublic},
+		{Address: "94.237.0.2", Family: IPAddressFamilyIPv4, Access: IPAddressAccessPublic},
+	}
+
+	assert.Len(t, ips.AllAddresses("", ""), 4)
+	assert.Equal(t, []string{"94.237.0.1", "2a04:3540::1", "94.237.0.2"}, ips.AllAddresses("", IPAddressAccessPublic))
+	assert.Equal(t, []string{"94.237.0.1", "94.237.0.2"}, ips.AllAddresses(IPAddressFamilyIPv4, IPAddressAccessPublic))
+	assert.Empty(t, ips.AllAddresses(IPAddressFamilyIPv6, IPAddressAccessPrivate))
+
+	assert.Equal(t, "94.237.0.1", ips.Address(IPAddressFamilyIPv4, IPAddressAccessPublic))
+	assert.Equal(t, "", ips.Address(IPAddressFamilyIPv4, IPAddressAccessPrivate))
+}
+
+// TestIPAddressSliceFilterHelpers tests the Filter, Floating and PTRRecords helpers
+func TestIPAddressSliceFilterHelpers(t *testing.T) {
+	ips := IPAddressSlice{
+		{Address: "10.0.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessUtility},
+		{Address: "94.237.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessPublic, PTRRecord: "server.example.com"},
+		{Address: "94.237.0.2", Family: IPAddressFamilyIPv4, Access: IPAddressAccessPublic, Floating: True},
+		{Address: "2a04:3540::1", Family: IPAddressFamilyIPv6, Access: IPAddressAccessPublic, PTRRecord: "v6.example.com"},
+	}
+
+	assert.Equal(t, IPAddressSlice{ips[1], ips[2]}, ips.Filter(IPAddressFamilyIPv4, IPAddressAccessPublic))
+	assert.Equal(t, IPAddressSlice{ips[3]}, ips.Filter(IPAddressFamilyIPv6, ""))
+	assert.Empty(t, ips.Filter("", IPAddressAccessPrivate))
+	assert.Equal(t, IPAddressSlice{ips[2]}, ips.Floating())
+	assert.Equal(t, map[string]string{
+		"94.237.0.1":   "server.example.com",
+		"2a04:3540::1": "v6.example.com",
+	}, ips.PTRRecords())
+}
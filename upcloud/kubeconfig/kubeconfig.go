@@ -0,0 +1,246 @@
+// Package kubeconfig merges kubeconfig files retrieved with GetKubernetesKubeconfig into existing kubeconfig files.
+package kubeconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a kubeconfig file. Cluster and user details are kept as is so that fields not known by this package are
+// preserved when the file is written back.
+type Config struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Preferences    map[string]interface{} `yaml:"preferences"`
+	Clusters       []NamedCluster         `yaml:"clusters"`
+	Contexts       []NamedContext         `yaml:"contexts"`
+	Users          []NamedUser            `yaml:"users"`
+	CurrentContext string                 `yaml:"current-context"`
+	Extensions     interface{}            `yaml:"extensions,omitempty"`
+}
+
+// NamedCluster is a cluster entry of kubeconfig
+type NamedCluster struct {
+	Name    string                 `yaml:"name"`
+	Cluster map[string]interface{} `yaml:"cluster"`
+}
+
+// NamedUser is a user entry of kubeconfig
+type NamedUser struct {
+	Name string                 `yaml:"name"`
+	User map[string]interface{} `yaml:"user"`
+}
+
+// NamedContext is a context entry of kubeconfig
+type NamedContext struct {
+	Name    string  `yaml:"name"`
+	Context Context `yaml:"context"`
+}
+
+// Context binds cluster and user entries of kubeconfig together
+type Context struct {
+	Cluster   string `yaml:"cluster"`
+	User      string `yaml:"user"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// New returns an empty kubeconfig
+func New() *Config {
+	return &Config{
+		APIVersion:  "v1",
+		Kind:        "Config",
+		Preferences: map[string]interface{}{},
+	}
+}
+
+// Parse parses kubeconfig data
+func Parse(data []byte) (*Config, error) {
+	c := New()
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unable to parse kubeconfig: %w", err)
+	}
+	return c, nil
+}
+
+// Load reads kubeconfig from the file. Empty kubeconfig is returned if the file does not exist.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Marshal returns kubeconfig as YAML
+func (c *Config) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// Save writes kubeconfig to the file. The file is replaced atomically and it is readable only by the owner.
+func (c *Config) Save(path string) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Context returns the context with the specified name
+func (c *Config) Context(name string) (Context, bool) {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return ctx.Context, true
+		}
+	}
+	return Context{}, false
+}
+
+// MergeOption configures how kubeconfig is merged
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	setCurrentContext bool
+}
+
+// WithCurrentContext sets the merged context as the current context
+func WithCurrentContext() MergeOption {
+	return func(c *mergeConfig) {
+		c.setCurrentContext = true
+	}
+}
+
+// Merge adds the current context of src, and the cluster and user it refers to, to the kubeconfig. The entries are
+// named after contextName and they replace existing entries with the same name. Other entries are left intact.
+func (c *Config) Merge(src *Config, contextName string, opts ...MergeOption) error {
+	cfg := mergeConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if contextName == "" {
+		return errors.New("context name is required")
+	}
+
+	srcContextName := src.CurrentContext
+	if srcContextName == "" && len(src.Contexts) == 1 {
+		srcContextName = src.Contexts[0].Name
+	}
+	srcContext, ok := src.Context(srcContextName)
+	if !ok {
+		return fmt.Errorf("context %q not found in kubeconfig", srcContextName)
+	}
+
+	var cluster *NamedCluster
+	for i := range src.Clusters {
+		if src.Clusters[i].Name == srcContext.Cluster {
+			cluster = &src.Clusters[i]
+		}
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster %q not found in kubeconfig", srcContext.Cluster)
+	}
+	var user *NamedUser
+	for i := range src.Users {
+		if src.Users[i].Name == srcContext.User {
+			user = &src.Users[i]
+		}
+	}
+	if user == nil {
+		return fmt.Errorf("user %q not found in kubeconfig", srcContext.User)
+	}
+
+	c.setCluster(NamedCluster{Name: contextName, Cluster: cluster.Cluster})
+	c.setUser(NamedUser{Name: contextName, User: user.User})
+	c.setContext(NamedContext{Name: contextName, Context: Context{
+		Cluster:   contextName,
+		User:      contextName,
+		Namespace: srcContext.Namespace,
+	}})
+	if cfg.setCurrentContext || c.CurrentContext == "" {
+		c.CurrentContext = contextName
+	}
+	return nil
+}
+
+// MergeFile merges kubeconfig data, e.g. the one returned by GetKubernetesKubeconfig, into the kubeconfig file under
+// contextName. The file is created if it does not exist.
+func MergeFile(path string, kubeconfig []byte, contextName string, opts ...MergeOption) error {
+	src, err := Parse(kubeconfig)
+	if err != nil {
+		return err
+	}
+	dst, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := dst.Merge(src, contextName, opts...); err != nil {
+		return err
+	}
+	return dst.Save(path)
+}
+
+// DefaultPath returns the kubeconfig file used by kubectl by default, i.e. the first file in KUBECONFIG environment
+// variable or ~/.kube/config.
+func DefaultPath() (string, error) {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0], nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+func (c *Config) setCluster(cluster NamedCluster) {
+	for i := range c.Clusters {
+		if c.Clusters[i].Name == cluster.Name {
+			c.Clusters[i] = cluster
+			return
+		}
+	}
+	c.Clusters = append(c.Clusters, cluster)
+}
+
+func (c *Config) setUser(user NamedUser) {
+	for i := range c.Users {
+		if c.Users[i].Name == user.Name {
+			c.Users[i] = user
+			return
+		}
+	}
+	c.Users = append(c.Users, user)
+}
+
+func (c *Config) setContext(ctx NamedContext) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == ctx.Name {
+			c.Contexts[i] = ctx
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, ctx)
+}
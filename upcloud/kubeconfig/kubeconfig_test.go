@@ -0,0 +1,130 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upcloudKubeconfig = `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: Y2EtZGF0YQ==
+    server: https://lb-0a1b2c3d.upcloudlb.com:6443
+  name: example
+contexts:
+- context:
+    cluster: example
+    user: example-admin
+  name: example-admin@example
+current-context: example-admin@example
+kind: Config
+preferences: {}
+users:
+- name: example-admin
+  user:
+    client-certificate-data: Y2VydC1kYXRh
+    client-key-data: a2V5LWRhdGE=
+`
+
+const existingKubeconfig = `apiVersion: v1
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+    insecure-skip-tls-verify: true
+  name: local
+contexts:
+- context:
+    cluster: local
+    user: local
+    namespace: dev
+  name: local
+current-context: local
+kind: Config
+preferences: {}
+users:
+- name: local
+  user:
+    token: local-token
+`
+
+func TestMergeFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".kube", "config")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(existingKubeconfig), 0o600))
+
+	require.NoError(t, MergeFile(path, []byte(upcloudKubeconfig), "upcloud-example"))
+	c, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "local", c.CurrentContext)
+	require.Len(t, c.Clusters, 2)
+	assert.Equal(t, true, c.Clusters[0].Cluster["insecure-skip-tls-verify"])
+	assert.Equal(t, NamedCluster{Name: "upcloud-example", Cluster: map[string]interface{}{
+		"certificate-authority-data": "Y2EtZGF0YQ==",
+		"server":                     "https://lb-0a1b2c3d.upcloudlb.com:6443",
+	}}, c.Clusters[1])
+	assert.Equal(t, NamedUser{Name: "upcloud-example", User: map[string]interface{}{
+		"client-certificate-data": "Y2VydC1kYXRh",
+		"client-key-data":         "a2V5LWRhdGE=",
+	}}, c.Users[1])
+	ctx, ok := c.Context("upcloud-example")
+	assert.True(t, ok)
+	assert.Equal(t, Context{Cluster: "upcloud-example", User: "upcloud-example"}, ctx)
+	ctx, _ = c.Context("local")
+	assert.Equal(t, "dev", ctx.Namespace)
+
+	// Merging again replaces the entries and can switch the current context
+	require.NoError(t, MergeFile(path, []byte(upcloudKubeconfig), "upcloud-example", WithCurrentContext()))
+	c, err = Load(path)
+	require.NoError(t, err)
+	assert.Len(t, c.Clusters, 2)
+	assert.Len(t, c.Users, 2)
+	assert.Len(t, c.Contexts, 2)
+	assert.Equal(t, "upcloud-example", c.CurrentContext)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestMergeFile_new(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, MergeFile(path, []byte(upcloudKubeconfig), "example"))
+	c, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", c.APIVersion)
+	assert.Equal(t, "Config", c.Kind)
+	assert.Equal(t, "example", c.CurrentContext)
+	assert.Len(t, c.Contexts, 1)
+}
+
+func TestMerge_errors(t *testing.T) {
+	t.Parallel()
+
+	src, err := Parse([]byte(upcloudKubeconfig))
+	require.NoError(t, err)
+	assert.EqualError(t, New().Merge(src, ""), "context name is required")
+
+	src.Users = nil
+	assert.EqualError(t, New().Merge(src, "example"), `user "example-admin" not found in kubeconfig`)
+
+	src.CurrentContext = "missing"
+	assert.EqualError(t, New().Merge(src, "example"), `context "missing" not found in kubeconfig`)
+
+	_, err = Parse([]byte("clusters: {"))
+	assert.Error(t, err)
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/tmp/a"+string(filepath.ListSeparator)+"/tmp/b")
+	p, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/a", p)
+}
@@ -84,6 +84,26 @@ type KubernetesNode struct {
 	State KubernetesNodeState `json:"state,omitempty"`
 }
 
+// RunningNodes returns the nodes that are in running state
+func (d KubernetesNodeGroupDetails) RunningNodes() []KubernetesNode {
+	nodes := make([]KubernetesNode, 0, len(d.Nodes))
+	for _, n := range d.Nodes {
+		if n.State == KubernetesNodeStateRunning {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// NodeStates returns the number of nodes in each state
+func (d KubernetesNodeGroupDetails) NodeStates() map[KubernetesNodeState]int {
+	states := make(map[KubernetesNodeState]int)
+	for _, n := range d.Nodes {
+		states[n.State]++
+	}
+	return states
+}
+
 type KubernetesKubeletArg struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
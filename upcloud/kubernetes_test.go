@@ -245,3 +245,15 @@ func exampleKubernetesNodeGroup(antiAffinity bool) KubernetesNodeGroup {
 		UtilityNetworkAccess: true,
 	}
 }
+
+func TestKubernetesNodeGroupDetailsNodes(t *testing.T) {
+	t.Parallel()
+
+	d := KubernetesNodeGroupDetails{Nodes: []KubernetesNode{
+		{Name: "node-1", State: KubernetesNodeStateRunning},
+		{Name: "node-2", State: KubernetesNodeStatePending},
+		{Name: "node-3", State: KubernetesNodeStatePending},
+	}}
+	assert.Equal(t, []KubernetesNode{{Name: "node-1", State: KubernetesNodeStateRunning}}, d.RunningNodes())
+	assert.Equal(t, map[KubernetesNodeState]int{KubernetesNodeStateRunning: 1, KubernetesNodeStatePending: 2}, d.NodeStates())
+}
@@ -1,6 +1,9 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+)
 
 // Label represents a key-value pair label in a response.
 type Label struct {
@@ -15,17 +18,12 @@ type LabelSlice []Label
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (ls *LabelSlice) UnmarshalJSON(b []byte) error {
+	*ls = nil
 	wrapper := struct {
-		Labels []Label `json:"label"`
-	}{}
-	err := json.Unmarshal(b, &wrapper)
-	if err != nil {
-		return err
-	}
+		Labels *[]Label `json:"label"`
+	}{(*[]Label)(ls)}
 
-	(*ls) = wrapper.Labels
-
-	return nil
+	return json.Unmarshal(b, &wrapper)
 }
 
 // MarshalJSON is a custom marshaller that deals with
@@ -41,3 +39,22 @@ func (ls LabelSlice) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(wrapper)
 }
+
+// NewLabels returns the labels of the map sorted by key.
+func NewLabels(m map[string]string) []Label {
+	labels := make([]Label, 0, len(m))
+	for key, value := range m {
+		labels = append(labels, Label{Key: key, Value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Key < labels[j].Key })
+	return labels
+}
+
+// LabelMap returns the labels as a map from key to value.
+func LabelMap(labels []Label) map[string]string {
+	m := make(map[string]string, len(labels))
+	for _, l := range labels {
+		m[l.Key] = l.Value
+	}
+	return m
+}
@@ -0,0 +1,119 @@
+package upcloud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelOperator is the operator of a label selector requirement.
+type LabelOperator string
+
+const (
+	LabelOperatorEquals       LabelOperator = "="
+	LabelOperatorNotEquals    LabelOperator = "!="
+	LabelOperatorExists       LabelOperator = "exists"
+	LabelOperatorDoesNotExist LabelOperator = "!"
+)
+
+// LabelRequirement is a single requirement of a label selector.
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Value    string
+}
+
+// Matches returns true if the labels satisfy the requirement.
+func (r LabelRequirement) Matches(labels []Label) bool {
+	value, ok := labelValue(labels, r.Key)
+	switch r.Operator {
+	case LabelOperatorEquals:
+		return ok && value == r.Value
+	case LabelOperatorNotEquals:
+		return !ok || value != r.Value
+	case LabelOperatorExists:
+		return ok
+	case LabelOperatorDoesNotExist:
+		return !ok
+	}
+	return false
+}
+
+// String returns the requirement in label selector syntax.
+func (r LabelRequirement) String() string {
+	switch r.Operator {
+	case LabelOperatorExists:
+		return r.Key
+	case LabelOperatorDoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key + string(r.Operator) + r.Value
+}
+
+// LabelSelector is a set of label requirements that all must be satisfied.
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses a comma separated list of label requirements. Supported requirements are key=value,
+// key==value, key!=value, key (label exists) and !key (label does not exist), e.g. "env=prod,team!=x".
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var s LabelSelector
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var r LabelRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			r.Key, r.Value, _ = strings.Cut(part, "!=")
+			r.Operator = LabelOperatorNotEquals
+		case strings.Contains(part, "=="):
+			r.Key, r.Value, _ = strings.Cut(part, "==")
+			r.Operator = LabelOperatorEquals
+		case strings.Contains(part, "="):
+			r.Key, r.Value, _ = strings.Cut(part, "=")
+			r.Operator = LabelOperatorEquals
+		case strings.HasPrefix(part, "!"):
+			r.Key = strings.TrimPrefix(part, "!")
+			r.Operator = LabelOperatorDoesNotExist
+		default:
+			r.Key = part
+			r.Operator = LabelOperatorExists
+		}
+
+		r.Key, r.Value = strings.TrimSpace(r.Key), strings.TrimSpace(r.Value)
+		if r.Key == "" || strings.ContainsAny(r.Key, "=!") || strings.ContainsAny(r.Value, "=!") {
+			return nil, fmt.Errorf("invalid label selector requirement %q", part)
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+// Matches returns true if the labels satisfy all requirements of the selector. Empty selector matches everything.
+func (s LabelSelector) Matches(labels []Label) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in label selector syntax.
+func (s LabelSelector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func labelValue(labels []Label, key string) (string, bool) {
+	for _, l := range labels {
+		if l.Key == key {
+			return l.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,52 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	s, err := ParseLabelSelector("env=prod, team!=x,tier==web,managed,!legacy")
+	require.NoError(t, err)
+	assert.Equal(t, LabelSelector{
+		{Key: "env", Operator: LabelOperatorEquals, Value: "prod"},
+		{Key: "team", Operator: LabelOperatorNotEquals, Value: "x"},
+		{Key: "tier", Operator: LabelOperatorEquals, Value: "web"},
+		{Key: "managed", Operator: LabelOperatorExists},
+		{Key: "legacy", Operator: LabelOperatorDoesNotExist},
+	}, s)
+	assert.Equal(t, "env=prod,team!=x,tier=web,managed,!legacy", s.String())
+
+	s, err = ParseLabelSelector("")
+	require.NoError(t, err)
+	assert.Empty(t, s)
+
+	for _, invalid := range []string{"=prod", "env=a=b", "!", "env!=!x"} {
+		_, err = ParseLabelSelector(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := LabelSlice{{Key: "env", Value: "prod"}, {Key: "managed", Value: ""}}
+
+	for selector, want := range map[string]bool{
+		"":                    true,
+		"env=prod":            true,
+		"env=dev":             false,
+		"env!=dev,managed":    true,
+		"team!=x":             true,
+		"env!=prod":           false,
+		"!legacy":             true,
+		"!managed":            false,
+		"env=prod,team":       false,
+		"env=prod,managed=":   true,
+		"env=prod,!team,!foo": true,
+	} {
+		s, err := ParseLabelSelector(selector)
+		require.NoError(t, err, selector)
+		assert.Equal(t, want, s.Matches(labels), selector)
+	}
+}
@@ -51,3 +51,11 @@ func TestUnmarshalLabelSlice(t *testing.T) {
 		assert.Equal(t, label.Value, v.Value)
 	}
 }
+
+func TestNewLabelsAndLabelMap(t *testing.T) {
+	m := map[string]string{"team": "x", "env": "prod"}
+	labels := NewLabels(m)
+	assert.Equal(t, []Label{{Key: "env", Value: "prod"}, {Key: "team", Value: "x"}}, labels)
+	assert.Equal(t, m, LabelMap(labels))
+	assert.Empty(t, NewLabels(nil))
+}
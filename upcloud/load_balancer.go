@@ -1,6 +1,7 @@
 package upcloud
 
 import (
+	"sort"
 	"time"
 )
 
@@ -382,6 +383,33 @@ type LoadBalancerCertificateBundle struct {
 	OperationalState LoadBalancerCertificateBundleOperationalState `json:"operational_state,omitempty"`
 }
 
+// ExpiresWithin returns true if the certificate of the bundle expires within the specified duration from now.
+// Bundles without a certificate, e.g. dynamic bundles that have not been issued yet, never expire.
+func (b LoadBalancerCertificateBundle) ExpiresWithin(d time.Duration) bool {
+	if b.NotAfter.IsZero() {
+		return false
+	}
+	return time.Until(b.NotAfter) < d
+}
+
+// LoadBalancerCertificateBundles is a list of certificate bundles
+type LoadBalancerCertificateBundles []LoadBalancerCertificateBundle
+
+// ExpiringBundles returns the bundles whose certificate expires within the threshold from now, soonest expiring first.
+// Already expired bundles are included.
+func (b LoadBalancerCertificateBundles) ExpiringBundles(threshold time.Duration) LoadBalancerCertificateBundles {
+	expiring := make(LoadBalancerCertificateBundles, 0)
+	for _, bundle := range b {
+		if bundle.ExpiresWithin(threshold) {
+			expiring = append(expiring, bundle)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+	})
+	return expiring
+}
+
 // LoadBalancerNetwork represents network attached to loadbalancer
 type LoadBalancerNetwork struct {
 	UUID        string                    `json:"uuid,omitempty"`
@@ -0,0 +1,101 @@
+package upcloud
+
+import "fmt"
+
+// LoadBalancerFrontendRulePriorityMax is the highest priority a frontend rule can have. Rules with higher priority are
+// evaluated first.
+const LoadBalancerFrontendRulePriorityMax int = 100
+
+// LoadBalancerFrontendRulePriorities computes new priorities for the rules so that they are evaluated in the specified
+// order, first rule having the highest priority. Order must contain the name of each rule exactly once. Priorities are
+// only changed where needed to keep the existing ones, and the returned map contains only the rules whose priority
+// changes.
+func LoadBalancerFrontendRulePriorities(rules []LoadBalancerFrontendRule, order []string) (map[string]int, error) {
+	current := make(map[string]int, len(rules))
+	for _, r := range rules {
+		current[r.Name] = r.Priority
+	}
+	if len(order) != len(rules) {
+		return nil, fmt.Errorf("order has %d rules, frontend has %d rules", len(order), len(rules))
+	}
+	if len(order) > LoadBalancerFrontendRulePriorityMax+1 {
+		return nil, fmt.Errorf("unable to order more than %d rules", LoadBalancerFrontendRulePriorityMax+1)
+	}
+
+	n := len(order)
+	p := make([]int, n)
+	seen := make(map[string]bool, n)
+	for i, name := range order {
+		priority, ok := current[name]
+		if !ok {
+			return nil, fmt.Errorf("rule %s not found", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("rule %s is listed more than once", name)
+		}
+		seen[name] = true
+		p[i] = priority
+	}
+
+	// Find the largest set of rules that can keep their priority. Kept priorities must be strictly decreasing and leave
+	// room for the rules in between them as well as for the rules before the first and after the last kept rule.
+	fits := func(i int) bool {
+		return p[i] >= n-1-i && p[i] <= LoadBalancerFrontendRulePriorityMax-i
+	}
+	kept := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+	for j := 0; j < n; j++ {
+		prev[j] = -1
+		if !fits(j) {
+			continue
+		}
+		kept[j] = 1
+		for i := 0; i < j; i++ {
+			if kept[i] > 0 && p[i]-p[j] >= j-i && kept[i]+1 > kept[j] {
+				kept[j] = kept[i] + 1
+				prev[j] = i
+			}
+		}
+		if best < 0 || kept[j] > kept[best] {
+			best = j
+		}
+	}
+
+	priorities := make([]int, n)
+	if best < 0 {
+		for i := range priorities {
+			priorities[i] = n - 1 - i
+		}
+	} else {
+		anchors := make([]bool, n)
+		for i := best; i >= 0; i = prev[i] {
+			anchors[i] = true
+			priorities[i] = p[i]
+		}
+		// Rules after an anchor count down from it; rules before the first anchor count up from it.
+		last := -1
+		for i := 0; i < n; i++ {
+			if anchors[i] {
+				last = i
+				continue
+			}
+			if last >= 0 {
+				priorities[i] = priorities[i-1] - 1
+			}
+		}
+		for i := n - 1; i >= 0; i-- {
+			if !anchors[i] && i < n-1 && priorities[i] <= priorities[i+1] {
+				priorities[i] = priorities[i+1] + 1
+			}
+		}
+	}
+
+	changes := make(map[string]int)
+	for i, name := range order {
+		if priorities[i] != current[name] {
+			changes[name] = priorities[i]
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,50 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBalancerFrontendRulePriorities(t *testing.T) {
+	t.Parallel()
+
+	rules := []LoadBalancerFrontendRule{
+		{Name: "api", Priority: 50},
+		{Name: "static", Priority: 40},
+		{Name: "default", Priority: 0},
+		{Name: "health", Priority: 0},
+	}
+
+	// Only the moved rule gets a new priority
+	changes, err := LoadBalancerFrontendRulePriorities(rules, []string{"api", "health", "static", "default"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"health": 49}, changes)
+
+	// Rules before the first kept rule count up from it and rules after the last kept rule count down
+	changes, err = LoadBalancerFrontendRulePriorities(rules, []string{"health", "static", "api", "default"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"health": 41, "api": 39}, changes)
+
+	// Existing order needs no changes
+	changes, err = LoadBalancerFrontendRulePriorities(rules[:3], []string{"api", "static", "default"})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Priorities without room in between are recomputed
+	changes, err = LoadBalancerFrontendRulePriorities([]LoadBalancerFrontendRule{
+		{Name: "a", Priority: 100},
+		{Name: "b", Priority: 100},
+		{Name: "c", Priority: 100},
+	}, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"b": 99, "c": 98}, changes)
+
+	_, err = LoadBalancerFrontendRulePriorities(rules, []string{"api", "static", "default"})
+	assert.EqualError(t, err, "order has 3 rules, frontend has 4 rules")
+	_, err = LoadBalancerFrontendRulePriorities(rules, []string{"api", "static", "default", "missing"})
+	assert.EqualError(t, err, "rule missing not found")
+	_, err = LoadBalancerFrontendRulePriorities(rules, []string{"api", "static", "default", "api"})
+	assert.EqualError(t, err, "rule api is listed more than once")
+}
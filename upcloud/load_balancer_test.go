@@ -3,6 +3,7 @@ package upcloud
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -1141,3 +1142,22 @@ func testJSON(t *testing.T, unMarshall, marshall interface{}, want string) {
 	assert.NoError(t, err)
 	assert.Equal(t, marshall, unMarshall)
 }
+
+func TestLoadBalancerCertificateBundlesExpiringBundles(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	bundles := LoadBalancerCertificateBundles{
+		{UUID: "valid", NotAfter: now.Add(90 * 24 * time.Hour)},
+		{UUID: "expiring", NotAfter: now.Add(10 * 24 * time.Hour)},
+		{UUID: "pending", Type: LoadBalancerCertificateBundleTypeDynamic},
+		{UUID: "expired", NotAfter: now.Add(-time.Hour)},
+	}
+	var uuids []string
+	for _, b := range bundles.ExpiringBundles(30 * 24 * time.Hour) {
+		uuids = append(uuids, b.UUID)
+	}
+	assert.Equal(t, []string{"expired", "expiring"}, uuids)
+	assert.Len(t, bundles.ExpiringBundles(0), 1)
+	assert.False(t, bundles[2].ExpiresWithin(time.Hour))
+}
@@ -0,0 +1,225 @@
+package upcloud
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+// Types returns the JSON types accepted by the property. Type can be either a single type or a list of types in the
+// service type metadata.
+func (p ManagedDatabaseServiceProperty) Types() []string {
+	switch t := p.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// EnumValues returns the allowed values of the property, or nil if the property is not an enum.
+func (p ManagedDatabaseServiceProperty) EnumValues() []interface{} {
+	if p.Enum == nil {
+		return nil
+	}
+	v := reflect.ValueOf(p.Enum)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
+
+// Validate checks that the value matches the type, enum, range, length and pattern constraints of the property.
+func (p ManagedDatabaseServiceProperty) Validate(value interface{}) error {
+	if types := p.Types(); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if matchesPropertyType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("expected value of type %v, got %T", types, value)
+		}
+	}
+	if value == nil {
+		return nil
+	}
+
+	if enum := p.EnumValues(); enum != nil {
+		found := false
+		for _, e := range enum {
+			if propertyValuesEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("value %v is not one of %v", value, enum)
+		}
+	}
+
+	if n, ok := propertyNumber(value); ok {
+		if p.Minimum != nil && n < *p.Minimum {
+			return fmt.Errorf("value %v is less than minimum %v", value, *p.Minimum)
+		}
+		if p.Maximum != nil && n > *p.Maximum {
+			return fmt.Errorf("value %v is greater than maximum %v", value, *p.Maximum)
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		length := utf8.RuneCountInString(s)
+		if p.MinLength > 0 && length < p.MinLength {
+			return fmt.Errorf("value is shorter than minimum length %d", p.MinLength)
+		}
+		if p.MaxLength > 0 && length > p.MaxLength {
+			return fmt.Errorf("value is longer than maximum length %d", p.MaxLength)
+		}
+		// Patterns that are not supported by the regexp package are skipped and left for the API to validate.
+		if re, err := regexp.Compile(p.Pattern); p.Pattern != "" && err == nil && !re.MatchString(s) {
+			return fmt.Errorf("value %q does not match pattern %s", s, p.Pattern)
+		}
+	}
+
+	if len(p.Properties) > 0 {
+		if m, ok := propertyObject(value); ok {
+			return validateProperties(p.Properties, m, "", false)
+		}
+	}
+	return nil
+}
+
+// Validate checks user supplied properties against the property metadata of the service type. Unknown properties and
+// values not matching the property constraints are reported. This can be used to validate properties of
+// CreateManagedDatabaseRequest before sending it to the API, see also ValidateModify.
+func (t *ManagedDatabaseType) Validate(properties map[ManagedDatabasePropertyKey]interface{}) error {
+	return t.validate(properties, false)
+}
+
+// ValidateModify works like Validate, but also reports properties that can only be set when the database is created.
+// This can be used to validate properties of ModifyManagedDatabaseRequest before sending it to the API.
+func (t *ManagedDatabaseType) ValidateModify(properties map[ManagedDatabasePropertyKey]interface{}) error {
+	return t.validate(properties, true)
+}
+
+func (t *ManagedDatabaseType) validate(properties map[ManagedDatabasePropertyKey]interface{}, modify bool) error {
+	m := make(map[string]interface{}, len(properties))
+	for k, v := range properties {
+		m[string(k)] = v
+	}
+	return validateProperties(t.Properties, m, "", modify)
+}
+
+func validateProperties(schema map[string]ManagedDatabaseServiceProperty, values map[string]interface{}, prefix string, modify bool) error {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, k := range keys {
+		name := prefix + k
+		p, ok := schema[k]
+		if !ok {
+			errs = append(errs, fmt.Errorf("property %s: unknown property", name))
+			continue
+		}
+		if modify && p.CreateOnly {
+			errs = append(errs, fmt.Errorf("property %s: can only be set when creating the database", name))
+			continue
+		}
+		if len(p.Properties) > 0 {
+			if m, ok := propertyObject(values[k]); ok {
+				if err := validateProperties(p.Properties, m, name+".", modify); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+		}
+		if err := p.Validate(values[k]); err != nil {
+			errs = append(errs, fmt.Errorf("property %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func matchesPropertyType(t string, value interface{}) bool {
+	if value == nil {
+		return t == "null"
+	}
+	switch t {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		n, ok := propertyNumber(value)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := propertyNumber(value)
+		return ok
+	case "array":
+		k := reflect.ValueOf(value).Kind()
+		return k == reflect.Slice || k == reflect.Array
+	case "object":
+		_, ok := propertyObject(value)
+		return ok
+	}
+	return false
+}
+
+func propertyNumber(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func propertyObject(value interface{}) (map[string]interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+func propertyValuesEqual(a, b interface{}) bool {
+	if x, ok := propertyNumber(a); ok {
+		y, ok := propertyNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
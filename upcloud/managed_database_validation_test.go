@@ -0,0 +1,76 @@
+package upcloud
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedDatabaseTypeValidate(t *testing.T) {
+	t.Parallel()
+
+	var dbType ManagedDatabaseType
+	require.NoError(t, json.Unmarshal([]byte(`
+		{
+			"name": "pg",
+			"properties": {
+				"automatic_utility_network_ip_filter": {"type": "boolean", "default": true, "title": "Automatic utility network IP Filter"},
+				"admin_username": {"type": ["string", "null"], "createOnly": true, "maxLength": 64, "pattern": "^[_A-Za-z0-9][-._A-Za-z0-9]{0,63}$", "title": "Custom username for admin user"},
+				"backup_hour": {"type": ["integer", "null"], "minimum": 0, "maximum": 23, "title": "Hour of day when backup is started"},
+				"ip_filter": {"type": "array", "maxItems": 1024, "title": "IP filter"},
+				"pg_stat_monitor_enable": {"type": "boolean", "title": "Enable pg_stat_monitor"},
+				"synchronous_replication": {"type": "string", "enum": ["quorum", "off"], "title": "Synchronous replication type"},
+				"pglookout": {
+					"type": "object",
+					"title": "PGLookout settings",
+					"properties": {
+						"max_failover_replication_time_lag": {"type": "integer", "minimum": 10, "title": "Max failover replication time lag"}
+					}
+				}
+			}
+		}
+	`), &dbType))
+
+	assert.Equal(t, []string{"string", "null"}, dbType.Properties["admin_username"].Types())
+	assert.Equal(t, []interface{}{"quorum", "off"}, dbType.Properties["synchronous_replication"].EnumValues())
+	assert.Nil(t, dbType.Properties["backup_hour"].EnumValues())
+
+	assert.NoError(t, dbType.Validate(map[ManagedDatabasePropertyKey]interface{}{
+		ManagedDatabasePropertyAutoUtilityIPFilter: true,
+		"admin_username":          "admin",
+		"backup_hour":             float64(2),
+		"ip_filter":               []string{"10.0.0.0/8"},
+		"synchronous_replication": "quorum",
+		"pglookout":               map[string]interface{}{"max_failover_replication_time_lag": 60},
+	}))
+	assert.NoError(t, dbType.Validate(map[ManagedDatabasePropertyKey]interface{}{"backup_hour": nil}))
+
+	err := dbType.Validate(map[ManagedDatabasePropertyKey]interface{}{
+		"admin_username":          "-admin",
+		"backup_hour":             24,
+		"ip_filter":               "10.0.0.0/8",
+		"pg_stat_monitor_enable":  "yes",
+		"synchronous_replication": "on",
+		"pglookout":               map[string]interface{}{"max_failover_replication_time_lag": 1, "unknown": 1},
+		"unknown":                 1,
+	})
+	require.Error(t, err)
+	assert.Equal(t, `property admin_username: value "-admin" does not match pattern ^[_A-Za-z0-9][-._A-Za-z0-9]{0,63}$
+property backup_hour: value 24 is greater than maximum 23
+property ip_filter: expected value of type [array], got string
+property pg_stat_monitor_enable: expected value of type [boolean], got string
+property pglookout.max_failover_replication_time_lag: value 1 is less than minimum 10
+property pglookout.unknown: unknown property
+property synchronous_replication: value on is not one of [quorum off]
+property unknown: unknown property`, err.Error())
+
+	assert.EqualError(t, dbType.Validate(map[ManagedDatabasePropertyKey]interface{}{"backup_hour": 1.5}),
+		"property backup_hour: expected value of type [integer null], got float64")
+
+	// Create only properties can not be modified
+	props := map[ManagedDatabasePropertyKey]interface{}{"admin_username": "admin", "backup_hour": 1}
+	assert.NoError(t, dbType.Validate(props))
+	assert.EqualError(t, dbType.ValidateModify(props), "property admin_username: can only be set when creating the database")
+}
@@ -141,3 +141,10 @@ type ManagedObjectStorageMetrics struct {
 	TotalObjects   int `json:"total_objects"`
 	TotalSizeBytes int `json:"total_size_bytes"`
 }
+
+// ManagedObjectStorageUsage represents service level metrics of a Managed Object Storage service together with
+// metrics of all of its buckets
+type ManagedObjectStorageUsage struct {
+	ManagedObjectStorageMetrics
+	Buckets []ManagedObjectStorageBucketMetrics `json:"buckets"`
+}
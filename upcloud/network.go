@@ -1,6 +1,9 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"slices"
+)
 
 type RouterStaticRouteType string
 
@@ -23,17 +26,12 @@ type ServerInterfaceSlice []ServerInterface
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *ServerInterfaceSlice) UnmarshalJSON(b []byte) error {
+	*s = nil
 	v := struct {
-		Interfaces []ServerInterface `json:"interface"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-
-	(*s) = v.Interfaces
+		Interfaces *[]ServerInterface `json:"interface"`
+	}{(*[]ServerInterface)(s)}
 
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Networking represents networking in a response
@@ -46,17 +44,12 @@ type Networking struct {
 func (s *Networking) UnmarshalJSON(b []byte) error {
 	type localNetworking Networking
 
+	*s = Networking{}
 	v := struct {
-		Networking localNetworking `json:"networking"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		Networking *localNetworking `json:"networking"`
+	}{(*localNetworking)(s)}
 
-	(*s) = Networking(v.Networking)
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Interface represents a network interface in a response
@@ -75,17 +68,12 @@ type Interface struct {
 func (s *Interface) UnmarshalJSON(b []byte) error {
 	type localInterface Interface
 
+	*s = Interface{}
 	v := struct {
-		Interface localInterface `json:"interface"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-
-	(*s) = Interface(v.Interface)
+		Interface *localInterface `json:"interface"`
+	}{(*localInterface)(s)}
 
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // IPNetwork represents an IP network in a response.
@@ -106,17 +94,12 @@ type IPNetworkSlice []IPNetwork
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (t *IPNetworkSlice) UnmarshalJSON(b []byte) error {
+	*t = nil
 	v := struct {
-		IPNetworks []IPNetwork `json:"ip_network"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-
-	(*t) = v.IPNetworks
+		IPNetworks *[]IPNetwork `json:"ip_network"`
+	}{(*[]IPNetwork)(t)}
 
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // MarshalJSON is a custom marshaller that deals with
@@ -140,17 +123,12 @@ type NetworkServerSlice []NetworkServer
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (t *NetworkServerSlice) UnmarshalJSON(b []byte) error {
+	*t = nil
 	v := struct {
-		NetworkServers []NetworkServer `json:"server"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		NetworkServers *[]NetworkServer `json:"server"`
+	}{(*[]NetworkServer)(t)}
 
-	(*t) = v.NetworkServers
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // NetworkServer represents a server in a networking response
@@ -176,17 +154,12 @@ type Network struct {
 func (s *Network) UnmarshalJSON(b []byte) error {
 	type localNetwork Network
 
+	*s = Network{}
 	v := struct {
-		Network localNetwork `json:"network"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-
-	(*s) = Network(v.Network)
+		Network *localNetwork `json:"network"`
+	}{(*localNetwork)(s)}
 
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Networks represents multiple networks in a GetNetworks and GetNetworksInZone response.
@@ -210,6 +183,7 @@ func (n *Networks) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	n.Networks = slices.Grow(n.Networks, len(v.Networks.Networks))
 	for _, ln := range v.Networks.Networks {
 		n.Networks = append(n.Networks, Network(ln))
 	}
@@ -224,17 +198,12 @@ type NetworkSlice []Network
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (t *NetworkSlice) UnmarshalJSON(b []byte) error {
+	*t = nil
 	v := struct {
-		Networks []Network `json:"network"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		Networks *[]Network `json:"network"`
+	}{(*[]Network)(t)}
 
-	(*t) = v.Networks
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Routers represents a response to a GetRouters request
@@ -258,6 +227,7 @@ func (n *Routers) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	n.Routers = slices.Grow(n.Routers, len(v.Routers.Routers))
 	for _, ln := range v.Routers.Routers {
 		n.Routers = append(n.Routers, Router(ln))
 	}
@@ -277,17 +247,12 @@ type RouterNetworkSlice []RouterNetwork
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (t *RouterNetworkSlice) UnmarshalJSON(b []byte) error {
+	*t = nil
 	v := struct {
-		Networks []RouterNetwork `json:"network"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-
-	(*t) = v.Networks
+		Networks *[]RouterNetwork `json:"network"`
+	}{(*[]RouterNetwork)(t)}
 
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Router represents a Router in a response
@@ -313,15 +278,10 @@ type StaticRoute struct {
 func (s *Router) UnmarshalJSON(b []byte) error {
 	type localRouter Router
 
+	*s = Router{}
 	v := struct {
-		Router localRouter `json:"router"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		Router *localRouter `json:"router"`
+	}{(*localRouter)(s)}
 
-	(*s) = Router(v.Router)
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
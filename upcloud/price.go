@@ -1,6 +1,10 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
 
 // PriceZones represents a /price response
 type PriceZones struct {
@@ -49,6 +53,79 @@ type PriceZone struct {
 	StorageBackup          *Price `json:"storage_backup"`
 	StorageMaxIOPS         *Price `json:"storage_maxiops"`
 	StorageTemplate        *Price `json:"storage_template"`
+
+	// Items contains all price items of the zone keyed by item name, including the ones not listed above.
+	Items map[string]*Price `json:"-"`
+}
+
+// Price item name prefixes used to derive per-zone capabilities from the price listing
+const (
+	priceItemServerPlanPrefix = "server_plan_"
+	priceItemStoragePrefix    = "storage_"
+)
+
+// UnmarshalJSON is a custom unmarshaller that also collects every price item of the zone into Items.
+func (s *PriceZone) UnmarshalJSON(b []byte) error {
+	type localPriceZone PriceZone
+
+	v := localPriceZone{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	items := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+
+	v.Items = make(map[string]*Price, len(items))
+	for name, raw := range items {
+		price := Price{}
+		if err := json.Unmarshal(raw, &price); err != nil {
+			// Not a price item, e.g. the zone name
+			continue
+		}
+		v.Items[name] = &price
+	}
+
+	(*s) = PriceZone(v)
+
+	return nil
+}
+
+// Has returns true if the zone has a price for the specified item, e.g. "firewall" or "server_plan_1xCPU-1GB".
+func (s *PriceZone) Has(item string) bool {
+	_, ok := s.Items[item]
+	return ok
+}
+
+// Plans returns names of the server plans that are priced, and thus available, in the zone.
+func (s *PriceZone) Plans() []string {
+	return s.itemsWithPrefix(priceItemServerPlanPrefix)
+}
+
+// StorageTiers returns storage tiers that are priced, and thus available, in the zone.
+// Backup and template storage prices are not considered to be tiers.
+func (s *PriceZone) StorageTiers() []string {
+	tiers := make([]string, 0)
+	for _, tier := range s.itemsWithPrefix(priceItemStoragePrefix) {
+		if tier == StorageTypeBackup || tier == StorageTypeTemplate {
+			continue
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
+
+func (s *PriceZone) itemsWithPrefix(prefix string) []string {
+	names := make([]string, 0)
+	for name := range s.Items {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, strings.TrimPrefix(name, prefix))
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // Price represents a price
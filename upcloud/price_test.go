@@ -173,3 +173,57 @@ func TestUmarshalPriceZones(t *testing.T) {
 
 	// TODO: Test the remaining fields
 }
+
+// TestPriceZoneItems tests that all price items are collected and plans and storage tiers are derived from them
+func TestPriceZoneItems(t *testing.T) {
+	originalJSON := `
+{
+  "name": "fi-hel1",
+  "firewall": {
+    "amount": 1,
+    "price": 0.56
+  },
+  "network_private_vlan": {
+    "amount": 1,
+    "price": 0.694
+  },
+  "storage_backup": {
+    "amount": 1,
+    "price": 0.007
+  },
+  "storage_maxiops": {
+    "amount": 1,
+    "price": 0.028
+  },
+  "storage_standard": {
+    "amount": 1,
+    "price": 0.02
+  },
+  "server_plan_2xCPU-4GB": {
+    "amount": 1,
+    "price": 4.4642
+  },
+  "server_plan_1xCPU-2GB": {
+    "amount": 1,
+    "price": 2.2321
+  }
+}
+`
+	zone := PriceZone{}
+	err := json.Unmarshal([]byte(originalJSON), &zone)
+	assert.NoError(t, err)
+	assert.Equal(t, "fi-hel1", zone.Name)
+	assert.Equal(t, 0.56, zone.Firewall.Price)
+	assert.Len(t, zone.Items, 7)
+	assert.Equal(t, 0.694, zone.Items["network_private_vlan"].Price)
+	assert.True(t, zone.Has("network_private_vlan"))
+	assert.False(t, zone.Has("name"))
+	assert.Equal(t, []string{"1xCPU-2GB", "2xCPU-4GB"}, zone.Plans())
+	assert.Equal(t, []string{StorageTierMaxIOPS, StorageTierStandard}, zone.StorageTiers())
+
+	capabilities := NewZoneCapabilities(Zone{ID: "fi-hel1"}, zone, []Plan{{Name: "1xCPU-2GB"}})
+	assert.Equal(t, []string{"1xCPU-2GB"}, capabilities.Plans)
+	assert.True(t, capabilities.HasStorageTier(StorageTierStandard))
+	assert.False(t, capabilities.HasStorageTier(StorageTierHDD))
+	assert.Equal(t, []string{ZoneFeatureFirewall, ZoneFeaturePrivateNetwork, ZoneFeatureBackup}, capabilities.Features)
+}
@@ -1,7 +1,9 @@
 package upcloud
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 )
@@ -20,6 +22,8 @@ type Problem struct {
 	CorrelationID string `json:"correlation_id,omitempty"`
 	// HTTP Status code
 	Status int `json:"status"`
+	// RequestID is the client-side ID sent in the X-Request-ID header of the request that caused the problem
+	RequestID string `json:"-"`
 }
 
 // ProblemInvalidParam is a type describing extra information in the Problem type's InvalidParams field.
@@ -34,6 +38,9 @@ func (p *Problem) Error() string {
 	if p.CorrelationID != "" {
 		_, _ = fmt.Fprintf(&sb, ", correlation_id=%s", p.CorrelationID)
 	}
+	if p.RequestID != "" {
+		_, _ = fmt.Fprintf(&sb, ", request_id=%s", p.RequestID)
+	}
 	if len(p.InvalidParams) > 0 {
 		for _, ip := range p.InvalidParams {
 			_, _ = fmt.Fprintf(&sb, ", invalid_params_%s='%s'", ip.Name, ip.Reason)
@@ -54,3 +61,60 @@ func (p *Problem) ErrorCode() string {
 
 	return strings.Replace(parsedURL.Fragment, "ERROR_", "", 1)
 }
+
+// IsNotFound reports whether err is a problem returned by the API because the resource does not exist
+func IsNotFound(err error) bool {
+	problem, ok := asProblem(err)
+	return ok && (problem.Status == http.StatusNotFound || strings.HasSuffix(problem.ErrorCode(), "_NOT_FOUND"))
+}
+
+// IsConflict reports whether err is a problem returned by the API because the request conflicts with the current
+// state of the resource, e.g. the server is not in a state that allows the operation or the resource already exists.
+// Conflicts can often be resolved by waiting for the resource to change state and retrying.
+func IsConflict(err error) bool {
+	problem, ok := asProblem(err)
+	if !ok {
+		return false
+	}
+	code := problem.ErrorCode()
+	return problem.Status == http.StatusConflict ||
+		strings.HasSuffix(code, "_STATE_ILLEGAL") ||
+		strings.HasSuffix(code, "_EXISTS") ||
+		strings.HasSuffix(code, "_IN_USE") ||
+		strings.HasPrefix(code, "DUPLICATE_")
+}
+
+// IsQuotaExceeded reports whether err is a problem returned by the API because a limit of the account has been reached
+// or the account has insufficient credits
+func IsQuotaExceeded(err error) bool {
+	problem, ok := asProblem(err)
+	if !ok {
+		return false
+	}
+	code := problem.ErrorCode()
+	return strings.HasSuffix(code, "_LIMIT_REACHED") || code == ErrCodeInsufficientCredits
+}
+
+// IsMaintenance reports whether err was caused by platform maintenance: the API is temporarily unavailable, the
+// problem is a maintenance error, or the operation is illegal because the server or storage is in maintenance state.
+// Such operations can be retried once the maintenance is over, see service.DeferMaintenance.
+func IsMaintenance(err error) bool {
+	var transitionErr *ServerTransitionError
+	if errors.As(err, &transitionErr) {
+		return transitionErr.State == ServerStateMaintenance
+	}
+	problem, ok := asProblem(err)
+	if !ok {
+		return false
+	}
+	code := problem.ErrorCode()
+	return problem.Status == http.StatusServiceUnavailable ||
+		strings.Contains(code, "MAINTENANCE") ||
+		(strings.HasSuffix(code, "_STATE_ILLEGAL") && strings.Contains(strings.ToLower(problem.Title), ServerStateMaintenance))
+}
+
+func asProblem(err error) (*Problem, bool) {
+	var problem *Problem
+	ok := errors.As(err, &problem)
+	return problem, ok
+}
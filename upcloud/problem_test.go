@@ -2,6 +2,9 @@ package upcloud
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +55,42 @@ func TestProblemErrorCodes(t *testing.T) {
 	assert.Equal(t, ErrCodeServerNotFound, p.ErrorCode())
 	assert.NotEqual(t, "SOME_RANDOM_STRING", p.ErrorCode())
 }
+
+func TestProblemClassification(t *testing.T) {
+	t.Parallel()
+
+	notFound := &Problem{Type: ErrCodeServerNotFound, Status: http.StatusNotFound}
+	stateIllegal := fmt.Errorf("starting server: %w", &Problem{Type: ErrCodeServerStateIllegal, Status: http.StatusConflict})
+	exists := &Problem{Type: "https://developers.upcloud.com/1.3/errors#ERROR_NETWORK_EXISTS", Status: http.StatusBadRequest}
+	quota := &Problem{Type: ErrCodeServerCoresLimitReached, Status: http.StatusConflict}
+	credits := &Problem{Type: ErrCodeInsufficientCredits, Status: http.StatusPaymentRequired}
+
+	assert.True(t, IsNotFound(notFound))
+	assert.True(t, IsNotFound(&Problem{Type: ErrCodeDBNotFound, Status: http.StatusBadRequest}))
+	assert.False(t, IsNotFound(stateIllegal))
+	assert.True(t, IsConflict(stateIllegal))
+	assert.True(t, IsConflict(exists))
+	assert.False(t, IsConflict(notFound))
+	assert.True(t, IsQuotaExceeded(quota))
+	assert.True(t, IsQuotaExceeded(credits))
+	assert.False(t, IsQuotaExceeded(stateIllegal))
+
+	for _, err := range []error{nil, errors.New("SERVER_NOT_FOUND")} {
+		assert.False(t, IsNotFound(err))
+		assert.False(t, IsConflict(err))
+		assert.False(t, IsQuotaExceeded(err))
+	}
+}
+
+func TestIsMaintenance(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsMaintenance(&Problem{Type: "SERVICE_UNAVAILABLE", Status: http.StatusServiceUnavailable}))
+	assert.True(t, IsMaintenance(&Problem{Type: ErrCodeServerStateIllegal, Title: "The server is in maintenance state.", Status: http.StatusConflict}))
+	assert.True(t, IsMaintenance(&Problem{Type: "https://developers.upcloud.com/1.3/errors#ERROR_ZONE_MAINTENANCE", Status: http.StatusConflict}))
+	assert.True(t, IsMaintenance(fmt.Errorf("migrating: %w", &ServerTransitionError{State: ServerStateMaintenance, Operation: ServerOperationMigrate})))
+	assert.False(t, IsMaintenance(&ServerTransitionError{State: ServerStateStarted, Operation: ServerOperationMigrate}))
+	assert.False(t, IsMaintenance(&Problem{Type: ErrCodeServerStateIllegal, Title: "The server is started.", Status: http.StatusConflict}))
+	assert.False(t, IsMaintenance(errors.New("maintenance")))
+	assert.False(t, IsMaintenance(nil))
+}
@@ -110,3 +110,9 @@ type GetAccountDetailsRequest struct {
 func (r *GetAccountDetailsRequest) RequestURL() string {
 	return fmt.Sprintf("/account/details/%s", r.Username)
 }
+
+// GetInventoryRequest represents a request to gather a snapshot of the account's resources
+type GetInventoryRequest struct {
+	// If specified, only resources in this zone are included. Tags are not zone specific and are always included.
+	Zone string
+}
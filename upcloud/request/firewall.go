@@ -2,7 +2,9 @@ package request
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/netip"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 )
@@ -40,6 +42,11 @@ func (r *CreateFirewallRuleRequest) RequestURL() string {
 	return fmt.Sprintf("/server/%s/firewall_rule", r.ServerUUID)
 }
 
+// Validate checks that the firewall rule is valid
+func (r *CreateFirewallRuleRequest) Validate() error {
+	return r.FirewallRule.Validate()
+}
+
 // MarshalJSON is a custom marshaller that deals with
 // deeply embedded values.
 func (r CreateFirewallRuleRequest) MarshalJSON() ([]byte, error) {
@@ -90,3 +97,135 @@ type CreateFirewallRulesRequest struct {
 func (r *CreateFirewallRulesRequest) RequestURL() string {
 	return fmt.Sprintf("/server/%s/firewall_rule", r.ServerUUID)
 }
+
+// Validate checks that the firewall rules are valid
+func (r *CreateFirewallRulesRequest) Validate() error {
+	var errs []error
+	for i := range r.FirewallRules {
+		if err := r.FirewallRules[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("firewall rule %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BootstrapFirewallRequest represents a request to replace the firewall rules of a server with a default-deny
+// baseline and to enable the server firewall.
+type BootstrapFirewallRequest struct {
+	ServerUUID string
+	// AdminCIDRs are the IPv4 and IPv6 networks, e.g. "203.0.113.0/24", allowed to connect to the admin ports
+	AdminCIDRs []string
+	// AdminPorts are the TCP ports open to the admin networks. Defaults to port 22.
+	AdminPorts []int
+	// Rules are additional rules inserted after the baseline allow rules and before the default deny rules
+	Rules []upcloud.FirewallRule
+}
+
+// bootstrapReturnPortStart and bootstrapReturnPortEnd are the Linux ephemeral port range. The firewall does not track
+// connection state, so return traffic of outbound connections is allowed to these ports.
+const (
+	bootstrapReturnPortStart = 32768
+	bootstrapReturnPortEnd   = 60999
+)
+
+// FirewallRules returns the baseline rule set: return traffic of outbound connections, admin networks to admin ports,
+// ICMPv6 neighbor discovery, the additional rules and finally dropping everything else.
+func (r *BootstrapFirewallRequest) FirewallRules() (FirewallRuleSlice, error) {
+	families := []string{upcloud.IPAddressFamilyIPv4, upcloud.IPAddressFamilyIPv6}
+	rules := make(FirewallRuleSlice, 0)
+
+	for _, family := range families {
+		for _, protocol := range []string{upcloud.FirewallRuleProtocolTCP, upcloud.FirewallRuleProtocolUDP} {
+			rule := upcloud.FirewallRule{
+				Action:    upcloud.FirewallRuleActionAccept,
+				Direction: upcloud.FirewallRuleDirectionIn,
+				Family:    family,
+				Protocol:  protocol,
+				Comment:   "Allow return traffic",
+			}
+			rule.SetDestinationPorts(bootstrapReturnPortStart, bootstrapReturnPortEnd)
+			rules = append(rules, rule)
+		}
+	}
+
+	ports := r.AdminPorts
+	if len(ports) == 0 {
+		ports = []int{22}
+	}
+	for _, cidr := range r.AdminCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin CIDR %q: %w", cidr, err)
+		}
+		start, end := prefixRange(prefix)
+		family := upcloud.IPAddressFamilyIPv4
+		if start.Is6() {
+			family = upcloud.IPAddressFamilyIPv6
+		}
+		for _, port := range ports {
+			rule := upcloud.FirewallRule{
+				Action:             upcloud.FirewallRuleActionAccept,
+				Direction:          upcloud.FirewallRuleDirectionIn,
+				Family:             family,
+				Protocol:           upcloud.FirewallRuleProtocolTCP,
+				SourceAddressStart: start.String(),
+				SourceAddressEnd:   end.String(),
+				Comment:            fmt.Sprintf("Allow admin access from %s", prefix.Masked()),
+			}
+			rule.SetDestinationPorts(port, port)
+			rules = append(rules, rule)
+		}
+	}
+
+	// Router solicitation, router advertisement, neighbor solicitation and neighbor advertisement
+	for _, icmpType := range []string{"133", "134", "135", "136"} {
+		rules = append(rules, upcloud.FirewallRule{
+			Action:    upcloud.FirewallRuleActionAccept,
+			Direction: upcloud.FirewallRuleDirectionIn,
+			Family:    upcloud.IPAddressFamilyIPv6,
+			Protocol:  upcloud.FirewallRuleProtocolICMP,
+			ICMPType:  icmpType,
+			Comment:   "Allow ICMPv6 neighbor discovery",
+		})
+	}
+
+	rules = append(rules, r.Rules...)
+
+	for _, family := range families {
+		rules = append(rules, upcloud.FirewallRule{
+			Action:    upcloud.FirewallRuleActionDrop,
+			Direction: upcloud.FirewallRuleDirectionIn,
+			Family:    family,
+			Comment:   "Default deny",
+		})
+	}
+
+	for i := range rules {
+		rules[i].Position = i + 1
+	}
+	return rules, nil
+}
+
+func prefixRange(prefix netip.Prefix) (netip.Addr, netip.Addr) {
+	prefix = prefix.Masked()
+	start := prefix.Addr()
+	end := start.AsSlice()
+	for bit := prefix.Bits(); bit < len(end)*8; bit++ {
+		end[bit/8] |= 1 << (7 - bit%8)
+	}
+	last, _ := netip.AddrFromSlice(end)
+	return start, last
+}
+
+// ImportFirewallRulesRequest represents a request to replace the firewall rules of a server with a ruleset document
+type ImportFirewallRulesRequest struct {
+	ServerUUID string
+	Ruleset    *upcloud.FirewallRuleset
+}
+
+// ApplyFirewallRulesRequest represents a request to make the firewall rules of a server match the desired rules. The
+// rules are keyed by their comments, see upcloud.DiffFirewallRules.
+type ApplyFirewallRulesRequest struct {
+	ServerUUID    string
+	FirewallRules []upcloud.FirewallRule
+}
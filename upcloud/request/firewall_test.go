@@ -150,3 +150,62 @@ func TestCreateFirewallRulesRequest(t *testing.T) {
 	assert.JSONEq(t, expectedJSON, string(actualJSON))
 	assert.Equal(t, "/server/foo/firewall_rule", request.RequestURL())
 }
+
+// TestCreateFirewallRulesRequest_Validate tests that the invalid rules are reported by their position
+func TestCreateFirewallRulesRequest_Validate(t *testing.T) {
+	r := CreateFirewallRulesRequest{
+		FirewallRules: FirewallRuleSlice{
+			{Action: upcloud.FirewallRuleActionAccept, Direction: upcloud.FirewallRuleDirectionIn},
+			{Action: upcloud.FirewallRuleActionAccept, Direction: upcloud.FirewallRuleDirectionIn, Protocol: upcloud.FirewallRuleProtocolICMP, Family: upcloud.IPAddressFamilyIPv4, DestinationPortStart: "22"},
+		},
+	}
+	err := r.Validate()
+	assert.ErrorContains(t, err, "firewall rule 2: ports cannot be used with protocol icmp")
+	assert.NotContains(t, err.Error(), "firewall rule 1")
+
+	r.FirewallRules = r.FirewallRules[:1]
+	assert.NoError(t, r.Validate())
+}
+
+// TestBootstrapFirewallRequest tests the default-deny baseline rule set
+func TestBootstrapFirewallRequest(t *testing.T) {
+	r := BootstrapFirewallRequest{
+		ServerUUID: "uuid",
+		AdminCIDRs: []string{"203.0.113.7/24", "2001:db8::/64"},
+		AdminPorts: []int{22, 443},
+		Rules: []upcloud.FirewallRule{{
+			Action:    upcloud.FirewallRuleActionAccept,
+			Direction: upcloud.FirewallRuleDirectionIn,
+			Family:    upcloud.IPAddressFamilyIPv4,
+			Protocol:  upcloud.FirewallRuleProtocolTCP,
+			Comment:   "HTTP",
+		}},
+	}
+	rules, err := r.FirewallRules()
+	assert.NoError(t, err)
+	assert.NoError(t, (&CreateFirewallRulesRequest{FirewallRules: rules}).Validate())
+	assert.Len(t, rules, 4+4+4+1+2)
+
+	for i, rule := range rules {
+		assert.Equal(t, i+1, rule.Position)
+	}
+
+	admin := rules[4]
+	assert.Equal(t, "203.0.113.0", admin.SourceAddressStart)
+	assert.Equal(t, "203.0.113.255", admin.SourceAddressEnd)
+	assert.Equal(t, "22", admin.DestinationPortStart)
+	assert.Equal(t, "443", rules[5].DestinationPortStart)
+	assert.Equal(t, "2001:db8::", rules[6].SourceAddressStart)
+	assert.Equal(t, "2001:db8::ffff:ffff:ffff:ffff", rules[6].SourceAddressEnd)
+	assert.Equal(t, upcloud.IPAddressFamilyIPv6, rules[6].Family)
+
+	assert.Equal(t, "HTTP", rules[12].Comment)
+	for _, rule := range rules[13:] {
+		assert.Equal(t, upcloud.FirewallRuleActionDrop, rule.Action)
+		assert.Empty(t, rule.Protocol)
+	}
+
+	r.AdminCIDRs = []string{"203.0.113.7"}
+	_, err = r.FirewallRules()
+	assert.Error(t, err)
+}
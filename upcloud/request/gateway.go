@@ -215,3 +215,26 @@ type DeleteGatewayConnectionTunnelRequest struct {
 func (r *DeleteGatewayConnectionTunnelRequest) RequestURL() string {
 	return fmt.Sprintf("%s/%s/connections/%s/tunnels/%s", gatewayBaseURL, r.ServiceUUID, r.ConnectionUUID, r.UUID)
 }
+
+// WaitForGatewayOperationalStateRequest represents a request to wait for a gateway to enter a desired operational state
+type WaitForGatewayOperationalStateRequest struct {
+	UUID         string                          `json:"-"`
+	DesiredState upcloud.GatewayOperationalState `json:"-"`
+}
+
+func (r *WaitForGatewayOperationalStateRequest) RequestURL() string {
+	return fmt.Sprintf("%s/%s", gatewayBaseURL, r.UUID)
+}
+
+// WaitForGatewayConnectionTunnelOperationalStateRequest represents a request to wait for a gateway connection tunnel
+// to enter a desired operational state
+type WaitForGatewayConnectionTunnelOperationalStateRequest struct {
+	ServiceUUID    string                                `json:"-"`
+	ConnectionUUID string                                `json:"-"`
+	UUID           string                                `json:"-"`
+	DesiredState   upcloud.GatewayTunnelOperationalState `json:"-"`
+}
+
+func (r *WaitForGatewayConnectionTunnelOperationalStateRequest) RequestURL() string {
+	return fmt.Sprintf("%s/%s/connections/%s/tunnels/%s", gatewayBaseURL, r.ServiceUUID, r.ConnectionUUID, r.UUID)
+}
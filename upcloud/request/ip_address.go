@@ -52,6 +52,14 @@ type ModifyIPAddressRequest struct {
 	MAC       string `json:"mac,omitempty"`
 }
 
+// SetPTRRecordsRequest represents a request to set the PTR records of multiple IP addresses
+type SetPTRRecordsRequest struct {
+	// Records maps IP addresses to their PTR records
+	Records map[string]string
+	// Concurrency is the number of records set at the same time. Defaults to 4.
+	Concurrency int
+}
+
 // RequestURL implements the Request interface
 func (r *ModifyIPAddressRequest) RequestURL() string {
 	return fmt.Sprintf("/ip_address/%s", r.IPAddress)
@@ -88,3 +96,8 @@ type ReleaseIPAddressRequest struct {
 func (r *ReleaseIPAddressRequest) RequestURL() string {
 	return fmt.Sprintf("/ip_address/%s", r.IPAddress)
 }
+
+// ReleaseAllIPAddressesRequest represents a request to release the additional public IP addresses of a server
+type ReleaseAllIPAddressesRequest struct {
+	ServerUUID string
+}
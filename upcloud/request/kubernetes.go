@@ -115,6 +115,18 @@ func (r *WaitForKubernetesNodeGroupStateRequest) RequestURL() string {
 	return fmt.Sprintf("%s/%s/node-groups/%s", kubernetesClusterBasePath, r.ClusterUUID, r.Name)
 }
 
+// WaitForKubernetesNodeGroupNodeCountRequest represents a request to wait for a Kubernetes node group to have the
+// desired number of running nodes. If Count is zero, the count of the node group is used.
+type WaitForKubernetesNodeGroupNodeCountRequest struct {
+	ClusterUUID string `json:"-"`
+	Name        string `json:"-"`
+	Count       int    `json:"-"`
+}
+
+func (r *WaitForKubernetesNodeGroupNodeCountRequest) RequestURL() string {
+	return fmt.Sprintf("%s/%s/node-groups/%s", kubernetesClusterBasePath, r.ClusterUUID, r.Name)
+}
+
 // GetKubernetesKubeconfigRequest represents a request to get kubeconfig for a Kubernetes cluster
 type GetKubernetesKubeconfigRequest struct {
 	UUID string `json:"-"`
@@ -21,3 +21,18 @@ type FilterLabelKey struct {
 func (k FilterLabelKey) ToQueryParam() string {
 	return fmt.Sprintf("label=%s", k.Key)
 }
+
+// LabelSelectorFilters returns query filters for the requirements of the selector that the API supports, i.e. equality
+// and label existence. Other requirements need to be applied to the response with upcloud.LabelSelector.Matches.
+func LabelSelectorFilters(selector upcloud.LabelSelector) []QueryFilter {
+	filters := make([]QueryFilter, 0, len(selector))
+	for _, r := range selector {
+		switch r.Operator {
+		case upcloud.LabelOperatorEquals:
+			filters = append(filters, FilterLabel{Label: upcloud.Label{Key: r.Key, Value: r.Value}})
+		case upcloud.LabelOperatorExists:
+			filters = append(filters, FilterLabelKey{Key: r.Key})
+		}
+	}
+	return filters
+}
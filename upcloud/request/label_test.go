@@ -0,0 +1,17 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelSelectorFilters(t *testing.T) {
+	s, err := upcloud.ParseLabelSelector("color=green,team!=x,size,!legacy")
+	require.NoError(t, err)
+
+	r := GetGatewaysRequest{Filters: LabelSelectorFilters(s)}
+	assert.Equal(t, "/gateway?label=color%3Dgreen&label=size", r.RequestURL())
+}
@@ -501,6 +501,18 @@ func (r *ModifyLoadBalancerFrontendRuleRequest) RequestURL() string {
 	return fmt.Sprintf("/load-balancer/%s/frontends/%s/rules/%s", r.ServiceUUID, r.FrontendName, r.Name)
 }
 
+// ReorderLoadBalancerFrontendRulesRequest represents a request to reorder frontend rules
+type ReorderLoadBalancerFrontendRulesRequest struct {
+	ServiceUUID  string `json:"-"`
+	FrontendName string `json:"-"`
+	// Names of all frontend rules in the order they should be evaluated
+	Order []string `json:"-"`
+}
+
+func (r *ReorderLoadBalancerFrontendRulesRequest) RequestURL() string {
+	return fmt.Sprintf("/load-balancer/%s/frontends/%s/rules", r.ServiceUUID, r.FrontendName)
+}
+
 // DeleteLoadBalancerFrontendRuleRequest represents a request to delete frontend rule
 type DeleteLoadBalancerFrontendRuleRequest struct {
 	ServiceUUID  string `json:"-"`
@@ -711,6 +723,18 @@ func (r *DeleteLoadBalancerCertificateBundleRequest) RequestURL() string {
 	return fmt.Sprintf("/load-balancer/certificate-bundles/%s", r.UUID)
 }
 
+// WaitForLoadBalancerCertificateBundleOperationalStateRequest represents a request to wait for a certificate bundle
+// to enter a desired operational state
+type WaitForLoadBalancerCertificateBundleOperationalStateRequest struct {
+	DesiredState upcloud.LoadBalancerCertificateBundleOperationalState `json:"-"`
+	UUID         string                                                `json:"-"`
+}
+
+// RequestURL implements the Request interface
+func (r *WaitForLoadBalancerCertificateBundleOperationalStateRequest) RequestURL() string {
+	return fmt.Sprintf("/load-balancer/certificate-bundles/%s", r.UUID)
+}
+
 type ModifyLoadBalancerNetwork struct {
 	Name string `json:"name,omitempty"`
 }
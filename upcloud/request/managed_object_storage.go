@@ -117,6 +117,16 @@ func (r *GetManagedObjectStorageMetricsRequest) RequestURL() string {
 	return fmt.Sprintf("%s/%s/metrics", managedObjectStorageBasePath, r.ServiceUUID)
 }
 
+// GetManagedObjectStorageUsageRequest represents a request for retrieving service and bucket metrics
+type GetManagedObjectStorageUsageRequest struct {
+	ServiceUUID string `json:"-"`
+}
+
+// RequestURL implements the Request interface
+func (r *GetManagedObjectStorageUsageRequest) RequestURL() string {
+	return fmt.Sprintf("%s/%s/metrics", managedObjectStorageBasePath, r.ServiceUUID)
+}
+
 // GetManagedObjectStorageBucketMetricsRequest represents a request for retrieving buckets' metrics
 type GetManagedObjectStorageBucketMetricsRequest struct {
 	Page        *Page  `json:"-"`
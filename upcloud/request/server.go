@@ -2,7 +2,9 @@ package request
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,17 +17,40 @@ const (
 	PasswordDeliveryEmail = "email"
 	PasswordDeliverySMS   = "sms"
 
+	LoginUserCreatePasswordYes = "yes"
+	LoginUserCreatePasswordNo  = "no"
+
 	ServerStopTypeSoft = "soft"
 	ServerStopTypeHard = "hard"
 
+	// SimpleBackupDisabled disables the simple backup of a server
+	SimpleBackupDisabled = "no"
+
 	RestartTimeoutActionDestroy = "destroy"
 	RestartTimeoutActionIgnore  = "ignore"
 
+	StopTimeoutActionDestroy = "destroy"
+	StopTimeoutActionIgnore  = "ignore"
+
 	CreateServerStorageDeviceActionCreate = "create"
 	CreateServerStorageDeviceActionClone  = "clone"
 	CreateServerStorageDeviceActionAttach = "attach"
 )
 
+// GetServerConfigurationsRequest represents a request to list available server configurations.
+// The listing is filtered client-side as the API does not support filtering server sizes.
+type GetServerConfigurationsRequest struct {
+	// If specified, only configurations with this core number will be returned
+	CoreNumber int
+	// If specified, only configurations with this memory amount (in megabytes) will be returned
+	MemoryAmount int
+}
+
+// RequestURL implements the Request interface
+func (r *GetServerConfigurationsRequest) RequestURL() string {
+	return "/server_size"
+}
+
 // Deprecated: ServerFilter filter is deprecated. Use QueryFilter instead.
 type ServerFilter = QueryFilter
 
@@ -47,6 +72,13 @@ func (r *GetServersWithFiltersRequest) RequestURL() string {
 	return fmt.Sprintf("%s?%s", basePath, encodeQueryFilters(r.Filters))
 }
 
+// GetServersWithDetailsRequest represents a request for listing servers together with their details
+type GetServersWithDetailsRequest struct {
+	Filters []QueryFilter
+	// Concurrency limits the number of concurrent server details requests. Defaults to 8.
+	Concurrency int
+}
+
 // GetServerDetailsRequest represents a request for retrieving details about a server
 type GetServerDetailsRequest struct {
 	UUID string
@@ -162,6 +194,27 @@ type CreateServerRequest struct {
 	Zone                 string                         `json:"zone"`
 }
 
+// DualStackNetworking returns the networking of a dual-stack server: a public interface with an IPv4 address, a
+// public interface with an IPv6 address and a utility network interface with an IPv4 address.
+func DualStackNetworking() *CreateServerNetworking {
+	return &CreateServerNetworking{
+		Interfaces: CreateServerInterfaceSlice{
+			{
+				Type:        upcloud.IPAddressAccessPublic,
+				IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+			},
+			{
+				Type:        upcloud.IPAddressAccessPublic,
+				IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv6}},
+			},
+			{
+				Type:        upcloud.IPAddressAccessUtility,
+				IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+			},
+		},
+	}
+}
+
 // MarshalJSON is a custom marshaller that deals with
 // deeply embedded values.
 func (r CreateServerRequest) MarshalJSON() ([]byte, error) {
@@ -211,8 +264,27 @@ func (s SSHKeySlice) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v)
 }
 
+// ValidateBackups checks that simple backup of the server is not combined with backup rules of the storage devices,
+// which the API does not allow
+func (r *CreateServerRequest) ValidateBackups() error {
+	if !simpleBackupEnabled(r.SimpleBackup) {
+		return nil
+	}
+	var devices []string
+	for i, d := range r.StorageDevices {
+		if d.BackupRule != nil && d.BackupRule.Interval != "" {
+			devices = append(devices, strconv.Itoa(i+1))
+		}
+	}
+	if len(devices) > 0 {
+		return fmt.Errorf("simple backup can not be combined with backup rules of storage devices %s", strings.Join(devices, ", "))
+	}
+	return nil
+}
+
 // LoginUser represents the login_user block when creating a new server
 type LoginUser struct {
+	// CreatePassword is either LoginUserCreatePasswordYes or LoginUserCreatePasswordNo
 	CreatePassword string      `json:"create_password,omitempty"`
 	Username       string      `json:"username,omitempty"`
 	SSHKeys        SSHKeySlice `json:"ssh_keys,omitempty"`
@@ -261,6 +333,9 @@ type StopServerRequest struct {
 
 	StopType string        `json:"stop_type,omitempty"`
 	Timeout  time.Duration `json:"timeout,omitempty,string"`
+	// TimeoutAction defines what happens when a soft stop does not finish within the timeout, see
+	// StopTimeoutActionDestroy and StopTimeoutActionIgnore.
+	TimeoutAction string `json:"timeout_action,omitempty"`
 }
 
 // RequestURL implements the Request interface
@@ -268,6 +343,21 @@ func (r *StopServerRequest) RequestURL() string {
 	return fmt.Sprintf("/server/%s/stop", r.UUID)
 }
 
+// Validate checks that the timeout action is valid and only used with soft stops
+func (r *StopServerRequest) Validate() error {
+	switch r.TimeoutAction {
+	case "":
+		return nil
+	case StopTimeoutActionDestroy, StopTimeoutActionIgnore:
+	default:
+		return fmt.Errorf("invalid timeout action %q", r.TimeoutAction)
+	}
+	if r.StopType == ServerStopTypeHard {
+		return errors.New("timeout action can only be used with soft stop")
+	}
+	return nil
+}
+
 // MarshalJSON is a custom marshaller that deals with
 // deeply embedded values.
 func (r StopServerRequest) MarshalJSON() ([]byte, error) {
@@ -353,11 +443,19 @@ func (r *ModifyServerRequest) RequestURL() string {
 // DeleteServerRequest represents a request to delete a server
 type DeleteServerRequest struct {
 	UUID string
+	// Storages deletes also the storages attached to the server
+	Storages bool
+	// Backups defines what happens to the backups of the deleted storages. It is only used when Storages is set;
+	// the API default is DeleteStorageBackupsModeKeep.
+	Backups DeleteStorageBackupsMode
 }
 
 // RequestURL implements the Request interface
 func (r *DeleteServerRequest) RequestURL() string {
-	return fmt.Sprintf("/server/%s", r.UUID)
+	if !r.Storages {
+		return fmt.Sprintf("/server/%s", r.UUID)
+	}
+	return (&DeleteServerAndStoragesRequest{UUID: r.UUID, Backups: r.Backups}).RequestURL()
 }
 
 // DeleteServerAndStoragesRequest represents a request to delete a server and all attached storages
@@ -396,3 +494,60 @@ type UntagServerRequest struct {
 func (r *UntagServerRequest) RequestURL() string {
 	return fmt.Sprintf("/server/%s/untag/%s", r.UUID, strings.Join(r.Tags, ","))
 }
+
+// MigrateToSimpleBackupRequest represents a request to replace the backup rules of the server's storages with simple
+// backup of the server
+type MigrateToSimpleBackupRequest struct {
+	ServerUUID string
+	// SimpleBackup is the simple backup schedule, e.g. "0430,dailies"
+	SimpleBackup string
+}
+
+// MigrateToBackupRulesRequest represents a request to replace the simple backup of a server with backup rules of its
+// storages
+type MigrateToBackupRulesRequest struct {
+	ServerUUID string
+	BackupRule upcloud.BackupRule
+}
+
+// simpleBackupEnabled returns true if the simple backup value enables backups
+func simpleBackupEnabled(v string) bool {
+	return v != "" && v != SimpleBackupDisabled
+}
+
+// RescueBootRequest represents a request to boot a server from an ISO, e.g. a rescue image
+type RescueBootRequest struct {
+	ServerUUID string
+	// StorageUUID is the ISO to boot from, e.g. a public CD-ROM storage or an uploaded image
+	StorageUUID string
+	// StopType of the server, defaults to a soft stop
+	StopType string
+	// Timeout of a soft stop
+	Timeout time.Duration
+}
+
+// ExitRescueRequest represents a request to restore the configuration of a server booted with RescueBoot
+type ExitRescueRequest struct {
+	Session upcloud.RescueSession
+	// StopType of the server, defaults to a soft stop
+	StopType string
+	// Timeout of a soft stop
+	Timeout time.Duration
+}
+
+// MigrateServerToZoneRequest represents a request to move a server to another zone by cloning its disks to the zone
+// and recreating the server there
+type MigrateServerToZoneRequest struct {
+	ServerUUID string
+	Zone       string
+	// Plan overrides the plan of the server, e.g. when the plan is not available in the target zone
+	Plan string
+	// PrivateNetworks maps the private networks of the server to networks in the target zone. Private interfaces in
+	// networks that are not mapped are left out, as networks are zone specific.
+	PrivateNetworks map[string]string
+	// FloatingIPAddress is a floating IP address in the target zone that is attached to the new server
+	FloatingIPAddress string
+	// DeleteSource deletes the source server and its disks once the new server is running. Backups of the disks are
+	// kept.
+	DeleteSource bool
+}
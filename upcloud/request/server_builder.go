@@ -0,0 +1,288 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+// ServerBuilder builds a CreateServerRequest step by step. Use Build to validate and return the request.
+type ServerBuilder struct {
+	r CreateServerRequest
+}
+
+// NewServerBuilder returns a new server builder
+func NewServerBuilder() *ServerBuilder {
+	return &ServerBuilder{}
+}
+
+// Zone sets the zone of the server
+func (b *ServerBuilder) Zone(zone string) *ServerBuilder {
+	b.r.Zone = zone
+	return b
+}
+
+// Title sets the title of the server
+func (b *ServerBuilder) Title(title string) *ServerBuilder {
+	b.r.Title = title
+	return b
+}
+
+// Hostname sets the hostname of the server
+func (b *ServerBuilder) Hostname(hostname string) *ServerBuilder {
+	b.r.Hostname = hostname
+	return b
+}
+
+// Plan sets the plan of the server
+func (b *ServerBuilder) Plan(plan string) *ServerBuilder {
+	b.r.Plan = plan
+	return b
+}
+
+// CustomPlan sets the server to use the custom plan with the specified core number and memory amount in megabytes
+func (b *ServerBuilder) CustomPlan(coreNumber, memoryAmount int) *ServerBuilder {
+	b.r.Plan = "custom"
+	b.r.CoreNumber = coreNumber
+	b.r.MemoryAmount = memoryAmount
+	return b
+}
+
+// CloneStorage adds a storage device cloned from the template or storage with the size in gigabytes
+func (b *ServerBuilder) CloneStorage(template string, size int) *ServerBuilder {
+	return b.StorageDevice(CreateServerStorageDevice{
+		Action:  CreateServerStorageDeviceActionClone,
+		Storage: template,
+		Size:    size,
+	})
+}
+
+// CreateStorage adds a new empty storage device with the size in gigabytes and tier
+func (b *ServerBuilder) CreateStorage(size int, tier string) *ServerBuilder {
+	return b.StorageDevice(CreateServerStorageDevice{
+		Action: CreateServerStorageDeviceActionCreate,
+		Size:   size,
+		Tier:   tier,
+	})
+}
+
+// AttachStorage attaches an existing storage to the server
+func (b *ServerBuilder) AttachStorage(uuid string) *ServerBuilder {
+	return b.StorageDevice(CreateServerStorageDevice{
+		Action:  CreateServerStorageDeviceActionAttach,
+		Storage: uuid,
+	})
+}
+
+// StorageDevice adds a storage device to the server. Storage devices without a title are titled after the server.
+func (b *ServerBuilder) StorageDevice(device CreateServerStorageDevice) *ServerBuilder {
+	b.r.StorageDevices = append(b.r.StorageDevices, device)
+	return b
+}
+
+// PublicIPv4 adds a public network interface with an IPv4 address
+func (b *ServerBuilder) PublicIPv4() *ServerBuilder {
+	return b.Interface(CreateServerInterface{
+		Type:        upcloud.IPAddressAccessPublic,
+		IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+	})
+}
+
+// PublicIPv6 adds a public network interface with an IPv6 address
+func (b *ServerBuilder) PublicIPv6() *ServerBuilder {
+	return b.Interface(CreateServerInterface{
+		Type:        upcloud.IPAddressAccessPublic,
+		IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv6}},
+	})
+}
+
+// UtilityNetwork adds a utility network interface with an IPv4 address
+func (b *ServerBuilder) UtilityNetwork() *ServerBuilder {
+	return b.Interface(CreateServerInterface{
+		Type:        upcloud.IPAddressAccessUtility,
+		IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+	})
+}
+
+// PrivateNetwork adds a network interface attached to the private network with an IPv4 address from the network DHCP
+func (b *ServerBuilder) PrivateNetwork(networkUUID string) *ServerBuilder {
+	return b.Interface(CreateServerInterface{
+		Type:        upcloud.IPAddressAccessPrivate,
+		Network:     networkUUID,
+		IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+	})
+}
+
+// DualStack configures the server with the dual-stack profile: public IPv4 and IPv6 interfaces and a utility network
+// interface, see DualStackNetworking, with the metadata service enabled. The virtio NIC model and the VGA video model
+// are used unless set otherwise.
+func (b *ServerBuilder) DualStack() *ServerBuilder {
+	for _, iface := range DualStackNetworking().Interfaces {
+		b.Interface(iface)
+	}
+	b.r.Metadata = upcloud.True
+	if b.r.NICModel == "" {
+		b.r.NICModel = upcloud.NICModelVirtio
+	}
+	if b.r.VideoModel == "" {
+		b.r.VideoModel = upcloud.VideoModelVGA
+	}
+	return b
+}
+
+// NICModel sets the model of the network interfaces of the server, e.g. upcloud.NICModelVirtio
+func (b *ServerBuilder) NICModel(model string) *ServerBuilder {
+	b.r.NICModel = model
+	return b
+}
+
+// VideoModel sets the video model of the server, e.g. upcloud.VideoModelVGA
+func (b *ServerBuilder) VideoModel(model string) *ServerBuilder {
+	b.r.VideoModel = model
+	return b
+}
+
+// Interface adds a network interface to the server
+func (b *ServerBuilder) Interface(iface CreateServerInterface) *ServerBuilder {
+	if b.r.Networking == nil {
+		b.r.Networking = &CreateServerNetworking{}
+	}
+	b.r.Networking.Interfaces = append(b.r.Networking.Interfaces, iface)
+	return b
+}
+
+// Label adds a label to the server
+func (b *ServerBuilder) Label(key, value string) *ServerBuilder {
+	if b.r.Labels == nil {
+		b.r.Labels = &upcloud.LabelSlice{}
+	}
+	*b.r.Labels = append(*b.r.Labels, upcloud.Label{Key: key, Value: value})
+	return b
+}
+
+// LoginUser sets the login user and the SSH keys of the server
+func (b *ServerBuilder) LoginUser(username string, sshKeys ...string) *ServerBuilder {
+	b.r.LoginUser = &LoginUser{
+		Username: username,
+		SSHKeys:  sshKeys,
+	}
+	return b
+}
+
+// UserData sets the user data script or URL of the server. Requires metadata service, which is enabled as well.
+func (b *ServerBuilder) UserData(userData string) *ServerBuilder {
+	b.r.UserData = userData
+	b.r.Metadata = upcloud.True
+	return b
+}
+
+// Metadata enables or disables the metadata service of the server
+func (b *ServerBuilder) Metadata(enabled bool) *ServerBuilder {
+	b.r.Metadata = upcloud.FromBool(enabled)
+	return b
+}
+
+// Firewall enables or disables the firewall of the server
+func (b *ServerBuilder) Firewall(enabled bool) *ServerBuilder {
+	b.r.Firewall = "off"
+	if enabled {
+		b.r.Firewall = "on"
+	}
+	return b
+}
+
+// SimpleBackup sets the simple backup schedule of the server, e.g. "0430,dailies"
+func (b *ServerBuilder) SimpleBackup(backup string) *ServerBuilder {
+	b.r.SimpleBackup = backup
+	return b
+}
+
+// ServerGroup adds the server to the server group
+func (b *ServerBuilder) ServerGroup(uuid string) *ServerBuilder {
+	b.r.ServerGroup = uuid
+	return b
+}
+
+// TimeZone sets the time zone of the server
+func (b *ServerBuilder) TimeZone(timezone string) *ServerBuilder {
+	b.r.TimeZone = timezone
+	return b
+}
+
+// PasswordDelivery sets the delivery method of the generated password
+func (b *ServerBuilder) PasswordDelivery(delivery string) *ServerBuilder {
+	b.r.PasswordDelivery = delivery
+	return b
+}
+
+// Build validates and returns the request. All validation errors are returned joined.
+func (b *ServerBuilder) Build() (*CreateServerRequest, error) {
+	// Copy the slices so that further changes to the builder do not modify the returned request
+	r := b.r
+	r.StorageDevices = append(CreateServerStorageDeviceSlice(nil), b.r.StorageDevices...)
+	if b.r.Networking != nil {
+		r.Networking = &CreateServerNetworking{
+			Interfaces: append(CreateServerInterfaceSlice(nil), b.r.Networking.Interfaces...),
+		}
+	}
+	if b.r.Labels != nil {
+		labels := append(upcloud.LabelSlice(nil), *b.r.Labels...)
+		r.Labels = &labels
+	}
+
+	if r.Title == "" {
+		r.Title = r.Hostname
+	}
+	for i := range r.StorageDevices {
+		if r.StorageDevices[i].Title == "" && r.StorageDevices[i].Action != CreateServerStorageDeviceActionAttach {
+			r.StorageDevices[i].Title = fmt.Sprintf("%s-disk%d", r.Title, i+1)
+		}
+	}
+
+	var errs []error
+	if r.Zone == "" {
+		errs = append(errs, errors.New("zone is required"))
+	}
+	if r.Hostname == "" {
+		errs = append(errs, errors.New("hostname is required"))
+	}
+	if r.Plan == "custom" && (r.CoreNumber <= 0 || r.MemoryAmount <= 0) {
+		errs = append(errs, errors.New("custom plan requires core number and memory amount"))
+	}
+	if len(r.StorageDevices) == 0 {
+		errs = append(errs, errors.New("at least one storage device is required"))
+	}
+	for i, d := range r.StorageDevices {
+		switch d.Action {
+		case CreateServerStorageDeviceActionClone, CreateServerStorageDeviceActionAttach:
+			if d.Storage == "" {
+				errs = append(errs, fmt.Errorf("storage device %d: storage UUID is required", i+1))
+			}
+		case CreateServerStorageDeviceActionCreate:
+			if d.Size <= 0 {
+				errs = append(errs, fmt.Errorf("storage device %d: size is required", i+1))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("storage device %d: invalid action %q", i+1, d.Action))
+		}
+	}
+	if r.Networking != nil {
+		for i, iface := range r.Networking.Interfaces {
+			if iface.Type == upcloud.IPAddressAccessPrivate && iface.Network == "" {
+				errs = append(errs, fmt.Errorf("network interface %d: private network UUID is required", i+1))
+			}
+		}
+	}
+	if r.UserData != "" && r.Metadata != upcloud.True {
+		errs = append(errs, errors.New("user data requires metadata service to be enabled"))
+	}
+	if err := r.ValidateBackups(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &r, nil
+}
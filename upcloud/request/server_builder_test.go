@@ -0,0 +1,112 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+func TestServerBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := NewServerBuilder().
+		Zone("fi-hel1").
+		Plan("1xCPU-2GB").
+		Hostname("web.example.com").
+		CloneStorage("01000000-0000-4000-8000-000030240200", 25).
+		PublicIPv4().
+		PrivateNetwork("03000000-0000-4000-8100-000000000000").
+		Label("env", "prod").
+		LoginUser("admin", "ssh-ed25519 AAAA").
+		UserData("#!/bin/sh")
+
+	r, err := b.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, &CreateServerRequest{
+		Zone:     "fi-hel1",
+		Plan:     "1xCPU-2GB",
+		Title:    "web.example.com",
+		Hostname: "web.example.com",
+		StorageDevices: CreateServerStorageDeviceSlice{{
+			Action:  CreateServerStorageDeviceActionClone,
+			Storage: "01000000-0000-4000-8000-000030240200",
+			Title:   "web.example.com-disk1",
+			Size:    25,
+		}},
+		Networking: &CreateServerNetworking{
+			Interfaces: CreateServerInterfaceSlice{
+				{
+					Type:        upcloud.IPAddressAccessPublic,
+					IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+				},
+				{
+					Type:        upcloud.IPAddressAccessPrivate,
+					Network:     "03000000-0000-4000-8100-000000000000",
+					IPAddresses: CreateServerIPAddressSlice{{Family: upcloud.IPAddressFamilyIPv4}},
+				},
+			},
+		},
+		Labels:    &upcloud.LabelSlice{{Key: "env", Value: "prod"}},
+		LoginUser: &LoginUser{Username: "admin", SSHKeys: SSHKeySlice{"ssh-ed25519 AAAA"}},
+		UserData:  "#!/bin/sh",
+		Metadata:  upcloud.True,
+	}, r)
+
+	// Changes to the builder do not modify built requests
+	b.PublicIPv6().Label("team", "x").CreateStorage(10, upcloud.StorageTierMaxIOPS)
+	assert.Len(t, r.Networking.Interfaces, 2)
+	assert.Len(t, *r.Labels, 1)
+	assert.Len(t, r.StorageDevices, 1)
+}
+
+func TestServerBuilder_validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewServerBuilder().
+		CustomPlan(0, 1024).
+		CreateStorage(0, upcloud.StorageTierMaxIOPS).
+		AttachStorage("").
+		PrivateNetwork("").
+		UserData("#!/bin/sh").
+		Metadata(false).
+		Build()
+
+	assert.EqualError(t, err, `zone is required
+hostname is required
+custom plan requires core number and memory amount
+storage device 1: size is required
+storage device 2: storage UUID is required
+network interface 1: private network UUID is required
+user data requires metadata service to be enabled`)
+
+	_, err = NewServerBuilder().Zone("fi-hel1").Hostname("a").Build()
+	assert.EqualError(t, err, "at least one storage device is required")
+}
+
+func TestServerBuilder_dualStack(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewServerBuilder().
+		Zone("fi-hel1").
+		Hostname("web.example.com").
+		CreateStorage(10, upcloud.StorageTierMaxIOPS).
+		NICModel(upcloud.NICModelE1000).
+		DualStack().
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, DualStackNetworking(), r.Networking)
+	assert.Equal(t, upcloud.True, r.Metadata)
+	assert.Equal(t, upcloud.NICModelE1000, r.NICModel)
+	assert.Equal(t, upcloud.VideoModelVGA, r.VideoModel)
+
+	families := make([]string, 0, len(r.Networking.Interfaces))
+	for _, iface := range r.Networking.Interfaces {
+		families = append(families, iface.Type+"/"+iface.IPAddresses[0].Family)
+	}
+	assert.Equal(t, []string{"public/IPv4", "public/IPv6", "utility/IPv4"}, families)
+}
@@ -9,6 +9,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// TestGetServerConfigurationsRequest tests that GetServerConfigurationsRequest objects behave correctly
+func TestGetServerConfigurationsRequest(t *testing.T) {
+	request := GetServerConfigurationsRequest{CoreNumber: 2}
+
+	assert.Equal(t, "/server_size", request.RequestURL())
+}
+
 // TestGetServersWithFiltersRequest tests that GetServersWithFiltersRequest objects behave correctly
 func TestGetServersWithFiltersRequest(t *testing.T) {
 	request := GetServersWithFiltersRequest{
@@ -260,6 +267,18 @@ func TestStopServerRequest(t *testing.T) {
 	assert.Nil(t, err)
 	assert.JSONEq(t, expectedJSON, string(actualJSON))
 	assert.Equal(t, "/server/foo/stop", request.RequestURL())
+	assert.NoError(t, request.Validate())
+
+	request.TimeoutAction = StopTimeoutActionDestroy
+	actualJSON, err = json.Marshal(&request)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"stop_server": {"stop_type": "soft", "timeout": "300", "timeout_action": "destroy"}}`, string(actualJSON))
+	assert.NoError(t, request.Validate())
+
+	request.StopType = ServerStopTypeHard
+	assert.EqualError(t, request.Validate(), "timeout action can only be used with soft stop")
+	request.TimeoutAction = "reboot"
+	assert.EqualError(t, request.Validate(), `invalid timeout action "reboot"`)
 }
 
 // TestRestartServerRequest tests that RestartServerRequest objects behave correctly
@@ -373,6 +392,15 @@ func TestDeleteServerRequest(t *testing.T) {
 	}
 
 	assert.Equal(t, "/server/foo", request.RequestURL())
+
+	request.Backups = DeleteStorageBackupsModeDelete
+	assert.Equal(t, "/server/foo", request.RequestURL())
+
+	request.Storages = true
+	assert.Equal(t, "/server/foo/?storages=1&backups=delete", request.RequestURL())
+
+	request.Backups = ""
+	assert.Equal(t, "/server/foo/?storages=1", request.RequestURL())
 }
 
 // TestDeleteServerAndStoragesRequest tests that DeleteServerAndStoragesRequest objects behave correctly
@@ -453,3 +481,23 @@ func TestUntagServerRequest(t *testing.T) {
 
 	assert.Equal(t, "/server/foo/untag/tag1", request.RequestURL())
 }
+
+func TestCreateServerRequestValidateBackups(t *testing.T) {
+	t.Parallel()
+
+	rule := &upcloud.BackupRule{Interval: "daily", Time: "0430", Retention: 7}
+	r := CreateServerRequest{
+		StorageDevices: CreateServerStorageDeviceSlice{
+			{Action: CreateServerStorageDeviceActionClone, BackupRule: rule},
+			{Action: CreateServerStorageDeviceActionCreate},
+			{Action: CreateServerStorageDeviceActionCreate, BackupRule: rule},
+		},
+	}
+	assert.NoError(t, r.ValidateBackups())
+
+	r.SimpleBackup = SimpleBackupDisabled
+	assert.NoError(t, r.ValidateBackups())
+
+	r.SimpleBackup = "0430,dailies"
+	assert.EqualError(t, r.ValidateBackups(), "simple backup can not be combined with backup rules of storage devices 1, 3")
+}
@@ -3,6 +3,7 @@ package request
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 )
@@ -27,6 +28,11 @@ type GetStoragesRequest struct {
 	Type string
 	// If specified, only storages marked as favorite will be retrieved
 	Favorite bool
+	// If specified, only storages in this zone will be retrieved. The API does not support filtering by zone, so
+	// storages are filtered after they have been retrieved.
+	Zone string
+	// If specified, only the storages on this page will be retrieved
+	Page *Page
 
 	Filters []QueryFilter
 }
@@ -46,10 +52,14 @@ func (r *GetStoragesRequest) RequestURL() string {
 		url = url + "/favorite"
 	}
 
-	if len(r.Filters) == 0 {
+	filters := r.Filters
+	if r.Page != nil {
+		filters = append(filters[:len(filters):len(filters)], r.Page)
+	}
+	if len(filters) == 0 {
 		return url
 	}
-	return fmt.Sprintf("%s?%s", url, encodeQueryFilters(r.Filters))
+	return fmt.Sprintf("%s?%s", url, encodeQueryFilters(filters))
 }
 
 // GetStorageDetailsRequest represents a request for retrieving details about a piece of storage
@@ -168,6 +178,37 @@ func (r DetachStorageRequest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&v)
 }
 
+// DetachAllStoragesRequest represents a request to detach all storages except the boot disk from a server
+type DetachAllStoragesRequest struct {
+	ServerUUID string
+}
+
+// ChangeStorageTierRequest represents a request to move a storage to another tier by cloning it
+type ChangeStorageTierRequest struct {
+	UUID string
+	Tier string
+	// Title of the new storage. Defaults to the title of the source storage.
+	Title string
+	// DeleteSource deletes the source storage once the new storage is attached in its place
+	DeleteSource bool
+}
+
+// BuildTemplateRequest represents a request to build a template from a temporary server
+type BuildTemplateRequest struct {
+	// Server is the request for creating the temporary build server, typically cloning a base template and passing a
+	// provisioning script in UserData.
+	Server CreateServerRequest
+	// ReadyTag is the tag the provisioning script adds to the server when it has completed. If set, the server is
+	// not stopped before it has the tag.
+	ReadyTag string
+	// Title of the template
+	Title string
+	// Version is set as the template_version label of the template, if not empty
+	Version string
+	// Labels are additional labels of the template
+	Labels []upcloud.Label
+}
+
 // DeleteStorageRequest represents a request to delete a storage device
 type DeleteStorageRequest struct {
 	UUID    string
@@ -263,6 +304,13 @@ func (r LoadCDROMRequest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&v)
 }
 
+// LoadCDROMByTitleRequest represents a request to load a public CD-ROM storage, looked up by its title, in the CD-ROM
+// device of a server
+type LoadCDROMByTitleRequest struct {
+	ServerUUID string
+	Title      string
+}
+
 // EjectCDROMRequest represents a request to load a storage as a CD-ROM in the CD-ROM device of a server
 type EjectCDROMRequest struct {
 	ServerUUID string
@@ -307,6 +355,46 @@ func (r *RestoreBackupRequest) RequestURL() string {
 	return fmt.Sprintf("/storage/%s/restore", r.UUID)
 }
 
+// CreateBackupGroupRequest represents a request to back up all disks of a server, or of all servers with a tag, as a
+// group. Either ServerUUID or Tag must be set.
+type CreateBackupGroupRequest struct {
+	ServerUUID string
+	Tag        string
+	// Title of the backups. Defaults to the title of the backup group.
+	Title string
+}
+
+// GetBackupGroupRequest represents a request to list the backups of a backup group
+type GetBackupGroupRequest struct {
+	ID string
+}
+
+// RestoreBackupGroupRequest represents a request to restore all backups of a backup group
+type RestoreBackupGroupRequest struct {
+	ID string
+}
+
+// DeleteBackupGroupRequest represents a request to delete all backups of a backup group
+type DeleteBackupGroupRequest struct {
+	ID string
+}
+
+// GetBackupsRequest represents a request to list backups. All set fields must match.
+type GetBackupsRequest struct {
+	// OriginUUID selects the backups of the storage
+	OriginUUID string
+	// ServerUUID selects the backups of the disks attached to the server
+	ServerUUID string
+	// Address selects the backups of the disk attached at the address of the server, e.g. "virtio:0". Requires
+	// ServerUUID.
+	Address string
+	// CreatedAfter and CreatedBefore select the backups created within the time range
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Zone selects the backups in the zone
+	Zone string
+}
+
 // ImportSourceLocation can be a string to a file or io.Reader in StorageImportSourceDirectUpload mode or a URL
 // in StorageImportSourceHTTPImport mode
 type ImportSourceLocation interface{}
@@ -364,3 +452,13 @@ type ResizeStorageFilesystemRequest struct {
 func (r *ResizeStorageFilesystemRequest) RequestURL() string {
 	return fmt.Sprintf("/storage/%s/resize", r.UUID)
 }
+
+// CancelStorageImportRequest represents a request to cancel an ongoing storage import
+type CancelStorageImportRequest struct {
+	StorageUUID string `json:"-"`
+}
+
+// RequestURL implements the Request interface
+func (r *CancelStorageImportRequest) RequestURL() string {
+	return fmt.Sprintf("/storage/%s/import/cancel", r.StorageUUID)
+}
@@ -45,6 +45,9 @@ func TestGetStoragesRequest(t *testing.T) {
 		},
 	}
 	assert.Equal(t, "/storage?label=color%3Dgreen&label=size", r.RequestURL())
+
+	r = GetStoragesRequest{Type: upcloud.StorageTypeBackup, Page: &Page{Size: 50, Number: 3}, Zone: "fi-hel1"}
+	assert.Equal(t, "/storage/backup?limit=50&offset=100", r.RequestURL())
 }
 
 // TestGetStorageDetailsRequest tests that GetStorageDetailsRequest objects behave correctly
@@ -326,3 +329,11 @@ func TestResizeStorageFilesystemRequest(t *testing.T) {
 
 	assert.Equal(t, "/storage/foo/resize", request.RequestURL())
 }
+
+func TestCancelStorageImportRequest(t *testing.T) {
+	request := CancelStorageImportRequest{
+		StorageUUID: "foo",
+	}
+
+	assert.Equal(t, "/storage/foo/import/cancel", request.RequestURL())
+}
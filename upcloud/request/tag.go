@@ -63,3 +63,10 @@ type DeleteTagRequest struct {
 func (r *DeleteTagRequest) RequestURL() string {
 	return fmt.Sprintf("/tag/%s", r.Name)
 }
+
+// RenameTagRequest represents a request to rename a tag by creating a new tag, moving the servers to it and deleting
+// the old tag
+type RenameTagRequest struct {
+	Name    string
+	NewName string
+}
@@ -2,6 +2,8 @@ package upcloud
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
 )
 
 // Constants
@@ -34,20 +36,15 @@ type ServerConfigurations struct {
 // deeply embedded values.
 func (s *ServerConfigurations) UnmarshalJSON(b []byte) error {
 	type serverConfigurationWrapper struct {
-		ServerConfigurations []ServerConfiguration `json:"server_size"`
+		ServerConfigurations *[]ServerConfiguration `json:"server_size"`
 	}
 
+	s.ServerConfigurations = nil
 	v := struct {
 		ServerConfigurations serverConfigurationWrapper `json:"server_sizes"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+	}{serverConfigurationWrapper{&s.ServerConfigurations}}
 
-	s.ServerConfigurations = v.ServerConfigurations.ServerConfigurations
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // ServerConfiguration represents a server configuration
@@ -56,6 +53,44 @@ type ServerConfiguration struct {
 	MemoryAmount int `json:"memory_amount,string"`
 }
 
+// UnmarshalJSON accepts the core number and memory amount as both JSON numbers and numeric strings.
+func (s *ServerConfiguration) UnmarshalJSON(b []byte) error {
+	v := struct {
+		CoreNumber   *FlexibleInt `json:"core_number"`
+		MemoryAmount *FlexibleInt `json:"memory_amount"`
+	}{(*FlexibleInt)(&s.CoreNumber), (*FlexibleInt)(&s.MemoryAmount)}
+
+	return json.Unmarshal(b, &v)
+}
+
+// Filter returns the server configurations matching the specified core number and memory amount.
+// Zero value matches any core number or memory amount.
+func (s *ServerConfigurations) Filter(coreNumber, memoryAmount int) []ServerConfiguration {
+	configurations := make([]ServerConfiguration, 0)
+	for _, c := range s.ServerConfigurations {
+		if coreNumber > 0 && c.CoreNumber != coreNumber {
+			continue
+		}
+		if memoryAmount > 0 && c.MemoryAmount != memoryAmount {
+			continue
+		}
+		configurations = append(configurations, c)
+	}
+	return configurations
+}
+
+// Validate checks that the specified custom core number and memory amount combination is available.
+// This can be used to validate CreateServerRequest and ModifyServerRequest before sending them to the API.
+func (s *ServerConfigurations) Validate(coreNumber, memoryAmount int) error {
+	if coreNumber < 1 || memoryAmount < 1 {
+		return fmt.Errorf("invalid server configuration: core number and memory amount must be positive, got %d cores and %d MB", coreNumber, memoryAmount)
+	}
+	if len(s.Filter(coreNumber, memoryAmount)) == 0 {
+		return fmt.Errorf("invalid server configuration: %d cores with %d MB memory is not available", coreNumber, memoryAmount)
+	}
+	return nil
+}
+
 // Servers represents a /server response
 type Servers struct {
 	Servers []Server `json:"servers"`
@@ -64,20 +99,21 @@ type Servers struct {
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *Servers) UnmarshalJSON(b []byte) error {
-	type serverWrapper struct {
-		Servers []Server `json:"server"`
-	}
-
+	s.Servers = nil
+	var servers []serverJSON
 	v := struct {
-		Servers serverWrapper `json:"servers"`
+		Servers struct {
+			Servers *[]serverJSON `json:"server"`
+		} `json:"servers"`
 	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
+	v.Servers.Servers = &servers
+
+	if err := json.Unmarshal(b, &v); err != nil {
 		return err
 	}
-
-	s.Servers = v.Servers.Servers
-
+	for _, server := range servers {
+		s.Servers = append(s.Servers, Server(server))
+	}
 	return nil
 }
 
@@ -88,17 +124,12 @@ type ServerTagSlice []string
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (t *ServerTagSlice) UnmarshalJSON(b []byte) error {
+	*t = nil
 	v := struct {
-		Tags []string `json:"tag"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		Tags *[]string `json:"tag"`
+	}{(*[]string)(t)}
 
-	(*t) = v.Tags
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // Server represents a server
@@ -114,6 +145,41 @@ type Server struct {
 	Title        string         `json:"title"`
 	UUID         string         `json:"uuid"`
 	Zone         string         `json:"zone"`
+	Labels       LabelSlice     `json:"labels"`
+}
+
+// serverNumbers points to the numeric fields of a Server that the API returns either as JSON numbers or numeric
+// strings. Embedded in a decoding struct at a shallower depth than Server, its fields take precedence over the fields
+// of Server.
+type serverNumbers struct {
+	CoreNumber   *FlexibleInt `json:"core_number"`
+	License      *License     `json:"license"`
+	MemoryAmount *FlexibleInt `json:"memory_amount"`
+	Progress     *Progress    `json:"progress"`
+}
+
+func newServerNumbers(s *Server) serverNumbers {
+	return serverNumbers{
+		CoreNumber:   (*FlexibleInt)(&s.CoreNumber),
+		License:      (*License)(&s.License),
+		MemoryAmount: (*FlexibleInt)(&s.MemoryAmount),
+		Progress:     (*Progress)(&s.Progress),
+	}
+}
+
+// serverJSON decodes a Server with the numeric fields in either format
+type serverJSON Server
+
+func (s *serverJSON) UnmarshalJSON(b []byte) error {
+	type localServer Server
+	type server struct{ *localServer }
+
+	v := struct {
+		server
+		serverNumbers
+	}{server{(*localServer)(s)}, newServerNumbers((*Server)(s))}
+
+	return json.Unmarshal(b, &v)
 }
 
 // ServerStorageDeviceSlice is a slice of ServerStorageDevices.
@@ -123,17 +189,12 @@ type ServerStorageDeviceSlice []ServerStorageDevice
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *ServerStorageDeviceSlice) UnmarshalJSON(b []byte) error {
+	*s = nil
 	v := struct {
-		StorageDevices []ServerStorageDevice `json:"storage_device"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		StorageDevices *[]ServerStorageDevice `json:"storage_device"`
+	}{(*[]ServerStorageDevice)(s)}
 
-	(*s) = v.StorageDevices
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // ServerNetworking represents the networking on a server response.
@@ -163,6 +224,25 @@ type ServerDetails struct {
 	RemoteAccessHost     string                   `json:"remote_access_host"`
 	RemoteAccessPassword string                   `json:"remote_access_password"`
 	RemoteAccessPort     int                      `json:"remote_access_port,string"`
+	// Username and Password are only returned when creating a server with password delivery "none"
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ServerCredentials represents the login credentials generated when creating a server
+type ServerCredentials struct {
+	Username string
+	Password string
+}
+
+// Credentials returns the credentials generated for the server. They are only available in the response of creating
+// a server with password delivery "none"; with email and SMS delivery, or when password creation is disabled for the
+// login user, false is returned.
+func (s *ServerDetails) Credentials() (ServerCredentials, bool) {
+	if s.Password == "" {
+		return ServerCredentials{}, false
+	}
+	return ServerCredentials{Username: s.Username, Password: s.Password}, true
 }
 
 func (s *ServerDetails) StorageDevice(storageUUID string) *ServerStorageDevice {
@@ -174,20 +254,105 @@ func (s *ServerDetails) StorageDevice(storageUUID string) *ServerStorageDevice {
 	return nil
 }
 
+// StorageDeviceByAddress returns a reference to the storage device at the given address, e.g. "virtio:1", or nil
+func (s *ServerDetails) StorageDeviceByAddress(address string) *ServerStorageDevice {
+	for _, storageDevice := range s.StorageDevices {
+		if storageDevice.Address == address {
+			return &storageDevice
+		}
+	}
+	return nil
+}
+
+// StorageDeviceByTitle returns a reference to the first storage device with the given title, or nil
+func (s *ServerDetails) StorageDeviceByTitle(title string) *ServerStorageDevice {
+	for _, storageDevice := range s.StorageDevices {
+		if storageDevice.Title == title {
+			return &storageDevice
+		}
+	}
+	return nil
+}
+
+// SimpleBackupEnabled returns true if simple backup is enabled for the server
+func (s *ServerDetails) SimpleBackupEnabled() bool {
+	return s.SimpleBackup != "" && s.SimpleBackup != "no"
+}
+
+// PublicIPv4Address returns the first public IPv4 address of the server, or an empty string if there is none
+func (s *ServerDetails) PublicIPv4Address() string {
+	return s.IPAddresses.Address(IPAddressFamilyIPv4, IPAddressAccessPublic)
+}
+
+// PublicIPv6Address returns the first public IPv6 address of the server, or an empty string if there is none
+func (s *ServerDetails) PublicIPv6Address() string {
+	return s.IPAddresses.Address(IPAddressFamilyIPv6, IPAddressAccessPublic)
+}
+
+// UtilityIPv4Address returns the first utility network IPv4 address of the server, or an empty string if there is none
+func (s *ServerDetails) UtilityIPv4Address() string {
+	return s.IPAddresses.Address(IPAddressFamilyIPv4, IPAddressAccessUtility)
+}
+
+// PrivateIPv4Address returns the first private network IPv4 address of the server, or an empty string if there is none
+func (s *ServerDetails) PrivateIPv4Address() string {
+	if addresses := s.AllAddresses(IPAddressFamilyIPv4, IPAddressAccessPrivate); len(addresses) > 0 {
+		return addresses[0]
+	}
+	return ""
+}
+
+// AllAddresses returns the addresses of the server matching the family and access, including the addresses of the
+// network interfaces. Empty family or access matches any family or access.
+func (s *ServerDetails) AllAddresses(family, access string) []string {
+	addresses := s.IPAddresses.AllAddresses(family, access)
+	for _, iface := range s.Networking.Interfaces {
+		if access != "" && iface.Type != access {
+			continue
+		}
+		for _, address := range iface.IPAddresses.AllAddresses(family, "") {
+			if address != "" && !slices.Contains(addresses, address) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	return addresses
+}
+
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *ServerDetails) UnmarshalJSON(b []byte) error {
 	type localServerDetails ServerDetails
 
-	v := struct {
-		ServerDetails localServerDetails `json:"server"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
+	// Decode directly into the receiver to avoid allocating and copying a temporary value. The other response
+	// envelopes, e.g. StorageDetails, IPAddress and Network, are decoded the same way. If decoding fails, the receiver
+	// is left partially decoded and should not be used.
+	*s = ServerDetails{}
+	type serverDetails struct {
+		*localServerDetails
+		serverNumbers
 	}
+	v := struct {
+		ServerDetails serverDetails `json:"server"`
+	}{serverDetails{(*localServerDetails)(s), newServerNumbers(&s.Server)}}
 
-	(*s) = ServerDetails(v.ServerDetails)
+	return json.Unmarshal(b, &v)
+}
 
-	return nil
+// RescueSession records the configuration of a server before it was booted from an ISO with service.RescueBoot, so
+// that service.ExitRescue can restore it
+type RescueSession struct {
+	ServerUUID string
+	// StorageUUID is the ISO the server was booted from
+	StorageUUID string
+	// BootOrder is the boot order of the server before the rescue boot
+	BootOrder string
+	// CDROMAddress is the address of the CD-ROM device of the server
+	CDROMAddress string
+	// CDROMAttached is true if the CD-ROM device was attached for the rescue boot
+	CDROMAttached bool
+	// PreviousCDROM is the storage loaded in the CD-ROM device before the rescue boot
+	PreviousCDROM string
+	// Started is true if the server was started before the rescue boot
+	Started bool
 }
@@ -53,6 +53,37 @@ func (s *ServerGroup) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// AntiAffinityStatusOf returns the anti affinity status of the member server. The ok result is false if the group has
+// no status for the server.
+func (s *ServerGroup) AntiAffinityStatusOf(serverUUID string) (status ServerAntiAffinityStatus, ok bool) {
+	for _, st := range s.AntiAffinityStatus {
+		if st.ServerUUID == serverUUID {
+			return st.Status, true
+		}
+	}
+	return "", false
+}
+
+// UnmetAntiAffinityMembers returns the UUIDs of the members whose anti affinity status is unmet
+func (s *ServerGroup) UnmetAntiAffinityMembers() []string {
+	members := make([]string, 0)
+	for _, st := range s.AntiAffinityStatus {
+		if st.Status == ServerAntiAffinityStatusUnmet {
+			members = append(members, st.ServerUUID)
+		}
+	}
+	return members
+}
+
+// AntiAffinityViolations returns the UUIDs of the members that violate strict anti affinity. Unmet status is not a
+// violation for groups with best-effort or no anti affinity, so an empty slice is returned for those.
+func (s *ServerGroup) AntiAffinityViolations() []string {
+	if s.AntiAffinityPolicy != ServerGroupAntiAffinityPolicyStrict {
+		return make([]string, 0)
+	}
+	return s.UnmetAntiAffinityMembers()
+}
+
 // ServerGroups represents list of server groups
 type ServerGroups []ServerGroup
 
@@ -75,3 +106,15 @@ func (s *ServerGroups) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// AntiAffinityViolations returns the members violating strict anti affinity keyed by server group UUID. Groups without
+// violations are omitted.
+func (s ServerGroups) AntiAffinityViolations() map[string][]string {
+	violations := make(map[string][]string)
+	for i := range s {
+		if v := s[i].AntiAffinityViolations(); len(v) > 0 {
+			violations[s[i].UUID] = v
+		}
+	}
+	return violations
+}
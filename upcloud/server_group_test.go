@@ -174,3 +174,27 @@ func TestUnmarshalServerGroups(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+// TestServerGroupAntiAffinityViolations tests that the unmet members of strict groups are reported
+func TestServerGroupAntiAffinityViolations(t *testing.T) {
+	status := []ServerGroupMemberAntiAffinityStatus{
+		{ServerUUID: "a", Status: ServerAntiAffinityStatusMet},
+		{ServerUUID: "b", Status: ServerAntiAffinityStatusUnmet},
+	}
+	groups := ServerGroups{
+		{UUID: "strict", AntiAffinityPolicy: ServerGroupAntiAffinityPolicyStrict, AntiAffinityStatus: status},
+		{UUID: "best-effort", AntiAffinityPolicy: ServerGroupAntiAffinityPolicyBestEffort, AntiAffinityStatus: status},
+		{UUID: "ok", AntiAffinityPolicy: ServerGroupAntiAffinityPolicyStrict, AntiAffinityStatus: status[:1]},
+	}
+
+	st, ok := groups[0].AntiAffinityStatusOf("b")
+	assert.True(t, ok)
+	assert.Equal(t, ServerAntiAffinityStatusUnmet, st)
+	_, ok = groups[0].AntiAffinityStatusOf("c")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"b"}, groups[1].UnmetAntiAffinityMembers())
+	assert.Empty(t, groups[1].AntiAffinityViolations())
+	assert.Equal(t, []string{"b"}, groups[0].AntiAffinityViolations())
+	assert.Equal(t, map[string][]string{"strict": {"b"}}, groups.AntiAffinityViolations())
+}
@@ -0,0 +1,198 @@
+package upcloud
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ServerSpec describes the desired state of a server. Zero value fields are not compared with the live server, except
+// that non-nil empty Tags, Labels and StorageUUIDs mean that the server should not have any.
+type ServerSpec struct {
+	Title        string
+	Hostname     string
+	Zone         string
+	Plan         string
+	CoreNumber   int
+	MemoryAmount int
+	Firewall     string
+	Metadata     Boolean
+	SimpleBackup string
+	Timezone     string
+	NICModel     string
+	VideoModel   string
+	BootOrder    string
+	Tags         []string
+	Labels       map[string]string
+	// StorageUUIDs lists the disks that should be attached to the server
+	StorageUUIDs []string
+}
+
+// ServerFieldChange represents a change of a single server field
+type ServerFieldChange struct {
+	Field string
+	From  string
+	To    string
+	// RequiresReplacement is true if the field cannot be modified and the server has to be recreated instead
+	RequiresReplacement bool
+}
+
+// ServerChangeSet contains the changes needed to bring a live server to the state described by a ServerSpec
+type ServerChangeSet struct {
+	Fields         []ServerFieldChange
+	AttachStorages []string
+	DetachStorages []string
+	AddTags        []string
+	RemoveTags     []string
+	// SetLabels contains the labels to add or change
+	SetLabels    map[string]string
+	RemoveLabels []string
+}
+
+// Empty reports whether the change set contains no changes
+func (c ServerChangeSet) Empty() bool {
+	return len(c.Fields) == 0 &&
+		len(c.AttachStorages) == 0 &&
+		len(c.DetachStorages) == 0 &&
+		len(c.AddTags) == 0 &&
+		len(c.RemoveTags) == 0 &&
+		len(c.SetLabels) == 0 &&
+		len(c.RemoveLabels) == 0
+}
+
+// RequiresReplacement reports whether any of the field changes requires recreating the server
+func (c ServerChangeSet) RequiresReplacement() bool {
+	for _, f := range c.Fields {
+		if f.RequiresReplacement {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the changes in a human-readable plan format, one change per line
+func (c ServerChangeSet) String() string {
+	var lines []string
+	for _, f := range c.Fields {
+		line := fmt.Sprintf("~ %s: %q -> %q", f.Field, f.From, f.To)
+		if f.RequiresReplacement {
+			line += " (requires replacement)"
+		}
+		lines = append(lines, line)
+	}
+	for _, uuid := range c.AttachStorages {
+		lines = append(lines, fmt.Sprintf("+ storage %s", uuid))
+	}
+	for _, uuid := range c.DetachStorages {
+		lines = append(lines, fmt.Sprintf("- storage %s", uuid))
+	}
+	for _, tag := range c.AddTags {
+		lines = append(lines, fmt.Sprintf("+ tag %s", tag))
+	}
+	for _, tag := range c.RemoveTags {
+		lines = append(lines, fmt.Sprintf("- tag %s", tag))
+	}
+	for _, key := range sortedKeys(c.SetLabels) {
+		lines = append(lines, fmt.Sprintf("~ label %s=%s", key, c.SetLabels[key]))
+	}
+	for _, key := range c.RemoveLabels {
+		lines = append(lines, fmt.Sprintf("- label %s", key))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns the changes needed to bring the live server to the state described by the spec
+func (s ServerSpec) Diff(details *ServerDetails) ServerChangeSet {
+	var c ServerChangeSet
+
+	field := func(name, want, have string, replace bool) {
+		if want != "" && want != have {
+			c.Fields = append(c.Fields, ServerFieldChange{Field: name, From: have, To: want, RequiresReplacement: replace})
+		}
+	}
+	number := func(name string, want, have int) {
+		if want != 0 && want != have {
+			c.Fields = append(c.Fields, ServerFieldChange{Field: name, From: strconv.Itoa(have), To: strconv.Itoa(want)})
+		}
+	}
+
+	field("zone", s.Zone, details.Zone, true)
+	field("title", s.Title, details.Title, false)
+	field("hostname", s.Hostname, details.Hostname, false)
+	field("plan", s.Plan, details.Plan, false)
+	number("core_number", s.CoreNumber, details.CoreNumber)
+	number("memory_amount", s.MemoryAmount, details.MemoryAmount)
+	field("firewall", s.Firewall, details.Firewall, false)
+	if !s.Metadata.Empty() && s.Metadata.Bool() != details.Metadata.Bool() {
+		c.Fields = append(c.Fields, ServerFieldChange{Field: "metadata", From: strconv.FormatBool(details.Metadata.Bool()), To: strconv.FormatBool(s.Metadata.Bool())})
+	}
+	field("simple_backup", s.SimpleBackup, details.SimpleBackup, false)
+	field("timezone", s.Timezone, details.Timezone, false)
+	field("nic_model", s.NICModel, details.NICModel, false)
+	field("video_model", s.VideoModel, details.VideoModel, false)
+	field("boot_order", s.BootOrder, details.BootOrder, false)
+
+	if s.StorageUUIDs != nil {
+		var attached []string
+		for _, device := range details.StorageDevices {
+			if device.Type == StorageTypeDisk {
+				attached = append(attached, device.UUID)
+			}
+		}
+		c.AttachStorages, c.DetachStorages = setDiff(s.StorageUUIDs, attached)
+	}
+
+	if s.Tags != nil {
+		c.AddTags, c.RemoveTags = setDiff(s.Tags, details.Tags)
+	}
+
+	if s.Labels != nil {
+		have := make(map[string]string, len(details.Labels))
+		for _, l := range details.Labels {
+			have[l.Key] = l.Value
+		}
+		for key, value := range s.Labels {
+			if v, ok := have[key]; !ok || v != value {
+				if c.SetLabels == nil {
+					c.SetLabels = make(map[string]string)
+				}
+				c.SetLabels[key] = value
+			}
+		}
+		for _, key := range sortedKeys(have) {
+			if _, ok := s.Labels[key]; !ok {
+				c.RemoveLabels = append(c.RemoveLabels, key)
+			}
+		}
+	}
+
+	return c
+}
+
+// setDiff returns the sorted values of want missing from have and the values of have missing from want
+func setDiff(want, have []string) (add, remove []string) {
+	for _, v := range want {
+		if !slices.Contains(have, v) && !slices.Contains(add, v) {
+			add = append(add, v)
+		}
+	}
+	for _, v := range have {
+		if !slices.Contains(want, v) && !slices.Contains(remove, v) {
+			remove = append(remove, v)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerSpec_Diff(t *testing.T) {
+	t.Parallel()
+
+	details := &ServerDetails{
+		Server: Server{
+			Title:    "web",
+			Hostname: "web.example.com",
+			Zone:     "fi-hel1",
+			Plan:     "1xCPU-1GB",
+			Tags:     ServerTagSlice{"a", "b"},
+		},
+		Metadata: True,
+		Labels:   LabelSlice{{Key: "env", Value: "dev"}, {Key: "team", Value: "x"}},
+		StorageDevices: ServerStorageDeviceSlice{
+			{UUID: "disk-1", Type: StorageTypeDisk},
+			{UUID: "disk-2", Type: StorageTypeDisk},
+			{Type: StorageTypeCDROM},
+		},
+	}
+
+	// Only the specified fields are compared
+	assert.True(t, ServerSpec{Title: "web", Zone: "fi-hel1"}.Diff(details).Empty())
+
+	c := ServerSpec{
+		Title:        "web-1",
+		Zone:         "de-fra1",
+		Plan:         "1xCPU-1GB",
+		Metadata:     False,
+		Tags:         []string{"b", "c"},
+		Labels:       map[string]string{"env": "prod", "team": "x"},
+		StorageUUIDs: []string{"disk-1", "disk-3"},
+	}.Diff(details)
+
+	assert.Equal(t, []ServerFieldChange{
+		{Field: "zone", From: "fi-hel1", To: "de-fra1", RequiresReplacement: true},
+		{Field: "title", From: "web", To: "web-1"},
+		{Field: "metadata", From: "true", To: "false"},
+	}, c.Fields)
+	assert.True(t, c.RequiresReplacement())
+	assert.Equal(t, []string{"disk-3"}, c.AttachStorages)
+	assert.Equal(t, []string{"disk-2"}, c.DetachStorages)
+	assert.Equal(t, []string{"c"}, c.AddTags)
+	assert.Equal(t, []string{"a"}, c.RemoveTags)
+	assert.Equal(t, map[string]string{"env": "prod"}, c.SetLabels)
+	assert.Empty(t, c.RemoveLabels)
+	assert.False(t, c.Empty())
+
+	assert.Equal(t, `~ zone: "fi-hel1" -> "de-fra1" (requires replacement)
+~ title: "web" -> "web-1"
+~ metadata: "true" -> "false"
++ storage disk-3
+- storage disk-2
++ tag c
+- tag a
+~ label env=prod`, c.String())
+}
+
+func TestServerSpec_DiffEmptyCollections(t *testing.T) {
+	t.Parallel()
+
+	details := &ServerDetails{
+		Server:         Server{Tags: ServerTagSlice{"a"}},
+		Labels:         LabelSlice{{Key: "env", Value: "dev"}},
+		StorageDevices: ServerStorageDeviceSlice{{UUID: "disk-1", Type: StorageTypeDisk}},
+	}
+
+	c := ServerSpec{Tags: []string{}, Labels: map[string]string{}, StorageUUIDs: []string{}, CoreNumber: 2}.Diff(details)
+	assert.Equal(t, []ServerFieldChange{{Field: "core_number", From: "0", To: "2"}}, c.Fields)
+	assert.False(t, c.RequiresReplacement())
+	assert.Equal(t, []string{"a"}, c.RemoveTags)
+	assert.Equal(t, []string{"env"}, c.RemoveLabels)
+	assert.Equal(t, []string{"disk-1"}, c.DetachStorages)
+}
@@ -0,0 +1,127 @@
+package upcloud
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ServerOperation is an operation whose legality depends on the state of the server
+type ServerOperation string
+
+const (
+	ServerOperationStart   ServerOperation = "start"
+	ServerOperationStop    ServerOperation = "stop"
+	ServerOperationRestart ServerOperation = "restart"
+	ServerOperationDelete  ServerOperation = "delete"
+	// ServerOperationMigrate moves the server to another zone, see service.MigrateServerToZone
+	ServerOperationMigrate ServerOperation = "migrate"
+	// ServerOperationChangeStorageTier moves a disk of the server to another tier, see service.ChangeStorageTier
+	ServerOperationChangeStorageTier ServerOperation = "change_storage_tier"
+	// ServerOperationWait is not an API operation but the step of waiting for the server to leave maintenance state
+	ServerOperationWait ServerOperation = "wait"
+)
+
+// ServerTransition is an operation that is legal in the From state and leaves the server in the To state. To is empty
+// if the operation deletes the server.
+type ServerTransition struct {
+	From      string
+	Operation ServerOperation
+	To        string
+}
+
+// serverTransitions is the graph of legal server operations. A server in maintenance state does not accept any
+// operations, but leaves the state on its own.
+var serverTransitions = []ServerTransition{
+	{From: ServerStateStarted, Operation: ServerOperationStop, To: ServerStateStopped},
+	{From: ServerStateStarted, Operation: ServerOperationRestart, To: ServerStateStarted},
+	{From: ServerStateStopped, Operation: ServerOperationStart, To: ServerStateStarted},
+	{From: ServerStateStopped, Operation: ServerOperationDelete},
+	{From: ServerStateStopped, Operation: ServerOperationMigrate, To: ServerStateStopped},
+	{From: ServerStateStopped, Operation: ServerOperationChangeStorageTier, To: ServerStateStopped},
+	{From: ServerStateError, Operation: ServerOperationStop, To: ServerStateStopped},
+}
+
+// ErrIllegalTransition is matched by *ServerTransitionError with errors.Is
+var ErrIllegalTransition = errors.New("illegal server state transition")
+
+// ServerTransitionError is returned when an operation is not legal in the current state of the server. Steps lists
+// the operations that bring the server to a state in which the operation is legal.
+type ServerTransitionError struct {
+	ServerUUID string
+	State      string
+	Operation  ServerOperation
+	Steps      []ServerOperation
+}
+
+func (e *ServerTransitionError) Error() string {
+	msg := fmt.Sprintf("can not %s server %s in %s state", e.Operation, e.ServerUUID, e.State)
+	if len(e.Steps) == 0 {
+		return msg
+	}
+	steps := make([]string, len(e.Steps))
+	for i, step := range e.Steps {
+		steps[i] = string(step)
+	}
+	return fmt.Sprintf("%s, required steps: %s", msg, strings.Join(steps, ", "))
+}
+
+// Is reports whether target is ErrIllegalTransition
+func (e *ServerTransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+// ServerTransitions returns the legal server operations and the states they lead to
+func ServerTransitions() []ServerTransition {
+	return slices.Clone(serverTransitions)
+}
+
+// ServerOperations returns the operations that are legal in the state, e.g. for disabling the invalid actions in a UI
+func ServerOperations(state string) []ServerOperation {
+	var operations []ServerOperation
+	for _, t := range serverTransitions {
+		if t.From == state {
+			operations = append(operations, t.Operation)
+		}
+	}
+	return operations
+}
+
+// CheckServerTransition returns *ServerTransitionError if the operation is not legal in the state of the server. The
+// shortest sequence of operations leading to a state in which the operation is legal is returned in Steps. A server in
+// maintenance state needs to be waited for before the steps can be determined.
+func CheckServerTransition(server *ServerDetails, operation ServerOperation) error {
+	if slices.Contains(ServerOperations(server.State), operation) {
+		return nil
+	}
+	err := &ServerTransitionError{ServerUUID: server.UUID, State: server.State, Operation: operation}
+	if server.State == ServerStateMaintenance {
+		err.Steps = []ServerOperation{ServerOperationWait}
+		return err
+	}
+
+	// Breadth-first search for the shortest path to a state allowing the operation
+	paths := map[string][]ServerOperation{server.State: {}}
+	queue := []string{server.State}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, t := range serverTransitions {
+			if t.From != state || t.To == "" {
+				continue
+			}
+			if _, seen := paths[t.To]; seen {
+				continue
+			}
+			path := append(slices.Clone(paths[state]), t.Operation)
+			if slices.Contains(ServerOperations(t.To), operation) {
+				err.Steps = path
+				return err
+			}
+			paths[t.To] = path
+			queue = append(queue, t.To)
+		}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package upcloud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerOperations(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []ServerOperation{ServerOperationStop, ServerOperationRestart}, ServerOperations(ServerStateStarted))
+	assert.Empty(t, ServerOperations(ServerStateMaintenance))
+	assert.Contains(t, ServerTransitions(), ServerTransition{From: ServerStateStopped, Operation: ServerOperationStart, To: ServerStateStarted})
+}
+
+func TestCheckServerTransition(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CheckServerTransition(&ServerDetails{Server: Server{State: ServerStateStopped}}, ServerOperationDelete))
+
+	for _, test := range []struct {
+		state string
+		op    ServerOperation
+		steps []ServerOperation
+		msg   string
+	}{
+		{ServerStateStarted, ServerOperationDelete, []ServerOperation{ServerOperationStop}, "can not delete server uuid in started state, required steps: stop"},
+		{ServerStateError, ServerOperationStart, []ServerOperation{ServerOperationStop}, "can not start server uuid in error state, required steps: stop"},
+		{ServerStateMaintenance, ServerOperationStop, []ServerOperation{ServerOperationWait}, "can not stop server uuid in maintenance state, required steps: wait"},
+		{ServerStateError, ServerOperationRestart, []ServerOperation{ServerOperationStop, ServerOperationStart}, "can not restart server uuid in error state, required steps: stop, start"},
+	} {
+		err := CheckServerTransition(&ServerDetails{Server: Server{UUID: "uuid", State: test.state}}, test.op)
+		var transitionErr *ServerTransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		assert.True(t, errors.Is(err, ErrIllegalTransition))
+		assert.Equal(t, test.steps, transitionErr.Steps)
+		assert.EqualError(t, err, test.msg)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestUnmarshalServerConfiguratons tests that ServerConfigurations and ServerConfiguration are unmarshaled correctly
@@ -343,3 +344,145 @@ func TestStorageDevice(t *testing.T) {
 	assert.Equal(t, serverDetails.StorageDevice(needle.UUID), &needle, "Should match the requested storage device")
 	assert.Nil(t, serverDetails.StorageDevice("012580a1-32a1-466e-a323-689ca16f2d42"), "Should return nil when no matches")
 }
+
+func TestStorageDeviceByAddressAndTitle(t *testing.T) {
+	serverDetails := ServerDetails{
+		StorageDevices: []ServerStorageDevice{
+			{UUID: "012580a1-32a1-466e-a323-689ca16f2d44", Address: "virtio:0", Title: "root", Type: StorageTypeDisk},
+			{UUID: "012580a1-32a1-466e-a323-689ca16f2d45", Address: "virtio:1", Title: "data", Type: StorageTypeDisk},
+			{UUID: "012580a1-32a1-466e-a323-689ca16f2d46", Address: "ide:0:0", Title: "installer", Type: StorageTypeCDROM},
+		},
+	}
+
+	assert.Equal(t, "data", serverDetails.StorageDeviceByAddress("virtio:1").Title)
+	assert.Nil(t, serverDetails.StorageDeviceByAddress("virtio:2"))
+	assert.Equal(t, "ide:0:0", serverDetails.StorageDeviceByTitle("installer").Address)
+	assert.Nil(t, serverDetails.StorageDeviceByTitle("missing"))
+
+	assert.True(t, serverDetails.StorageDevices[0].IsDisk())
+	assert.False(t, serverDetails.StorageDevices[0].IsCDROM())
+	assert.True(t, serverDetails.StorageDevices[2].IsCDROM())
+	assert.False(t, serverDetails.StorageDevices[2].IsDisk())
+}
+
+// TestServerConfigurationsFilterAndValidate tests that server configurations can be filtered and validated
+func TestServerConfigurationsFilterAndValidate(t *testing.T) {
+	configurations := ServerConfigurations{
+		ServerConfigurations: []ServerConfiguration{
+			{CoreNumber: 1, MemoryAmount: 512},
+			{CoreNumber: 1, MemoryAmount: 1024},
+			{CoreNumber: 2, MemoryAmount: 1024},
+		},
+	}
+
+	assert.Len(t, configurations.Filter(0, 0), 3)
+	assert.Len(t, configurations.Filter(1, 0), 2)
+	assert.Len(t, configurations.Filter(0, 1024), 2)
+	assert.Equal(t, []ServerConfiguration{{CoreNumber: 2, MemoryAmount: 1024}}, configurations.Filter(2, 1024))
+
+	assert.NoError(t, configurations.Validate(1, 512))
+	assert.Error(t, configurations.Validate(2, 512))
+	assert.Error(t, configurations.Validate(0, 512))
+}
+
+// TestUnmarshalServerDetails_reuse tests that unmarshaling into a previously used value replaces its contents
+func TestUnmarshalServerDetails_reuse(t *testing.T) {
+	t.Parallel()
+
+	var details ServerDetails
+	err := json.Unmarshal([]byte(`{"server":{"uuid":"a","state":"started","tags":{"tag":["one","two"]},"storage_devices":{"storage_device":[{"storage":"s1"}]}}}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, ServerTagSlice{"one", "two"}, details.Tags)
+	assert.Len(t, details.StorageDevices, 1)
+
+	err = json.Unmarshal([]byte(`{"server":{"uuid":"b","tags":{"tag":["three"]}}}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", details.UUID)
+	assert.Empty(t, details.State)
+	assert.Equal(t, ServerTagSlice{"three"}, details.Tags)
+	assert.Nil(t, details.StorageDevices)
+
+	var servers Servers
+	assert.NoError(t, json.Unmarshal([]byte(`{"servers":{"server":[{"uuid":"a"},{"uuid":"b"}]}}`), &servers))
+	assert.NoError(t, json.Unmarshal([]byte(`{"servers":{"server":[{"uuid":"c"}]}}`), &servers))
+	assert.Equal(t, []Server{{UUID: "c"}}, servers.Servers)
+}
+
+// TestServerDetailsIPAddresses tests the IP address accessors of server details
+func TestServerDetailsIPAddresses(t *testing.T) {
+	serverDetails := ServerDetails{
+		IPAddresses: IPAddressSlice{
+			{Address: "10.0.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessUtility},
+			{Address: "94.237.0.1", Family: IPAddressFamilyIPv4, Access: IPAddressAccessPublic},
+			{Address: "2a04:3540::1", Family: IPAddressFamilyIPv6, Access: IPAddressAccessPublic},
+		},
+		Networking: ServerNetworking{
+			Interfaces: ServerInterfaceSlice{
+				{
+					Type:        NetworkTypeUtility,
+					IPAddresses: IPAddressSlice{{Address: "10.0.0.1", Family: IPAddressFamilyIPv4}},
+				},
+				{
+					Type:        NetworkTypePrivate,
+					IPAddresses: IPAddressSlice{{Address: "172.16.0.10", Family: IPAddressFamilyIPv4}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "94.237.0.1", serverDetails.PublicIPv4Address())
+	assert.Equal(t, "2a04:3540::1", serverDetails.PublicIPv6Address())
+	assert.Equal(t, "10.0.0.1", serverDetails.UtilityIPv4Address())
+	assert.Equal(t, "172.16.0.10", serverDetails.PrivateIPv4Address())
+	assert.Equal(t, []string{"10.0.0.1", "94.237.0.1", "172.16.0.10"}, serverDetails.AllAddresses(IPAddressFamilyIPv4, ""))
+	assert.Empty(t, (&ServerDetails{}).PublicIPv4Address())
+}
+
+func TestServerDetailsCredentials(t *testing.T) {
+	t.Parallel()
+
+	var details ServerDetails
+	assert.NoError(t, json.Unmarshal([]byte(`{"server":{"uuid":"a","username":"root","password":"secret"}}`), &details))
+	creds, ok := details.Credentials()
+	assert.True(t, ok)
+	assert.Equal(t, ServerCredentials{Username: "root", Password: "secret"}, creds)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"server":{"uuid":"a"}}`), &details))
+	_, ok = details.Credentials()
+	assert.False(t, ok)
+}
+
+func TestServerDetailsSimpleBackupEnabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&ServerDetails{}).SimpleBackupEnabled())
+	assert.False(t, (&ServerDetails{SimpleBackup: "no"}).SimpleBackupEnabled())
+	assert.True(t, (&ServerDetails{SimpleBackup: "0430,dailies"}).SimpleBackupEnabled())
+}
+
+// TestUnmarshalServer_numbers tests that the numeric server fields are accepted as both JSON numbers and numeric strings
+func TestUnmarshalServer_numbers(t *testing.T) {
+	servers := Servers{}
+	err := json.Unmarshal([]byte(`{"servers":{"server":[
+		{"core_number":2,"memory_amount":"4096","license":"0.5","progress":50,"uuid":"a"},
+		{"core_number":"1","memory_amount":1024,"license":0,"progress":"95","uuid":"b"}
+	]}}`), &servers)
+	require.NoError(t, err)
+	require.Len(t, servers.Servers, 2)
+	assert.Equal(t, Server{CoreNumber: 2, MemoryAmount: 4096, License: 0.5, Progress: 50, UUID: "a"}, servers.Servers[0])
+	assert.Equal(t, Server{CoreNumber: 1, MemoryAmount: 1024, Progress: 95, UUID: "b"}, servers.Servers[1])
+
+	details := ServerDetails{}
+	err = json.Unmarshal([]byte(`{"server":{"core_number":2,"memory_amount":2048,"progress":"10","firewall":"on","uuid":"a"}}`), &details)
+	require.NoError(t, err)
+	assert.Equal(t, 2, details.CoreNumber)
+	assert.Equal(t, 2048, details.MemoryAmount)
+	assert.Equal(t, 10, details.Progress)
+	assert.Equal(t, "on", details.Firewall)
+	assert.Equal(t, "a", details.UUID)
+
+	configurations := ServerConfigurations{}
+	err = json.Unmarshal([]byte(`{"server_sizes":{"server_size":[{"core_number":1,"memory_amount":"1024"}]}}`), &configurations)
+	require.NoError(t, err)
+	assert.Equal(t, []ServerConfiguration{{CoreNumber: 1, MemoryAmount: 1024}}, configurations.ServerConfigurations)
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// PreflightModifyServer checks that enabling simple backup for the server does not conflict with backup rules of its
+// storages. Conflicts are returned as *PreflightError. Other errors are returned as is.
+func (s *Service) PreflightModifyServer(ctx context.Context, r *request.ModifyServerRequest) error {
+	if r.SimpleBackup == "" || r.SimpleBackup == request.SimpleBackupDisabled {
+		return nil
+	}
+	protection, err := s.GetServerStorageProtection(ctx, &request.GetServerDetailsRequest{UUID: r.UUID})
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, p := range protection {
+		if p.BackupRule != nil {
+			violations = append(violations, fmt.Sprintf("storage %s at %s has a backup rule, which can not be combined with simple backup", p.StorageUUID, p.Address))
+		}
+	}
+	if len(violations) > 0 {
+		return &PreflightError{Violations: violations}
+	}
+	return nil
+}
+
+// PreflightModifyStorage checks that setting a backup rule for the storage does not conflict with simple backup of
+// the servers the storage is attached to. Conflicts are returned as *PreflightError. Other errors are returned as is.
+func (s *Service) PreflightModifyStorage(ctx context.Context, r *request.ModifyStorageRequest) error {
+	if r.BackupRule == nil || r.BackupRule.Interval == "" {
+		return nil
+	}
+	storage, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: r.UUID})
+	if err != nil {
+		return err
+	}
+
+	var violations []string
+	for _, uuid := range storage.ServerUUIDs {
+		server, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: uuid})
+		if err != nil {
+			return err
+		}
+		if server.SimpleBackupEnabled() {
+			violations = append(violations, fmt.Sprintf("server %s has simple backup enabled, which can not be combined with backup rules", uuid))
+		}
+	}
+	if len(violations) > 0 {
+		return &PreflightError{Violations: violations}
+	}
+	return nil
+}
+
+// MigrateToSimpleBackup removes the backup rules of the server's disks and then enables simple backup for the server.
+// Existing backups are kept.
+func (s *Service) MigrateToSimpleBackup(ctx context.Context, r *request.MigrateToSimpleBackupRequest) (*upcloud.ServerDetails, error) {
+	if r.SimpleBackup == "" || r.SimpleBackup == request.SimpleBackupDisabled {
+		return nil, fmt.Errorf("invalid simple backup %q", r.SimpleBackup)
+	}
+	protection, err := s.GetServerStorageProtection(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range protection {
+		if p.BackupRule == nil {
+			continue
+		}
+		// Empty backup rule removes the rule
+		if _, err := s.ModifyStorage(ctx, &request.ModifyStorageRequest{UUID: p.StorageUUID, BackupRule: &upcloud.BackupRule{}}); err != nil {
+			return nil, fmt.Errorf("removing backup rule of storage %s: %w", p.StorageUUID, err)
+		}
+	}
+	return s.ModifyServer(ctx, &request.ModifyServerRequest{UUID: r.ServerUUID, SimpleBackup: r.SimpleBackup})
+}
+
+// MigrateToBackupRules disables simple backup of the server and then sets the backup rule for each of its disks.
+// Existing backups are kept.
+func (s *Service) MigrateToBackupRules(ctx context.Context, r *request.MigrateToBackupRulesRequest) (*upcloud.ServerDetails, error) {
+	if r.BackupRule.Interval == "" {
+		return nil, errors.New("backup rule interval is required")
+	}
+	server, err := s.ModifyServer(ctx, &request.ModifyServerRequest{UUID: r.ServerUUID, SimpleBackup: request.SimpleBackupDisabled})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, device := range server.StorageDevices {
+		if !device.IsDisk() {
+			continue
+		}
+		rule := r.BackupRule
+		if _, err := s.ModifyStorage(ctx, &request.ModifyStorageRequest{UUID: device.UUID, BackupRule: &rule}); err != nil {
+			return nil, fmt.Errorf("setting backup rule of storage %s: %w", device.UUID, err)
+		}
+	}
+	return server, nil
+}
+
+// BackupGroupLabel is the label key holding the group ID of the backups created with CreateBackupGroup
+const BackupGroupLabel = "backup_group"
+
+// CreateBackupGroup backs up all disks of the server, or of all servers with the tag, in one pass and labels the
+// backups with a common group ID. A disk attached to several of the servers is backed up once. The backups are created
+// concurrently so that they are taken as close to each other in time as possible, and each backup is labeled once it
+// has come online. Stop the servers, or otherwise quiesce their file systems, beforehand for consistent backups.
+func (s *Service) CreateBackupGroup(ctx context.Context, r *request.CreateBackupGroupRequest) (*upcloud.BackupGroup, error) {
+	if (r.ServerUUID == "") == (r.Tag == "") {
+		return nil, errors.New("either server UUID or tag is required")
+	}
+	storages, err := s.backupGroupStorages(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(storages) == 0 {
+		return nil, errors.New("no disks to back up")
+	}
+
+	group := &upcloud.BackupGroup{ID: newBackupGroupID(), Backups: make([]upcloud.Storage, len(storages))}
+	title := r.Title
+	if title == "" {
+		title = "backup group " + group.ID
+	}
+	labels := []upcloud.Label{{Key: BackupGroupLabel, Value: group.ID}}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(storages))
+	for i, uuid := range storages {
+		wg.Add(1)
+		go func(i int, uuid string) {
+			defer wg.Done()
+			op, err := s.CreateBackupOperation(ctx, &request.CreateBackupRequest{UUID: uuid, Title: title})
+			if err != nil {
+				errs[i] = fmt.Errorf("backing up storage %s: %w", uuid, err)
+				return
+			}
+			group.Backups[i] = op.Details.Storage
+			// The backup can not be modified while it is in maintenance
+			backup, err := op.Wait(ctx)
+			if err != nil {
+				errs[i] = fmt.Errorf("waiting for backup %s: %w", op.Details.UUID, err)
+				return
+			}
+			group.Backups[i] = backup.Storage
+			if _, err := s.ModifyStorage(ctx, &request.ModifyStorageRequest{UUID: backup.UUID, Labels: &labels}); err != nil {
+				errs[i] = fmt.Errorf("labeling backup %s: %w", backup.UUID, err)
+				return
+			}
+			group.Backups[i].Labels = labels
+		}(i, uuid)
+	}
+	wg.Wait()
+	group.Backups = slices.DeleteFunc(group.Backups, func(b upcloud.Storage) bool { return b.UUID == "" })
+	return group, errors.Join(errs...)
+}
+
+// backupGroupStorages returns the UUIDs of the disks of the servers selected by the request, each UUID once
+func (s *Service) backupGroupStorages(ctx context.Context, r *request.CreateBackupGroupRequest) ([]string, error) {
+	servers := []string{r.ServerUUID}
+	if r.Tag != "" {
+		all, err := s.GetServers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		servers = servers[:0]
+		for _, server := range all.Servers {
+			if slices.Contains(server.Tags, r.Tag) {
+				servers = append(servers, server.UUID)
+			}
+		}
+	}
+
+	var storages []string
+	for _, uuid := range servers {
+		details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: uuid})
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range details.StorageDevices {
+			if device.IsDisk() && !slices.Contains(storages, device.UUID) {
+				storages = append(storages, device.UUID)
+			}
+		}
+	}
+	return storages, nil
+}
+
+// GetBackupGroup returns the backups labeled with the group ID
+func (s *Service) GetBackupGroup(ctx context.Context, r *request.GetBackupGroupRequest) (*upcloud.BackupGroup, error) {
+	storages, err := s.GetStorages(ctx, &request.GetStoragesRequest{
+		Type:    upcloud.StorageTypeBackup,
+		Filters: []request.QueryFilter{request.FilterLabel{Label: upcloud.Label{Key: BackupGroupLabel, Value: r.ID}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &upcloud.BackupGroup{ID: r.ID, Backups: storages.Storages}, nil
+}
+
+// RestoreBackupGroup restores each backup of the group to its origin storage. The servers of the storages need to be
+// stopped.
+func (s *Service) RestoreBackupGroup(ctx context.Context, r *request.RestoreBackupGroupRequest) error {
+	group, err := s.GetBackupGroup(ctx, &request.GetBackupGroupRequest{ID: r.ID})
+	if err != nil {
+		return err
+	}
+	if len(group.Backups) == 0 {
+		return fmt.Errorf("backup group %s has no backups", r.ID)
+	}
+	for _, backup := range group.Backups {
+		if err := s.RestoreBackup(ctx, &request.RestoreBackupRequest{UUID: backup.UUID}); err != nil {
+			return fmt.Errorf("restoring backup %s to storage %s: %w", backup.UUID, backup.Origin, err)
+		}
+	}
+	return nil
+}
+
+// DeleteBackupGroup deletes all backups of the group
+func (s *Service) DeleteBackupGroup(ctx context.Context, r *request.DeleteBackupGroupRequest) error {
+	group, err := s.GetBackupGroup(ctx, &request.GetBackupGroupRequest{ID: r.ID})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, backup := range group.Backups {
+		if err := s.DeleteStorage(ctx, &request.DeleteStorageRequest{UUID: backup.UUID}); err != nil {
+			errs = append(errs, fmt.Errorf("deleting backup %s: %w", backup.UUID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetBackups returns the backups selected by the request ordered from the newest to the oldest, so that, for example,
+// the latest backup of the disk attached at virtio:0 of a server is found with
+//
+//	backups, err := svc.GetBackups(ctx, &request.GetBackupsRequest{ServerUUID: uuid, Address: "virtio:0"})
+//	latest := backups.Latest()
+//
+// Backups listed by server link to the address the origin storage is attached to.
+func (s *Service) GetBackups(ctx context.Context, r *request.GetBackupsRequest) (*upcloud.Backups, error) {
+	if r.Address != "" && r.ServerUUID == "" {
+		return nil, errors.New("address requires server UUID")
+	}
+
+	var devices map[string]upcloud.ServerStorageDevice
+	if r.ServerUUID != "" {
+		server, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+		if err != nil {
+			return nil, err
+		}
+		devices = make(map[string]upcloud.ServerStorageDevice)
+		for _, device := range server.StorageDevices {
+			if device.IsDisk() && (r.Address == "" || device.Address == r.Address) {
+				devices[device.UUID] = device
+			}
+		}
+		if len(devices) == 0 {
+			return &upcloud.Backups{}, nil
+		}
+	}
+
+	storages, err := s.GetStorages(ctx, &request.GetStoragesRequest{Type: upcloud.StorageTypeBackup, Zone: r.Zone})
+	if err != nil {
+		return nil, err
+	}
+	backups := &upcloud.Backups{}
+	for _, storage := range storages.Storages {
+		if r.OriginUUID != "" && storage.Origin != r.OriginUUID {
+			continue
+		}
+		if !r.CreatedAfter.IsZero() && !storage.Created.After(r.CreatedAfter) {
+			continue
+		}
+		if !r.CreatedBefore.IsZero() && !storage.Created.Before(r.CreatedBefore) {
+			continue
+		}
+		origin := upcloud.BackupOrigin{StorageUUID: storage.Origin}
+		if devices != nil {
+			device, ok := devices[storage.Origin]
+			if !ok {
+				continue
+			}
+			origin.ServerUUID = r.ServerUUID
+			origin.Address = device.Address
+		}
+		backups.Backups = append(backups.Backups, upcloud.Backup{Storage: storage, Origin: origin})
+	}
+	slices.SortStableFunc(backups.Backups, func(a, b upcloud.Backup) int {
+		return b.Created.Compare(a.Created)
+	})
+	return backups, nil
+}
+
+func newBackupGroupID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightModifyServer(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","simple_backup":"no","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","type":"disk"},
+		{"address":"virtio:1","storage":"disk-2","type":"disk"},
+		{"address":"ide:0:0","storage":"cdrom-1","type":"cdrom"}
+	]}}}`)
+	m.On(http.MethodGet, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1","backup_rule":{"interval":"daily","time":"0430","retention":"7"}}}`)
+	m.On(http.MethodGet, "/storage/disk-2").Reply(http.StatusOK, `{"storage":{"uuid":"disk-2"}}`)
+
+	require.NoError(t, svc.PreflightModifyServer(context.Background(), &request.ModifyServerRequest{UUID: "uuid", SimpleBackup: request.SimpleBackupDisabled}))
+	assert.Empty(t, m.Calls())
+
+	err := svc.PreflightModifyServer(context.Background(), &request.ModifyServerRequest{UUID: "uuid", SimpleBackup: "0430,dailies"})
+	var preflightErr *PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, []string{"storage disk-1 at virtio:0 has a backup rule, which can not be combined with simple backup"}, preflightErr.Violations)
+}
+
+func TestPreflightModifyStorage(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1","servers":{"server":["uuid"]}}}`)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","simple_backup":"0430,dailies"}}`)
+
+	err := svc.PreflightModifyStorage(context.Background(), &request.ModifyStorageRequest{
+		UUID:       "disk-1",
+		BackupRule: &upcloud.BackupRule{Interval: "daily", Time: "0430", Retention: 7},
+	})
+	var preflightErr *PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, []string{"server uuid has simple backup enabled, which can not be combined with backup rules"}, preflightErr.Violations)
+
+	// Removing the backup rule does not conflict
+	require.NoError(t, svc.PreflightModifyStorage(context.Background(), &request.ModifyStorageRequest{UUID: "disk-1", BackupRule: &upcloud.BackupRule{}}))
+}
+
+func TestMigrateToSimpleBackup(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","simple_backup":"no","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","type":"disk"},
+		{"address":"virtio:1","storage":"disk-2","type":"disk"}
+	]}}}`)
+	m.On(http.MethodGet, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1","backup_rule":{"interval":"daily","time":"0430","retention":"7"}}}`)
+	m.On(http.MethodGet, "/storage/disk-2").Reply(http.StatusOK, `{"storage":{"uuid":"disk-2"}}`)
+	m.On(http.MethodPut, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1"}}`)
+	m.On(http.MethodPut, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","simple_backup":"0430,dailies"}}`)
+
+	server, err := svc.MigrateToSimpleBackup(context.Background(), &request.MigrateToSimpleBackupRequest{ServerUUID: "uuid", SimpleBackup: "0430,dailies"})
+	require.NoError(t, err)
+	assert.True(t, server.SimpleBackupEnabled())
+
+	calls := m.Calls()
+	require.Len(t, calls, 5)
+	assert.Equal(t, "/storage/disk-1", calls[3].Path)
+	assert.JSONEq(t, `{"storage":{"backup_rule":{}}}`, string(calls[3].Body))
+	assert.Equal(t, "/server/uuid", calls[4].Path)
+	assert.JSONEq(t, `{"server":{"simple_backup":"0430,dailies"}}`, string(calls[4].Body))
+}
+
+func TestMigrateToBackupRules(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodPut, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","simple_backup":"no","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","type":"disk"},
+		{"address":"ide:0:0","storage":"cdrom-1","type":"cdrom"}
+	]}}}`)
+	m.On(http.MethodPut, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1"}}`)
+
+	_, err := svc.MigrateToBackupRules(context.Background(), &request.MigrateToBackupRulesRequest{
+		ServerUUID: "uuid",
+		BackupRule: upcloud.BackupRule{Interval: "daily", Time: "0430", Retention: 7},
+	})
+	require.NoError(t, err)
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.JSONEq(t, `{"server":{"simple_backup":"no"}}`, string(calls[0].Body))
+	assert.JSONEq(t, `{"storage":{"backup_rule":{"interval":"daily","time":"0430","retention":"7"}}}`, string(calls[1].Body))
+}
+
+func TestBackupGroup(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[
+		{"uuid":"web","tags":{"tag":["prod"]}},
+		{"uuid":"db","tags":{"tag":["prod"]}},
+		{"uuid":"dev","tags":{"tag":["dev"]}}
+	]}}`)
+	// The shared disk is backed up once
+	m.On(http.MethodGet, "/server/db").Reply(http.StatusOK, `{"server":{"uuid":"db","storage_devices":{"storage_device":[
+		{"storage":"disk-2","type":"disk"}
+	]}}}`)
+	m.On(http.MethodGet, "/server/web").Reply(http.StatusOK, `{"server":{"uuid":"web","storage_devices":{"storage_device":[
+		{"storage":"disk-1","type":"disk"},
+		{"storage":"cdrom-1","type":"cdrom"},
+		{"storage":"disk-2","type":"disk"}
+	]}}}`)
+	m.On(http.MethodPost, "/storage/disk-1/backup").Reply(http.StatusCreated, `{"storage":{"uuid":"backup-1","origin":"disk-1","type":"backup"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/backup").Reply(http.StatusCreated, `{"storage":{"uuid":"backup-2","origin":"disk-2","type":"backup"}}`)
+	for _, uuid := range []string{"backup-1", "backup-2"} {
+		m.On(http.MethodGet, "/storage/"+uuid).Reply(http.StatusOK, `{"storage":{"uuid":"`+uuid+`","state":"maintenance"}}`).Once()
+		m.On(http.MethodGet, "/storage/"+uuid).Reply(http.StatusOK, `{"storage":{"uuid":"`+uuid+`","state":"online"}}`)
+	}
+	m.On(http.MethodPut, "/storage/backup-1").Reply(http.StatusAccepted, `{"storage":{"uuid":"backup-1"}}`)
+	m.On(http.MethodPut, "/storage/backup-2").Reply(http.StatusAccepted, `{"storage":{"uuid":"backup-2"}}`)
+
+	group, err := svc.CreateBackupGroup(context.Background(), &request.CreateBackupGroupRequest{Tag: "prod", Title: "nightly"})
+	require.NoError(t, err)
+	require.Len(t, group.Backups, 2)
+	assert.Equal(t, 1, m.Called(http.MethodPost, "/storage/disk-2/backup"))
+	assert.Len(t, group.ID, 16)
+	assert.Equal(t, "backup-1", group.Backups[0].UUID)
+	assert.Equal(t, upcloud.StorageStateOnline, group.Backups[1].State)
+	assert.Equal(t, []upcloud.Label{{Key: BackupGroupLabel, Value: group.ID}}, group.Backups[1].Labels)
+	// Each backup is labeled only after it has gone from maintenance to online
+	for _, uuid := range []string{"backup-1", "backup-2"} {
+		var states []string
+		for _, call := range m.Calls() {
+			if call.Path != "/storage/"+uuid {
+				continue
+			}
+			switch call.Method {
+			case http.MethodGet:
+				states = append(states, "get")
+			case http.MethodPut:
+				states = append(states, "label")
+			}
+		}
+		assert.Equal(t, []string{"get", "get", "label"}, states, uuid)
+	}
+	for _, call := range m.Calls() {
+		switch call.Method {
+		case http.MethodPost:
+			assert.JSONEq(t, `{"storage":{"title":"nightly"}}`, string(call.Body))
+		case http.MethodPut:
+			assert.JSONEq(t, `{"storage":{"labels":[{"key":"backup_group","value":"`+group.ID+`"}]}}`, string(call.Body))
+		}
+	}
+
+	_, err = svc.CreateBackupGroup(context.Background(), &request.CreateBackupGroupRequest{ServerUUID: "web", Tag: "prod"})
+	assert.EqualError(t, err, "either server UUID or tag is required")
+}
+
+func TestBackupGroup_restoreAndDelete(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/backup?label=backup_group%3Dgroup").Reply(http.StatusOK, `{"storages":{"storage":[
+		{"uuid":"backup-1","origin":"disk-1","type":"backup"},
+		{"uuid":"backup-2","origin":"disk-2","type":"backup"}
+	]}}`)
+	m.On(http.MethodPost, "/storage/backup-1/restore").Reply(http.StatusNoContent, "")
+	m.On(http.MethodPost, "/storage/backup-2/restore").Reply(http.StatusNoContent, "")
+	m.On(http.MethodDelete, "/storage/backup-1").Reply(http.StatusNoContent, "")
+	m.On(http.MethodDelete, "/storage/backup-2").ReplyError(http.StatusConflict, upcloud.ErrCodeStorageStateIllegal, "storage is busy")
+
+	require.NoError(t, svc.RestoreBackupGroup(context.Background(), &request.RestoreBackupGroupRequest{ID: "group"}))
+
+	err := svc.DeleteBackupGroup(context.Background(), &request.DeleteBackupGroupRequest{ID: "group"})
+	assert.ErrorContains(t, err, "deleting backup backup-2")
+	m.AssertExpectations(t)
+}
+
+func TestGetBackups(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/backup").Reply(http.StatusOK, `{"storages":{"storage":[
+		{"uuid":"backup-1","origin":"disk-1","type":"backup","created":"2026-10-01T04:30:00Z"},
+		{"uuid":"backup-2","origin":"disk-2","type":"backup","created":"2026-10-02T04:30:00Z"},
+		{"uuid":"backup-3","origin":"disk-1","type":"backup","created":"2026-10-03T04:30:00Z"},
+		{"uuid":"backup-4","origin":"disk-3","type":"backup","created":"2026-10-04T04:30:00Z"}
+	]}}`)
+	m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, `{"server":{"uuid":"server","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","type":"disk"},
+		{"address":"virtio:1","storage":"disk-2","type":"disk"},
+		{"address":"ide:0:0","storage":"cdrom","type":"cdrom"}
+	]}}}`)
+
+	uuids := func(backups *upcloud.Backups) []string {
+		var uuids []string
+		for _, backup := range backups.Backups {
+			uuids = append(uuids, backup.UUID)
+		}
+		return uuids
+	}
+
+	backups, err := svc.GetBackups(context.Background(), &request.GetBackupsRequest{ServerUUID: "server", Address: "virtio:0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backup-3", "backup-1"}, uuids(backups))
+	assert.Equal(t, upcloud.BackupOrigin{StorageUUID: "disk-1", ServerUUID: "server", Address: "virtio:0"}, backups.Latest().Origin)
+
+	backups, err = svc.GetBackups(context.Background(), &request.GetBackupsRequest{ServerUUID: "server"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backup-3", "backup-2", "backup-1"}, uuids(backups))
+
+	backups, err = svc.GetBackups(context.Background(), &request.GetBackupsRequest{
+		OriginUUID:    "disk-1",
+		CreatedAfter:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backup-3"}, uuids(backups))
+	assert.Equal(t, upcloud.BackupOrigin{StorageUUID: "disk-1"}, backups.Latest().Origin)
+
+	backups, err = svc.GetBackups(context.Background(), &request.GetBackupsRequest{ServerUUID: "server", Address: "virtio:9"})
+	require.NoError(t, err)
+	assert.Nil(t, backups.Latest())
+
+	_, err = svc.GetBackups(context.Background(), &request.GetBackupsRequest{Address: "virtio:0"})
+	assert.Error(t, err)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// LoadCDROM loads a storage as a CD-ROM in the CD-ROM device of a server. If the server is in maintenance state, the
+// operation is retried once the maintenance is over, and the server details are returned after it.
+func (s *Service) LoadCDROM(ctx context.Context, r *request.LoadCDROMRequest) (*upcloud.ServerDetails, error) {
+	return s.waitOutMaintenance(ctx, r.ServerUUID, func() (*upcloud.ServerDetails, error) {
+		serverDetails := upcloud.ServerDetails{}
+		return &serverDetails, s.create(ctx, r, &serverDetails)
+	})
+}
+
+// EjectCDROM ejects the storage from the CD-ROM device of a server. If the server is in maintenance state, the
+// operation is retried once the maintenance is over, and the server details are returned after it.
+func (s *Service) EjectCDROM(ctx context.Context, r *request.EjectCDROMRequest) (*upcloud.ServerDetails, error) {
+	return s.waitOutMaintenance(ctx, r.ServerUUID, func() (*upcloud.ServerDetails, error) {
+		serverDetails := upcloud.ServerDetails{}
+		return &serverDetails, s.create(ctx, r, &serverDetails)
+	})
+}
+
+// GetCDROMs returns the public CD-ROM storages with their names and versions parsed from the titles. Use
+// CDROMs.Latest to find the newest version of a CD-ROM, e.g. "SystemRescueCd", without hardcoding its UUID.
+func (s *Service) GetCDROMs(ctx context.Context) (upcloud.CDROMs, error) {
+	storages, err := s.GetStorages(ctx, &request.GetStoragesRequest{
+		Access: upcloud.StorageAccessPublic,
+		Type:   upcloud.StorageTypeCDROM,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cdroms := make(upcloud.CDROMs, 0, len(storages.Storages))
+	for _, storage := range storages.Storages {
+		cdroms = append(cdroms, upcloud.NewCDROM(storage))
+	}
+	return cdroms, nil
+}
+
+// LoadCDROMByTitle loads the public CD-ROM storage with the given title, e.g. "SystemRescueCd 10.01", in the CD-ROM
+// device of a server. The title is matched case-insensitively.
+func (s *Service) LoadCDROMByTitle(ctx context.Context, r *request.LoadCDROMByTitleRequest) (*upcloud.ServerDetails, error) {
+	cdroms, err := s.GetCDROMs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []upcloud.CDROM
+	for _, cdrom := range cdroms {
+		if strings.EqualFold(cdrom.Title, r.Title) {
+			matches = append(matches, cdrom)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("public CD-ROM %q not found", r.Title)
+	case 1:
+	default:
+		return nil, fmt.Errorf("public CD-ROM title %q matches %d storages", r.Title, len(matches))
+	}
+
+	return s.LoadCDROM(ctx, &request.LoadCDROMRequest{
+		ServerUUID:  r.ServerUUID,
+		StorageUUID: matches[0].UUID,
+	})
+}
+
+// waitOutMaintenance runs the server operation and waits until the server has left maintenance state. If the
+// operation fails because the server is in maintenance state, the operation is retried after the maintenance.
+func (s *Service) waitOutMaintenance(ctx context.Context, serverUUID string, op func() (*upcloud.ServerDetails, error)) (*upcloud.ServerDetails, error) {
+	details, err := op()
+	var problem *upcloud.Problem
+	if errors.As(err, &problem) && problem.ErrorCode() == upcloud.ErrCodeServerStateIllegal {
+		current, getErr := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: serverUUID})
+		if getErr != nil || current.State != upcloud.ServerStateMaintenance {
+			return nil, err
+		}
+		if _, err := s.waitForServerMaintenance(ctx, serverUUID); err != nil {
+			return nil, err
+		}
+		details, err = op()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if details.State == upcloud.ServerStateMaintenance {
+		return s.waitForServerMaintenance(ctx, serverUUID)
+	}
+	return details, nil
+}
+
+func (s *Service) waitForServerMaintenance(ctx context.Context, serverUUID string) (*upcloud.ServerDetails, error) {
+	return s.WaitForServerState(ctx, &request.WaitForServerStateRequest{
+		UUID:           serverUUID,
+		UndesiredState: upcloud.ServerStateMaintenance,
+	})
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+const testCDROMs = `{"storages":{"storage":[
+	{"access":"public","title":"SystemRescueCd 10.01","type":"cdrom","uuid":"01000000-0000-4000-8000-000030060101"},
+	{"access":"public","title":"Debian 12 netinst","type":"cdrom","uuid":"01000000-0000-4000-8000-000020070101"},
+	{"access":"public","title":"Duplicate","type":"cdrom","uuid":"dup-1"},
+	{"access":"public","title":"duplicate","type":"cdrom","uuid":"dup-2"}
+]}}`
+
+func TestLoadCDROM_maintenance(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/server/uuid/cdrom/load").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`).Once()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+
+	details, err := svc.LoadCDROM(context.Background(), &request.LoadCDROMRequest{ServerUUID: "uuid", StorageUUID: "cdrom"})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStopped, details.State)
+	assert.Equal(t, 2, m.Called(http.MethodGet, "/server/uuid"))
+}
+
+func TestEjectCDROM_retryAfterMaintenance(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/server/uuid/cdrom/eject").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "server is in maintenance").Once()
+	m.On(http.MethodPost, "/server/uuid/cdrom/eject").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`).Times = 2
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+
+	details, err := svc.EjectCDROM(context.Background(), &request.EjectCDROMRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStopped, details.State)
+	assert.Equal(t, 2, m.Called(http.MethodPost, "/server/uuid/cdrom/eject"))
+}
+
+func TestEjectCDROM_stateIllegal(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodPost, "/server/uuid/cdrom/eject").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "server is started")
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"started"}}`)
+
+	_, err := svc.EjectCDROM(context.Background(), &request.EjectCDROMRequest{ServerUUID: "uuid"})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, upcloud.ErrCodeServerStateIllegal, problem.ErrorCode())
+	assert.Equal(t, 1, m.Called(http.MethodPost, "/server/uuid/cdrom/eject"))
+}
+
+func TestLoadCDROMByTitle(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/public/cdrom").Reply(http.StatusOK, testCDROMs)
+	m.On(http.MethodPost, "/server/uuid/cdrom/load").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+
+	_, err := svc.LoadCDROMByTitle(context.Background(), &request.LoadCDROMByTitleRequest{ServerUUID: "uuid", Title: "systemrescuecd 10.01"})
+	require.NoError(t, err)
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "/server/uuid/cdrom/load", calls[1].Path)
+	assert.JSONEq(t, `{"storage_device":{"storage":"01000000-0000-4000-8000-000030060101"}}`, string(calls[1].Body))
+
+	_, err = svc.LoadCDROMByTitle(context.Background(), &request.LoadCDROMByTitleRequest{ServerUUID: "uuid", Title: "Windows"})
+	assert.ErrorContains(t, err, "not found")
+	_, err = svc.LoadCDROMByTitle(context.Background(), &request.LoadCDROMByTitleRequest{ServerUUID: "uuid", Title: "Duplicate"})
+	assert.ErrorContains(t, err, "matches 2 storages")
+}
+
+func TestGetCDROMs(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/public/cdrom").Reply(http.StatusOK, testCDROMs)
+
+	cdroms, err := svc.GetCDROMs(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, cdroms, 4)
+
+	latest := cdroms.Latest("SystemRescueCd")
+	require.NotNil(t, latest)
+	assert.Equal(t, "01000000-0000-4000-8000-000030060101", latest.UUID)
+	assert.Equal(t, "10.01", latest.Version)
+	assert.Equal(t, "Debian", cdroms[1].Name)
+}
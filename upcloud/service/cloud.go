@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 )
@@ -11,6 +14,16 @@ type Cloud interface {
 	GetPriceZones(ctx context.Context) (*upcloud.PriceZones, error)
 	GetTimeZones(ctx context.Context) (*upcloud.TimeZones, error)
 	GetPlans(ctx context.Context) (*upcloud.Plans, error)
+	GetZoneCapabilities(ctx context.Context) ([]upcloud.ZoneCapabilities, error)
+}
+
+// zoneCapabilitiesTTL defines how long zone capability report is cached by the service
+const zoneCapabilitiesTTL = time.Hour
+
+type zoneCapabilitiesCache struct {
+	mu      sync.Mutex
+	value   []upcloud.ZoneCapabilities
+	expires time.Time
 }
 
 // GetZones returns the available zones
@@ -36,3 +49,76 @@ func (s *Service) GetPlans(ctx context.Context) (*upcloud.Plans, error) {
 	plans := upcloud.Plans{}
 	return &plans, s.get(ctx, "/plan", &plans)
 }
+
+// GetZoneCapabilities returns a report of plans, storage tiers and features available in each zone. The report is
+// combined from zone, price and plan listings and cached for an hour to make it cheap to consult repeatedly. The
+// listings are fetched without holding the cache lock, so concurrent callers of a cold cache may each fetch them.
+// The returned report is a copy that the caller is free to modify.
+func (s *Service) GetZoneCapabilities(ctx context.Context) ([]upcloud.ZoneCapabilities, error) {
+	if capabilities, ok := s.zoneCapabilities.get(); ok {
+		return capabilities, nil
+	}
+
+	zones, err := s.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	priceZones, err := s.GetPriceZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.GetPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]upcloud.PriceZone)
+	for _, p := range priceZones.PriceZones {
+		prices[p.Name] = p
+	}
+
+	capabilities := make([]upcloud.ZoneCapabilities, 0, len(zones.Zones))
+	for _, zone := range zones.Zones {
+		// Private zones might not have a price listing of their own, fall back to the parent zone
+		price, ok := prices[zone.ID]
+		if !ok && zone.ParentZone != "" {
+			price = prices[zone.ParentZone]
+		}
+		capabilities = append(capabilities, upcloud.NewZoneCapabilities(zone, price, plans.Plans))
+	}
+
+	s.zoneCapabilities.set(capabilities)
+	return cloneZoneCapabilities(capabilities), nil
+}
+
+// get returns a copy of the cached report if it has not expired
+func (c *zoneCapabilitiesCache) get() ([]upcloud.ZoneCapabilities, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.value == nil || !time.Now().Before(c.expires) {
+		return nil, false
+	}
+	return cloneZoneCapabilities(c.value), true
+}
+
+func (c *zoneCapabilitiesCache) set(capabilities []upcloud.ZoneCapabilities) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value = capabilities
+	c.expires = time.Now().Add(zoneCapabilitiesTTL)
+}
+
+func cloneZoneCapabilities(capabilities []upcloud.ZoneCapabilities) []upcloud.ZoneCapabilities {
+	clone := make([]upcloud.ZoneCapabilities, len(capabilities))
+	for i, c := range capabilities {
+		c.Plans = slices.Clone(c.Plans)
+		c.StorageTiers = slices.Clone(c.StorageTiers)
+		c.Features = slices.Clone(c.Features)
+		clone[i] = c
+	}
+	return clone
+}
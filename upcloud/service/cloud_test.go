@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/dnaeon/go-vcr/recorder"
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 )
 
 const testFiHel1Zone string = "fi-hel1"
@@ -99,3 +102,43 @@ func TestGetPlans(t *testing.T) {
 		assert.Equal(t, upcloud.StorageTierMaxIOPS, plan.StorageTier)
 	})
 }
+
+// TestGetZoneCapabilities tests that zone capability report is combined from zone, price and plan listings and cached
+func TestGetZoneCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	srv, svc := setupTestServerAndService(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case fmt.Sprintf("/%s/zone", client.APIVersion):
+			_, _ = fmt.Fprint(w, `{"zones":{"zone":[{"id":"fi-hel1","description":"Helsinki #1","public":"yes"},{"id":"fi-priv1","description":"Private","public":"no","parent_zone":"fi-hel1"}]}}`)
+		case fmt.Sprintf("/%s/price", client.APIVersion):
+			_, _ = fmt.Fprint(w, `{"prices":{"zone":[{"name":"fi-hel1","firewall":{"amount":1,"price":0.56},"storage_maxiops":{"amount":1,"price":0.028},"storage_backup":{"amount":1,"price":0.007},"server_plan_1xCPU-1GB":{"amount":1,"price":0.744},"server_plan_DEV-1xCPU-1GB":{"amount":1,"price":0.5}}]}}`)
+		case fmt.Sprintf("/%s/plan", client.APIVersion):
+			_, _ = fmt.Fprint(w, `{"plans":{"plan":[{"name":"1xCPU-1GB","core_number":1,"memory_amount":1024}]}}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	capabilities, err := svc.GetZoneCapabilities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, capabilities, 2)
+	assert.Equal(t, "fi-hel1", capabilities[0].Zone.ID)
+	assert.Equal(t, []string{"1xCPU-1GB"}, capabilities[0].Plans)
+	assert.Equal(t, []string{upcloud.StorageTierMaxIOPS}, capabilities[0].StorageTiers)
+	assert.True(t, capabilities[0].HasFeature(upcloud.ZoneFeatureFirewall))
+	assert.True(t, capabilities[0].HasFeature(upcloud.ZoneFeatureBackup))
+	assert.False(t, capabilities[0].HasFeature(upcloud.ZoneFeaturePrivateNetwork))
+	assert.True(t, capabilities[1].HasPlan("1xCPU-1GB"))
+	assert.Equal(t, 3, requests)
+
+	capabilities[0].Plans[0] = "modified"
+	capabilities, err = svc.GetZoneCapabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, []string{"1xCPU-1GB"}, capabilities[0].Plans)
+}
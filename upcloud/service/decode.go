@@ -0,0 +1,140 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+)
+
+// UnknownFieldsError is returned in strict decoding mode when the API response contains fields that are not
+// modelled by the SDK types.
+type UnknownFieldsError struct {
+	Location string
+	Fields   []string
+}
+
+// Error implements the error interface
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("response from %s contains unknown fields: %s", e.Location, strings.Join(e.Fields, ", "))
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns paths of the object keys in data that would be dropped when unmarshalling data into v. The
+// document is decoded with codec, so that it is read the same way as the response itself.
+//
+// Most SDK types implement custom unmarshallers that unwrap the envelope objects used by the API, which means that
+// json.Decoder.DisallowUnknownFields would not have effect on them. Instead, the JSON document is walked alongside
+// the Go type and single key objects are unwrapped when the key does not match a field of a type with a custom
+// unmarshaller, or when the target is a slice.
+func unknownFields(codec client.Codec, data []byte, v interface{}) ([]string, error) {
+	var doc interface{}
+	if err := codec.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0)
+	walkUnknownFields(doc, reflect.TypeOf(v), "", &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func walkUnknownFields(doc interface{}, t reflect.Type, path string, unknown *[]string) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch value := doc.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			if len(value) == 1 && reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+				for key, inner := range value {
+					if _, ok := fields[strings.ToLower(key)]; !ok {
+						walkUnknownFields(inner, t, joinFieldPath(path, key), unknown)
+						return
+					}
+				}
+			}
+			for key, inner := range value {
+				field, ok := fields[strings.ToLower(key)]
+				if !ok {
+					*unknown = append(*unknown, joinFieldPath(path, key))
+					continue
+				}
+				walkUnknownFields(inner, field, joinFieldPath(path, key), unknown)
+			}
+		case reflect.Slice, reflect.Array:
+			if t.Elem().Kind() == reflect.Uint8 {
+				// json.RawMessage or similar
+				return
+			}
+			if len(value) == 1 {
+				for key, inner := range value {
+					walkUnknownFields(inner, t, joinFieldPath(path, key), unknown)
+				}
+			}
+		case reflect.Map:
+			for key, inner := range value {
+				walkUnknownFields(inner, t.Elem(), joinFieldPath(path, key), unknown)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for i, inner := range value {
+			walkUnknownFields(inner, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	}
+}
+
+// jsonFields returns struct fields by their lower-cased JSON names, including fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
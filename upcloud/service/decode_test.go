@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		v    interface{}
+		want []string
+	}{
+		{
+			name: "server details",
+			data: `{"server":{"uuid":"u","hostname":"h","new_field":1,"tags":{"tag":["DEV"]},"ip_addresses":{"ip_address":[{"address":"10.0.0.1","family":"IPv4","new_ip_field":"x"}]},"storage_devices":{"storage_device":[{"storage":"s","address":"virtio:0"}]}}}`,
+			v:    &upcloud.ServerDetails{},
+			want: []string{"server.ip_addresses.ip_address[0].new_ip_field", "server.new_field"},
+		},
+		{
+			name: "servers",
+			data: `{"servers":{"server":[{"uuid":"u","state":"started"}]}}`,
+			v:    &upcloud.Servers{},
+			want: []string{},
+		},
+		{
+			name: "zones",
+			data: `{"zones":{"zone":[{"id":"fi-hel1","description":"Helsinki #1","public":"yes","region":"fi"}]}}`,
+			v:    &upcloud.Zones{},
+			want: []string{"zones.zone[0].region"},
+		},
+		{
+			name: "array response",
+			data: `[{"id":"1.26","version":"v1.26.3","eol":"2024-01-01"}]`,
+			v:    &[]upcloud.KubernetesVersion{},
+			want: []string{"[0].eol"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := unknownFields(client.JSONCodec{}, []byte(test.data), test.v)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","new_field":true}}`)
+	c := client.New("user", "pass", client.WithHTTPClient(m.HTTPClient()))
+
+	var reported []string
+	svc := New(c, WithStrictDecoding(func(location string, fields []string) {
+		assert.Equal(t, "/server/uuid", location)
+		reported = fields
+	}))
+	details, err := svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, "uuid", details.UUID)
+	assert.Equal(t, []string{"server.new_field"}, reported)
+
+	svc = New(c, WithStrictDecoding(nil))
+	_, err = svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	var unknownErr *UnknownFieldsError
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, []string{"server.new_field"}, unknownErr.Fields)
+
+	_, err = New(c).GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,176 @@
+package service
+
+//go:generate go run ../../scripts/gen-service-interceptor
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+)
+
+// Decorator wraps a ServiceAPI to add behaviour to its methods. Decorators are applied with Decorate.
+type Decorator func(next ServiceAPI) ServiceAPI
+
+// Decorate wraps api with the decorators. Earlier decorators are closer to the caller, i.e. they see the method calls
+// first.
+func Decorate(api ServiceAPI, decorators ...Decorator) ServiceAPI {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		api = decorators[i](api)
+	}
+	return api
+}
+
+// Call describes a call of a ServiceAPI method
+type Call struct {
+	// Method is the name of the ServiceAPI method, e.g. "GetServerDetails"
+	Method string
+	// Request is the first argument after the context, usually the request, or nil if the method takes none
+	Request any
+	// Result points to the result of the method, or is nil if the method only returns an error. The result is set
+	// when next returns.
+	Result any
+}
+
+// InterceptFunc is called on each ServiceAPI method call. It calls next to pass the call to the wrapped ServiceAPI.
+type InterceptFunc func(ctx context.Context, call Call, next func(ctx context.Context) error) error
+
+// Intercept returns a decorator that passes all method calls through fn
+func Intercept(fn InterceptFunc) Decorator {
+	return func(next ServiceAPI) ServiceAPI {
+		return &interceptedService{next: next, fn: fn}
+	}
+}
+
+// interceptedService implements ServiceAPI by passing all method calls of next through fn. The methods are generated
+// in intercept_gen.go.
+type interceptedService struct {
+	next ServiceAPI
+	fn   InterceptFunc
+}
+
+// MetricsFunc is called after each ServiceAPI method call with the method name, duration and error
+type MetricsFunc func(ctx context.Context, method string, duration time.Duration, err error)
+
+// MetricsDecorator reports the duration and outcome of each method call to fn
+func MetricsDecorator(fn MetricsFunc) Decorator {
+	return Intercept(func(ctx context.Context, call Call, next func(ctx context.Context) error) error {
+		start := time.Now()
+		err := next(ctx)
+		fn(ctx, call.Method, time.Since(start), err)
+		return err
+	})
+}
+
+// LoggingDecorator logs each method call with its name, duration and error at debug level, or at error level if the
+// call failed. Unlike the WithLogger option, which logs the polls of the waits, the decorator wraps the service.
+func LoggingDecorator(logger *slog.Logger) Decorator {
+	return Intercept(func(ctx context.Context, call Call, next func(ctx context.Context) error) error {
+		start := time.Now()
+		err := next(ctx)
+		attrs := []any{slog.String("method", call.Method), slog.Duration("duration", time.Since(start))}
+		if err != nil {
+			logger.ErrorContext(ctx, "service call failed", append(attrs, slog.Any("error", err))...)
+		} else {
+			logger.DebugContext(ctx, "service call", attrs...)
+		}
+		return err
+	})
+}
+
+// RateLimitDecorator waits for the limiter before each method call. Methods that make several API requests, e.g. the
+// WaitFor methods, take one token per call; use client.WithRateLimiter to limit individual API requests instead.
+func RateLimitDecorator(limiter *client.RateLimiter) Decorator {
+	return Intercept(func(ctx context.Context, _ Call, next func(ctx context.Context) error) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return next(ctx)
+	})
+}
+
+// CacheDecorator caches the results of the Get methods for ttl. Results are cached per request URL, so that the
+// decoded result is reused, whereas client.CachingClient caches the response bodies. Successful calls of the other
+// methods, except the WaitFor methods, invalidate the cached results of the same resources as client.CachingClient
+// would. Cached results are shared between callers and must not be modified.
+func CacheDecorator(ttl time.Duration) Decorator {
+	cache := &resultCache{ttl: ttl, entries: make(map[string]resultCacheEntry)}
+	return Intercept(cache.intercept)
+}
+
+type resultCacheEntry struct {
+	// resource is the resource root of the request URL, or empty if the request has no URL
+	resource string
+	result   reflect.Value
+	expires  time.Time
+}
+
+type resultCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]resultCacheEntry
+}
+
+func (c *resultCache) intercept(ctx context.Context, call Call, next func(ctx context.Context) error) error {
+	url, hasURL := "", call.Request == nil
+	if r, ok := call.Request.(requestable); ok {
+		url, hasURL = r.RequestURL(), true
+	}
+
+	switch {
+	case strings.HasPrefix(call.Method, "WaitFor"):
+		return next(ctx)
+	case !strings.HasPrefix(call.Method, "Get"):
+		err := next(ctx)
+		if err == nil {
+			c.invalidate(url)
+		}
+		return err
+	case !hasURL || call.Result == nil:
+		return next(ctx)
+	}
+
+	key := call.Method + " " + url
+	result := reflect.ValueOf(call.Result).Elem()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		result.Set(entry.result)
+		return nil
+	}
+
+	if err := next(ctx); err != nil {
+		return err
+	}
+	var resource string
+	if url != "" {
+		resource = client.InvalidatedResources(url)[0]
+	}
+	c.mu.Lock()
+	c.entries[key] = resultCacheEntry{resource: resource, result: reflect.ValueOf(result.Interface()), expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return nil
+}
+
+// invalidate removes the cached results affected by a mutation of url. Results of requests without URL, e.g. listing
+// servers with GetServers, can be affected by any mutation.
+func (c *resultCache) invalidate(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if url == "" {
+		clear(c.entries)
+		return
+	}
+	resources := client.InvalidatedResources(url)
+	for key, entry := range c.entries {
+		if entry.resource == "" || slices.Contains(resources, entry.resource) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecorate(t *testing.T) {
+	t.Parallel()
+
+	AssertServiceAPI[*interceptedService]()
+
+	var order []string
+	trace := func(name string) Decorator {
+		return Intercept(func(ctx context.Context, call Call, next func(ctx context.Context) error) error {
+			order = append(order, name+" "+call.Method)
+			return next(ctx)
+		})
+	}
+
+	type metric struct {
+		method string
+		failed bool
+	}
+	var metrics []metric
+	var logs bytes.Buffer
+	m, svc := setupMockTransportAndService()
+	api := Decorate(svc,
+		trace("outer"),
+		MetricsDecorator(func(_ context.Context, method string, _ time.Duration, err error) {
+			metrics = append(metrics, metric{method, err != nil})
+		}),
+		LoggingDecorator(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		RateLimitDecorator(client.NewRateLimiter(1000, 10)),
+		CacheDecorator(time.Minute),
+		trace("inner"),
+	)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid"}}`)
+	m.On(http.MethodGet, "/network/net").Reply(http.StatusOK, `{"network":{"uuid":"net"}}`)
+	m.On(http.MethodPost, "/server/uuid/start").Reply(http.StatusAccepted, `{"server":{"uuid":"uuid"}}`).Once()
+	m.On(http.MethodPost, "/server/uuid/start").ReplyError(http.StatusConflict, "SERVER_STATE_ILLEGAL", "server is started")
+
+	for i := 0; i < 2; i++ {
+		server, err := api.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+		require.NoError(t, err)
+		assert.Equal(t, "uuid", server.UUID)
+	}
+	_, err := api.GetNetworkDetails(context.Background(), &request.GetNetworkDetailsRequest{UUID: "net"})
+	require.NoError(t, err)
+	_, err = api.StartServer(context.Background(), &request.StartServerRequest{UUID: "uuid"})
+	require.NoError(t, err)
+	_, err = api.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	require.NoError(t, err)
+	_, err = api.GetNetworkDetails(context.Background(), &request.GetNetworkDetailsRequest{UUID: "net"})
+	require.NoError(t, err)
+	_, err = api.StartServer(context.Background(), &request.StartServerRequest{UUID: "uuid"})
+	require.Error(t, err)
+
+	// Second server GET is served from the cache. Starting the server invalidates the cached server but not the network.
+	assert.Len(t, m.Calls(), 5)
+	assert.Equal(t, []string{
+		"outer GetServerDetails", "inner GetServerDetails",
+		"outer GetServerDetails",
+		"outer GetNetworkDetails", "inner GetNetworkDetails",
+		"outer StartServer", "inner StartServer",
+		"outer GetServerDetails", "inner GetServerDetails",
+		"outer GetNetworkDetails",
+		"outer StartServer", "inner StartServer",
+	}, order)
+	assert.Equal(t, []metric{
+		{"GetServerDetails", false},
+		{"GetServerDetails", false},
+		{"GetNetworkDetails", false},
+		{"StartServer", false},
+		{"GetServerDetails", false},
+		{"GetNetworkDetails", false},
+		{"StartServer", true},
+	}, metrics)
+	assert.Contains(t, logs.String(), "level=DEBUG msg=\"service call\" method=GetServerDetails")
+	assert.Contains(t, logs.String(), "level=ERROR msg=\"service call failed\" method=StartServer")
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// WithDefaultZone sets the zone used by create requests that do not specify a zone, e.g. CreateServerRequest,
+// CreateStorageRequest and CreateNetworkRequest. The zone is also used by GetNetworksInZone when the request does
+// not specify a zone. Zone set in the request always takes precedence.
+func WithDefaultZone(zone string) ConfigFn {
+	return func(c *config) {
+		c.defaultZone = zone
+	}
+}
+
+// WithDefaultStorageTier sets the tier used by storage create and clone requests, including storage devices created
+// with a server, that do not specify a tier. Tier set in the request always takes precedence.
+func WithDefaultStorageTier(tier string) ConfigFn {
+	return func(c *config) {
+		c.defaultStorageTier = tier
+	}
+}
+
+// WithDefaultTimeout sets the timeout of the WaitFor methods when the context has no deadline.
+func WithDefaultTimeout(timeout time.Duration) ConfigFn {
+	return func(c *config) {
+		c.defaultTimeout = timeout
+	}
+}
+
+// withDefaults returns the request with default values set to empty fields. It is applied to all create requests
+// and to GetNetworksInZoneRequest. Requests are copied before modifying them, so that the caller's request is left
+// intact.
+func (s *Service) withDefaults(r requestable) requestable {
+	zone, tier := s.config.defaultZone, s.config.defaultStorageTier
+	if zone == "" && tier == "" {
+		return r
+	}
+
+	switch v := r.(type) {
+	case *request.CreateServerRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		if tier != "" {
+			c.StorageDevices = make(request.CreateServerStorageDeviceSlice, len(v.StorageDevices))
+			for i, d := range v.StorageDevices {
+				if d.Action != request.CreateServerStorageDeviceActionAttach {
+					d.Tier = defaultString(d.Tier, tier)
+				}
+				c.StorageDevices[i] = d
+			}
+		}
+		return &c
+	case *request.CreateStorageRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		c.Tier = defaultString(c.Tier, tier)
+		return &c
+	case *request.CloneStorageRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		c.Tier = defaultString(c.Tier, tier)
+		return &c
+	case *request.CreateNetworkRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.GetNetworksInZoneRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CreateLoadBalancerRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CreateManagedDatabaseRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CloneManagedDatabaseRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CreateKubernetesClusterRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CreateGatewayRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	case *request.CreateObjectStorageRequest:
+		c := *v
+		c.Zone = defaultString(c.Zone, zone)
+		return &c
+	}
+	return r
+}
+
+// retryConfig returns the configuration used by the WaitFor methods
+func (s *Service) retryConfig() *retryConfig {
+	return &retryConfig{backoff: s.config.backoff, timeout: s.config.defaultTimeout, logger: s.config.logger}
+}
+
+func defaultString(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceDefaults(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithDefaultZone("fi-hel1"), WithDefaultStorageTier(upcloud.StorageTierStandard))
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"uuid"}}`)
+	m.On(http.MethodPost, "/storage").Reply(http.StatusCreated, `{"storage":{"uuid":"uuid"}}`)
+
+	r := &request.CreateServerRequest{
+		Title: "server",
+		StorageDevices: request.CreateServerStorageDeviceSlice{
+			{Action: request.CreateServerStorageDeviceActionClone, Storage: "template"},
+			{Action: request.CreateServerStorageDeviceActionCreate, Tier: upcloud.StorageTierMaxIOPS},
+			{Action: request.CreateServerStorageDeviceActionAttach, Storage: "storage"},
+		},
+	}
+	_, err := svc.CreateServer(context.Background(), r)
+	require.NoError(t, err)
+	_, err = svc.CreateStorage(context.Background(), &request.CreateStorageRequest{Zone: "de-fra1", Title: "storage"})
+	require.NoError(t, err)
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Contains(t, string(calls[0].Body), `"zone":"fi-hel1"`)
+	assert.Contains(t, string(calls[0].Body), `{"action":"clone","storage":"template","tier":"standard"}`)
+	assert.Contains(t, string(calls[0].Body), `{"action":"create","storage":"","tier":"maxiops"}`)
+	assert.Contains(t, string(calls[0].Body), `{"action":"attach","storage":"storage"}`)
+	assert.Contains(t, string(calls[1].Body), `"zone":"de-fra1"`)
+	assert.Contains(t, string(calls[1].Body), `"tier":"standard"`)
+
+	// Caller's request is left intact
+	assert.Empty(t, r.Zone)
+	assert.Empty(t, r.StorageDevices[0].Tier)
+}
+
+// TestServiceDefaults_getNetworksInZone tests that the default zone is also applied to the networks in zone listing
+func TestServiceDefaults_getNetworksInZone(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithDefaultZone("fi-hel1"))
+	m.On(http.MethodGet, "/network/").Reply(http.StatusOK, `{"networks":{"network":[]}}`)
+
+	r := &request.GetNetworksInZoneRequest{}
+	_, err := svc.GetNetworksInZone(context.Background(), r)
+	require.NoError(t, err)
+	_, err = svc.GetNetworksInZone(context.Background(), &request.GetNetworksInZoneRequest{Zone: "de-fra1"})
+	require.NoError(t, err)
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, http.MethodGet, calls[0].Method)
+	assert.Equal(t, "zone=fi-hel1", calls[0].Query)
+	assert.Equal(t, "zone=de-fra1", calls[1].Query)
+	assert.Empty(t, r.Zone)
+}
+
+func TestServiceDefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(
+		WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}),
+		WithDefaultTimeout(50*time.Millisecond),
+	)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`)
+
+	_, err := svc.WaitForServerState(context.Background(), &request.WaitForServerStateRequest{
+		UUID:         "uuid",
+		DesiredState: upcloud.ServerStateStarted,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// Kinds of resources checked by deletion guards
+const (
+	DeletionTargetKindServer  = "server"
+	DeletionTargetKindStorage = "storage"
+)
+
+// ErrDeletionRefused is the error matched by errors.Is when a deletion guard refuses to delete a resource.
+var ErrDeletionRefused = errors.New("deletion refused")
+
+// DeletionTarget describes a resource that is about to be deleted
+type DeletionTarget struct {
+	Kind   string
+	UUID   string
+	Title  string
+	Labels []upcloud.Label
+}
+
+// DeletionGuard checks whether the target may be deleted. A non-nil error refuses the deletion.
+type DeletionGuard func(ctx context.Context, target DeletionTarget) error
+
+// DeletionRefusedError is returned when a deletion guard refuses to delete a resource. No delete request is sent to
+// the API.
+type DeletionRefusedError struct {
+	Target DeletionTarget
+	Err    error
+}
+
+func (e *DeletionRefusedError) Error() string {
+	return fmt.Sprintf("%s of %s %s: %s", ErrDeletionRefused, e.Target.Kind, e.Target.UUID, e.Err)
+}
+
+// Unwrap returns ErrDeletionRefused and the error of the guard
+func (e *DeletionRefusedError) Unwrap() []error {
+	return []error{ErrDeletionRefused, e.Err}
+}
+
+// WithDeletionGuard adds guards that are checked before DeleteServer, DeleteServerAndStorages and DeleteStorage
+// delete anything. Storages deleted along with a server are checked too. The details of the resources are fetched
+// from the API for the guards, and the deletion is refused with *DeletionRefusedError if any guard returns an error.
+// Resources that the service creates and deletes itself, such as the build servers of BuildTemplate and the clones
+// left behind by a failed MigrateServerToZone or ChangeStorageTier, are not checked.
+func WithDeletionGuard(guards ...DeletionGuard) ConfigFn {
+	return func(c *config) {
+		c.deletionGuards = append(c.deletionGuards, guards...)
+	}
+}
+
+// RequireLabel returns a deletion guard that allows deleting only resources labeled with the key and value.
+func RequireLabel(key, value string) DeletionGuard {
+	return func(_ context.Context, target DeletionTarget) error {
+		for _, label := range target.Labels {
+			if label.Key == key && label.Value == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("label %s=%s is missing", key, value)
+	}
+}
+
+// RequireTitlePrefix returns a deletion guard that allows deleting only resources whose title starts with prefix.
+func RequireTitlePrefix(prefix string) DeletionGuard {
+	return func(_ context.Context, target DeletionTarget) error {
+		if !strings.HasPrefix(target.Title, prefix) {
+			return fmt.Errorf("title %q does not start with %q", target.Title, prefix)
+		}
+		return nil
+	}
+}
+
+// RequireConfirmation returns a deletion guard that asks confirm whether the target may be deleted.
+func RequireConfirmation(confirm func(ctx context.Context, target DeletionTarget) bool) DeletionGuard {
+	return func(ctx context.Context, target DeletionTarget) error {
+		if !confirm(ctx, target) {
+			return errors.New("deletion was not confirmed")
+		}
+		return nil
+	}
+}
+
+// checkServerDeletion runs the deletion guards for the server and, if withStorages is set, for its disks.
+func (s *Service) checkServerDeletion(ctx context.Context, uuid string, withStorages bool) error {
+	if len(s.config.deletionGuards) == 0 {
+		return nil
+	}
+	details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: uuid})
+	if err != nil {
+		return err
+	}
+	err = s.checkDeletion(ctx, DeletionTarget{
+		Kind:   DeletionTargetKindServer,
+		UUID:   details.UUID,
+		Title:  details.Title,
+		Labels: details.Labels,
+	})
+	if err != nil || !withStorages {
+		return err
+	}
+	for _, device := range details.StorageDevices {
+		if device.Type != upcloud.StorageTypeDisk {
+			continue
+		}
+		if err := s.checkStorageDeletion(ctx, device.UUID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStorageDeletion runs the deletion guards for the storage.
+func (s *Service) checkStorageDeletion(ctx context.Context, uuid string) error {
+	if len(s.config.deletionGuards) == 0 {
+		return nil
+	}
+	details, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: uuid})
+	if err != nil {
+		return err
+	}
+	return s.checkDeletion(ctx, DeletionTarget{
+		Kind:   DeletionTargetKindStorage,
+		UUID:   details.UUID,
+		Title:  details.Title,
+		Labels: details.Labels,
+	})
+}
+
+func (s *Service) checkDeletion(ctx context.Context, target DeletionTarget) error {
+	for _, guard := range s.config.deletionGuards {
+		if err := guard(ctx, target); err != nil {
+			return &DeletionRefusedError{Target: target, Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deletionTestServer = `{"server":{"uuid":"server","title":"test-server","labels":{"label":[{"key":"env","value":"test"}]},
+	"storage_devices":{"storage_device":[
+		{"storage":"disk-1","type":"disk"},
+		{"storage":"cdrom-1","type":"cdrom"},
+		{"storage":"disk-2","type":"disk"}
+	]}}}`
+
+func TestDeletionGuard(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithDeletionGuard(RequireLabel("env", "test"), RequireTitlePrefix("test-")))
+	m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, deletionTestServer)
+	m.On(http.MethodGet, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1","title":"test-disk","labels":[{"key":"env","value":"test"}]}}`)
+	m.On(http.MethodGet, "/storage/disk-2").Reply(http.StatusOK, `{"storage":{"uuid":"disk-2","title":"prod-disk","labels":[{"key":"env","value":"test"}]}}`)
+	m.On(http.MethodDelete, "/server/server").Reply(http.StatusNoContent, "")
+	m.On(http.MethodDelete, "/storage/disk-1").Reply(http.StatusNoContent, "")
+
+	require.NoError(t, svc.DeleteServer(context.Background(), &request.DeleteServerRequest{UUID: "server"}))
+	require.NoError(t, svc.DeleteStorage(context.Background(), &request.DeleteStorageRequest{UUID: "disk-1"}))
+
+	err := svc.DeleteStorage(context.Background(), &request.DeleteStorageRequest{UUID: "disk-2"})
+	assert.ErrorIs(t, err, ErrDeletionRefused)
+	assert.EqualError(t, err, `deletion refused of storage disk-2: title "prod-disk" does not start with "test-"`)
+
+	err = svc.DeleteServerAndStorages(context.Background(), &request.DeleteServerAndStoragesRequest{UUID: "server"})
+	var refused *DeletionRefusedError
+	require.ErrorAs(t, err, &refused)
+	assert.Equal(t, "disk-2", refused.Target.UUID)
+
+	for _, call := range m.Calls() {
+		if call.Method == http.MethodDelete {
+			assert.NotContains(t, call.Path, "disk-2")
+			assert.Empty(t, call.Query)
+		}
+	}
+}
+
+func TestDeletionGuard_confirmation(t *testing.T) {
+	t.Parallel()
+
+	var confirmed []DeletionTarget
+	m, svc := setupMockTransportAndService(WithDeletionGuard(RequireConfirmation(func(_ context.Context, target DeletionTarget) bool {
+		confirmed = append(confirmed, target)
+		return false
+	})))
+	m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, deletionTestServer)
+
+	err := svc.DeleteServer(context.Background(), &request.DeleteServerRequest{UUID: "server", Storages: true})
+	assert.EqualError(t, err, "deletion refused of server server: deletion was not confirmed")
+	require.Len(t, confirmed, 1)
+	assert.Equal(t, DeletionTargetKindServer, confirmed[0].Kind)
+	assert.Equal(t, "test-server", confirmed[0].Title)
+	assert.Len(t, m.Calls(), 1)
+}
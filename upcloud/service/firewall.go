@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
@@ -13,6 +15,10 @@ type Firewall interface {
 	CreateFirewallRule(ctx context.Context, r *request.CreateFirewallRuleRequest) (*upcloud.FirewallRule, error)
 	CreateFirewallRules(ctx context.Context, r *request.CreateFirewallRulesRequest) error
 	DeleteFirewallRule(ctx context.Context, r *request.DeleteFirewallRuleRequest) error
+	BootstrapFirewall(ctx context.Context, r *request.BootstrapFirewallRequest) ([]upcloud.FirewallRule, error)
+	ExportFirewallRules(ctx context.Context, r *request.GetFirewallRulesRequest) (*upcloud.FirewallRuleset, error)
+	ImportFirewallRules(ctx context.Context, r *request.ImportFirewallRulesRequest) error
+	ApplyFirewallRules(ctx context.Context, r *request.ApplyFirewallRulesRequest) (*upcloud.FirewallRulesDiff, error)
 }
 
 // GetFirewallRules returns the firewall rules for the specified server
@@ -27,13 +33,15 @@ func (s *Service) GetFirewallRuleDetails(ctx context.Context, r *request.GetFire
 	return &firewallRule, s.get(ctx, r.RequestURL(), &firewallRule)
 }
 
-// CreateFirewallRule creates the firewall rule
+// CreateFirewallRule creates the firewall rule. Use the Validate method of the request to check the rule before
+// sending it.
 func (s *Service) CreateFirewallRule(ctx context.Context, r *request.CreateFirewallRuleRequest) (*upcloud.FirewallRule, error) {
 	firewallRule := upcloud.FirewallRule{}
 	return &firewallRule, s.create(ctx, r, &firewallRule)
 }
 
-// CreateFirewallRules creates multiple firewall rules
+// CreateFirewallRules creates multiple firewall rules. Use the Validate method of the request to check the rules
+// before sending them.
 func (s *Service) CreateFirewallRules(ctx context.Context, r *request.CreateFirewallRulesRequest) error {
 	return s.replace(ctx, r, nil)
 }
@@ -42,3 +50,119 @@ func (s *Service) CreateFirewallRules(ctx context.Context, r *request.CreateFire
 func (s *Service) DeleteFirewallRule(ctx context.Context, r *request.DeleteFirewallRuleRequest) error {
 	return s.delete(ctx, r)
 }
+
+// BootstrapFirewall replaces the firewall rules of the server with a default-deny baseline in a single request and
+// enables the server firewall. The applied rules are returned.
+func (s *Service) BootstrapFirewall(ctx context.Context, r *request.BootstrapFirewallRequest) ([]upcloud.FirewallRule, error) {
+	rules, err := r.FirewallRules()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CreateFirewallRules(ctx, &request.CreateFirewallRulesRequest{
+		ServerUUID:    r.ServerUUID,
+		FirewallRules: rules,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.ModifyServer(ctx, &request.ModifyServerRequest{
+		UUID:     r.ServerUUID,
+		Firewall: "on",
+	}); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ExportFirewallRules returns the firewall rules of the server as a ruleset document
+func (s *Service) ExportFirewallRules(ctx context.Context, r *request.GetFirewallRulesRequest) (*upcloud.FirewallRuleset, error) {
+	rules, err := s.GetFirewallRules(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return upcloud.NewFirewallRuleset(rules.FirewallRules)
+}
+
+// ImportFirewallRules validates the ruleset document and replaces the firewall rules of the server with it in a single
+// request
+func (s *Service) ImportFirewallRules(ctx context.Context, r *request.ImportFirewallRulesRequest) error {
+	if r.Ruleset == nil {
+		return errors.New("firewall ruleset is required")
+	}
+	if err := r.Ruleset.Validate(); err != nil {
+		return err
+	}
+	return s.CreateFirewallRules(ctx, &request.CreateFirewallRulesRequest{
+		ServerUUID:    r.ServerUUID,
+		FirewallRules: r.Ruleset.FirewallRules(),
+	})
+}
+
+// ApplyFirewallRules makes the firewall rules of the server match the desired rules. The rules are keyed by their
+// comments and compared to the live rules. Removed and changed rules are deleted and added and changed rules are
+// created at their desired positions, leaving the unchanged rules in place. The API cannot move rules, so if the
+// order of the kept rules differs, all rules are replaced in a single request instead. The computed diff is returned;
+// an empty diff means that no changes were made.
+func (s *Service) ApplyFirewallRules(ctx context.Context, r *request.ApplyFirewallRulesRequest) (*upcloud.FirewallRulesDiff, error) {
+	live, err := s.GetFirewallRules(ctx, &request.GetFirewallRulesRequest{ServerUUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+	diff, err := upcloud.DiffFirewallRules(live.FirewallRules, r.FirewallRules)
+	if err != nil || diff.Empty() {
+		return diff, err
+	}
+
+	if diff.Reordered {
+		rules := make(request.FirewallRuleSlice, len(r.FirewallRules))
+		for i, rule := range r.FirewallRules {
+			rule.Position = i + 1
+			rules[i] = rule
+		}
+		if err := s.CreateFirewallRules(ctx, &request.CreateFirewallRulesRequest{
+			ServerUUID:    r.ServerUUID,
+			FirewallRules: rules,
+		}); err != nil {
+			return nil, err
+		}
+		return diff, nil
+	}
+
+	recreate := make(map[string]bool, len(diff.Added)+len(diff.Changed))
+	for _, rule := range diff.Added {
+		recreate[rule.Comment] = true
+	}
+	positions := make([]int, 0, len(diff.Removed)+len(diff.Changed))
+	for _, rule := range diff.Removed {
+		positions = append(positions, rule.Position)
+	}
+	for _, rule := range diff.Changed {
+		recreate[rule.Comment] = true
+		for _, current := range live.FirewallRules {
+			if current.Comment == rule.Comment {
+				positions = append(positions, current.Position)
+				break
+			}
+		}
+	}
+
+	// Delete from the last position so that the positions of the remaining rules do not shift
+	slices.Sort(positions)
+	for i := len(positions) - 1; i >= 0; i-- {
+		if err := s.DeleteFirewallRule(ctx, &request.DeleteFirewallRuleRequest{ServerUUID: r.ServerUUID, Position: positions[i]}); err != nil {
+			return nil, err
+		}
+	}
+	for i, rule := range r.FirewallRules {
+		if !recreate[rule.Comment] {
+			continue
+		}
+		rule.Position = i + 1
+		if _, err := s.CreateFirewallRule(ctx, &request.CreateFirewallRuleRequest{ServerUUID: r.ServerUUID, FirewallRule: rule}); err != nil {
+			return nil, err
+		}
+	}
+	return diff, nil
+}
@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -117,3 +120,124 @@ func TestFirewallRules(t *testing.T) {
 		assert.Len(t, firewallRulesPostDelete.FirewallRules, 2)
 	})
 }
+
+// TestCreateFirewallRules_unvalidated tests that rules are sent as is, validation is left to the caller
+func TestCreateFirewallRules_unvalidated(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodPost, "/server/uuid/firewall_rule").Reply(http.StatusCreated, `{"firewall_rule":{}}`)
+
+	r := &request.CreateFirewallRuleRequest{
+		ServerUUID: "uuid",
+		FirewallRule: upcloud.FirewallRule{
+			Action:               upcloud.FirewallRuleActionAccept,
+			Direction:            upcloud.FirewallRuleDirectionIn,
+			DestinationPortStart: "22",
+		},
+	}
+	assert.Error(t, r.Validate())
+	_, err := svc.CreateFirewallRule(context.Background(), r)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Called(http.MethodPost, "/server/uuid/firewall_rule"))
+}
+
+func TestBootstrapFirewall(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodPut, "/server/uuid/firewall_rule").Reply(http.StatusNoContent, "")
+	m.On(http.MethodPut, "/server/uuid").ReplyCorpus(upcloudtest.CorpusServerDetails)
+
+	rules, err := svc.BootstrapFirewall(context.Background(), &request.BootstrapFirewallRequest{
+		ServerUUID: "uuid",
+		AdminCIDRs: []string{"203.0.113.0/24"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.FirewallRuleActionDrop, rules[len(rules)-1].Action)
+	assert.Equal(t, 1, m.Called(http.MethodPut, "/server/uuid/firewall_rule"))
+	assert.Equal(t, 1, m.Called(http.MethodPut, "/server/uuid"))
+}
+
+func TestExportImportFirewallRules(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid/firewall_rule").ReplyCorpus(upcloudtest.CorpusFirewallRules)
+	m.On(http.MethodPut, "/server/other/firewall_rule").Reply(http.StatusNoContent, "")
+
+	rs, err := svc.ExportFirewallRules(context.Background(), &request.GetFirewallRulesRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.FirewallRulesetVersion, rs.Version)
+	assert.NotEmpty(t, rs.Rules)
+
+	err = svc.ImportFirewallRules(context.Background(), &request.ImportFirewallRulesRequest{ServerUUID: "other", Ruleset: rs})
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Called(http.MethodPut, "/server/other/firewall_rule"))
+
+	err = svc.ImportFirewallRules(context.Background(), &request.ImportFirewallRulesRequest{ServerUUID: "other"})
+	assert.Error(t, err)
+}
+
+func TestApplyFirewallRules(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid/firewall_rule").Reply(http.StatusOK, `{"firewall_rules":{"firewall_rule":[
+		{"action":"accept","direction":"in","family":"IPv4","protocol":"tcp","comment":"SSH","destination_port_start":"22","destination_port_end":"22","position":"1"},
+		{"action":"accept","direction":"in","family":"IPv4","protocol":"tcp","comment":"HTTP","destination_port_start":"80","destination_port_end":"80","position":"2"},
+		{"action":"drop","direction":"in","comment":"Drop","position":"3"}
+	]}}`)
+	m.On(http.MethodDelete, "/server/uuid/firewall_rule/2").Reply(http.StatusNoContent, "")
+	m.On(http.MethodPost, "/server/uuid/firewall_rule").Reply(http.StatusCreated, `{"firewall_rule":{}}`)
+	m.On(http.MethodPut, "/server/uuid/firewall_rule").Reply(http.StatusNoContent, "")
+
+	tcp := func(comment, port string) upcloud.FirewallRule {
+		return upcloud.FirewallRule{
+			Action:               upcloud.FirewallRuleActionAccept,
+			Direction:            upcloud.FirewallRuleDirectionIn,
+			Family:               upcloud.IPAddressFamilyIPv4,
+			Protocol:             upcloud.FirewallRuleProtocolTCP,
+			Comment:              comment,
+			DestinationPortStart: port,
+			DestinationPortEnd:   port,
+		}
+	}
+	ssh := tcp("SSH", "22")
+	drop := upcloud.FirewallRule{Action: upcloud.FirewallRuleActionDrop, Direction: upcloud.FirewallRuleDirectionIn, Comment: "Drop"}
+
+	diff, err := svc.ApplyFirewallRules(context.Background(), &request.ApplyFirewallRulesRequest{
+		ServerUUID:    "uuid",
+		FirewallRules: []upcloud.FirewallRule{ssh, tcp("HTTP", "80"), drop},
+	})
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+	assert.Len(t, m.Calls(), 1)
+
+	// HTTP is changed to port 8080 and HTTPS is added after it; SSH and Drop stay in place
+	diff, err = svc.ApplyFirewallRules(context.Background(), &request.ApplyFirewallRulesRequest{
+		ServerUUID:    "uuid",
+		FirewallRules: []upcloud.FirewallRule{ssh, tcp("HTTP", "8080"), tcp("HTTPS", "443"), drop},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []upcloud.FirewallRule{tcp("HTTPS", "443")}, diff.Added)
+	assert.Equal(t, []upcloud.FirewallRule{tcp("HTTP", "8080")}, diff.Changed)
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/server/uuid/firewall_rule/2"))
+	var created []string
+	for _, c := range m.Calls() {
+		if c.Method == http.MethodPost {
+			var rule upcloud.FirewallRule
+			require.NoError(t, json.Unmarshal(c.Body, &rule))
+			created = append(created, fmt.Sprintf("%s@%d", rule.Comment, rule.Position))
+		}
+	}
+	assert.Equal(t, []string{"HTTP@2", "HTTPS@3"}, created)
+	assert.Equal(t, 0, m.Called(http.MethodPut, "/server/uuid/firewall_rule"))
+
+	_, err = svc.ApplyFirewallRules(context.Background(), &request.ApplyFirewallRulesRequest{
+		ServerUUID:    "uuid",
+		FirewallRules: []upcloud.FirewallRule{tcp("HTTP", "80"), ssh, drop},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Called(http.MethodPut, "/server/uuid/firewall_rule"))
+}
@@ -14,6 +14,7 @@ type Gateway interface {
 	CreateGateway(ctx context.Context, r *request.CreateGatewayRequest) (*upcloud.Gateway, error)
 	ModifyGateway(ctx context.Context, r *request.ModifyGatewayRequest) (*upcloud.Gateway, error)
 	DeleteGateway(ctx context.Context, r *request.DeleteGatewayRequest) error
+	WaitForGatewayOperationalState(ctx context.Context, r *request.WaitForGatewayOperationalStateRequest) (*upcloud.Gateway, error)
 
 	GetGatewayConnections(ctx context.Context, r *request.GetGatewayConnectionsRequest) ([]upcloud.GatewayConnection, error)
 	GetGatewayConnection(ctx context.Context, r *request.GetGatewayConnectionRequest) (*upcloud.GatewayConnection, error)
@@ -26,6 +27,7 @@ type Gateway interface {
 	CreateGatewayConnectionTunnel(ctx context.Context, r *request.CreateGatewayConnectionTunnelRequest) (*upcloud.GatewayTunnel, error)
 	// ModifyGatewayConnectionTunnel(ctx context.Context, r *request.ModifyGatewayConnectionTunnelRequest) (*upcloud.GatewayTunnel, error)
 	DeleteGatewayConnectionTunnel(ctx context.Context, r *request.DeleteGatewayConnectionTunnelRequest) error
+	WaitForGatewayConnectionTunnelOperationalState(ctx context.Context, r *request.WaitForGatewayConnectionTunnelOperationalStateRequest) (*upcloud.GatewayTunnel, error)
 }
 
 // GetGatewayPlans retrieves a list of all available plans for network gateway service
@@ -121,3 +123,42 @@ func (s *Service) ModifyGatewayConnectionTunnel(ctx context.Context, r *request.
 func (s *Service) DeleteGatewayConnectionTunnel(ctx context.Context, r *request.DeleteGatewayConnectionTunnelRequest) error {
 	return s.delete(ctx, r)
 }
+
+// WaitForGatewayOperationalState blocks execution until the specified gateway has entered the specified operational
+// state. If the state changes favorably, gateway details is returned. The method will give up after the specified timeout
+func (s *Service) WaitForGatewayOperationalState(ctx context.Context, r *request.WaitForGatewayOperationalStateRequest) (*upcloud.Gateway, error) {
+	return retry(ctx, func(_ int, c context.Context) (*upcloud.Gateway, error) {
+		details, err := s.GetGateway(c, &request.GetGatewayRequest{
+			UUID: r.UUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if details.OperationalState == r.DesiredState {
+			return details, nil
+		}
+		return nil, nil
+	}, s.retryConfig())
+}
+
+// WaitForGatewayConnectionTunnelOperationalState blocks execution until the specified gateway connection tunnel has
+// entered the specified operational state, e.g. established. If the state changes favorably, tunnel details is returned.
+// The method will give up after the specified timeout
+func (s *Service) WaitForGatewayConnectionTunnelOperationalState(ctx context.Context, r *request.WaitForGatewayConnectionTunnelOperationalStateRequest) (*upcloud.GatewayTunnel, error) {
+	return retry(ctx, func(_ int, c context.Context) (*upcloud.GatewayTunnel, error) {
+		details, err := s.GetGatewayConnectionTunnel(c, &request.GetGatewayConnectionTunnelRequest{
+			ServiceUUID:    r.ServiceUUID,
+			ConnectionUUID: r.ConnectionUUID,
+			UUID:           r.UUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if details.OperationalState == r.DesiredState {
+			return details, nil
+		}
+		return nil, nil
+	}, s.retryConfig())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGatewayPlans(t *testing.T) {
@@ -713,3 +714,35 @@ func waitGatewayToDelete(ctx context.Context, rec *recorder.Recorder, svc *Servi
 		time.Sleep(5 * time.Second)
 	}
 }
+
+func TestWaitForGatewayOperationalStates(t *testing.T) {
+	t.Parallel()
+
+	const (
+		gwPath     = "/gateway/0a1b2c3d-0000-4000-8000-000000000001"
+		tunnelPath = gwPath + "/connections/0a1b2c3d-0000-4000-8000-000000000002/tunnels/0a1b2c3d-0000-4000-8000-000000000003"
+	)
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, gwPath).Reply(http.StatusOK, `{"uuid":"0a1b2c3d-0000-4000-8000-000000000001","operational_state":"setup-gw"}`).Once()
+	m.On(http.MethodGet, gwPath).Reply(http.StatusOK, `{"uuid":"0a1b2c3d-0000-4000-8000-000000000001","operational_state":"running"}`)
+	m.On(http.MethodGet, tunnelPath).Reply(http.StatusOK, `{"name":"tunnel","operational_state":"connecting"}`).Times = 2
+	m.On(http.MethodGet, tunnelPath).Reply(http.StatusOK, `{"name":"tunnel","operational_state":"established"}`)
+
+	gw, err := svc.WaitForGatewayOperationalState(context.Background(), &request.WaitForGatewayOperationalStateRequest{
+		UUID:         "0a1b2c3d-0000-4000-8000-000000000001",
+		DesiredState: upcloud.GatewayOperationalStateRunning,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.GatewayOperationalStateRunning, gw.OperationalState)
+	assert.Equal(t, 2, m.Called(http.MethodGet, gwPath))
+
+	tunnel, err := svc.WaitForGatewayConnectionTunnelOperationalState(context.Background(), &request.WaitForGatewayConnectionTunnelOperationalStateRequest{
+		ServiceUUID:    "0a1b2c3d-0000-4000-8000-000000000001",
+		ConnectionUUID: "0a1b2c3d-0000-4000-8000-000000000002",
+		UUID:           "0a1b2c3d-0000-4000-8000-000000000003",
+		DesiredState:   upcloud.GatewayTunnelOperationalStateEstabilished,
+	})
+	require.NoError(t, err)
+	assert.True(t, tunnel.Established())
+	assert.Equal(t, 3, m.Called(http.MethodGet, tunnelPath))
+}
@@ -0,0 +1,2368 @@
+// Code generated by gen-service-interceptor. DO NOT EDIT.
+
+package service
+
+import (
+	"context"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+func (s *interceptedService) AddServerToServerGroup(ctx context.Context, r *request.AddServerToServerGroupRequest) error {
+	return s.fn(ctx, Call{Method: "AddServerToServerGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.AddServerToServerGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) ApplyFirewallRules(ctx context.Context, r *request.ApplyFirewallRulesRequest) (*upcloud.FirewallRulesDiff, error) {
+	var res *upcloud.FirewallRulesDiff
+	err := s.fn(ctx, Call{Method: "ApplyFirewallRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ApplyFirewallRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) AssignIPAddress(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "AssignIPAddress", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.AssignIPAddress(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) AssignPrivateIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "AssignPrivateIPv4", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.AssignPrivateIPv4(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) AssignPublicIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "AssignPublicIPv4", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.AssignPublicIPv4(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) AssignPublicIPv6(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "AssignPublicIPv6", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.AssignPublicIPv6(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) AttachManagedObjectStorageUserPolicy(ctx context.Context, r *request.AttachManagedObjectStorageUserPolicyRequest) error {
+	return s.fn(ctx, Call{Method: "AttachManagedObjectStorageUserPolicy", Request: r}, func(ctx context.Context) error {
+		return s.next.AttachManagedObjectStorageUserPolicy(ctx, r)
+	})
+}
+
+func (s *interceptedService) AttachNetworkRouter(ctx context.Context, r *request.AttachNetworkRouterRequest) error {
+	return s.fn(ctx, Call{Method: "AttachNetworkRouter", Request: r}, func(ctx context.Context) error {
+		return s.next.AttachNetworkRouter(ctx, r)
+	})
+}
+
+func (s *interceptedService) AttachStorage(ctx context.Context, r *request.AttachStorageRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "AttachStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.AttachStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) BootstrapFirewall(ctx context.Context, r *request.BootstrapFirewallRequest) ([]upcloud.FirewallRule, error) {
+	var res []upcloud.FirewallRule
+	err := s.fn(ctx, Call{Method: "BootstrapFirewall", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.BootstrapFirewall(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) BuildTemplate(ctx context.Context, r *request.BuildTemplateRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "BuildTemplate", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.BuildTemplate(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CancelManagedDatabaseSession(ctx context.Context, r *request.CancelManagedDatabaseSession) error {
+	return s.fn(ctx, Call{Method: "CancelManagedDatabaseSession", Request: r}, func(ctx context.Context) error {
+		return s.next.CancelManagedDatabaseSession(ctx, r)
+	})
+}
+
+func (s *interceptedService) CancelStorageImport(ctx context.Context, r *request.CancelStorageImportRequest) (*upcloud.StorageImportDetails, error) {
+	var res *upcloud.StorageImportDetails
+	err := s.fn(ctx, Call{Method: "CancelStorageImport", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CancelStorageImport(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ChangeStorageTier(ctx context.Context, r *request.ChangeStorageTierRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "ChangeStorageTier", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ChangeStorageTier(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CloneManagedDatabase(ctx context.Context, r *request.CloneManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "CloneManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CloneManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CloneStorage(ctx context.Context, r *request.CloneStorageRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "CloneStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CloneStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CloneStorageOperation(ctx context.Context, r *request.CloneStorageRequest) (*Operation[upcloud.StorageDetails], error) {
+	var res *Operation[upcloud.StorageDetails]
+	err := s.fn(ctx, Call{Method: "CloneStorageOperation", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CloneStorageOperation(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateBackup(ctx context.Context, r *request.CreateBackupRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "CreateBackup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateBackup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateBackupGroup(ctx context.Context, r *request.CreateBackupGroupRequest) (*upcloud.BackupGroup, error) {
+	var res *upcloud.BackupGroup
+	err := s.fn(ctx, Call{Method: "CreateBackupGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateBackupGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateBackupOperation(ctx context.Context, r *request.CreateBackupRequest) (*Operation[upcloud.StorageDetails], error) {
+	var res *Operation[upcloud.StorageDetails]
+	err := s.fn(ctx, Call{Method: "CreateBackupOperation", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateBackupOperation(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateFirewallRule(ctx context.Context, r *request.CreateFirewallRuleRequest) (*upcloud.FirewallRule, error) {
+	var res *upcloud.FirewallRule
+	err := s.fn(ctx, Call{Method: "CreateFirewallRule", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateFirewallRule(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateFirewallRules(ctx context.Context, r *request.CreateFirewallRulesRequest) error {
+	return s.fn(ctx, Call{Method: "CreateFirewallRules", Request: r}, func(ctx context.Context) error {
+		return s.next.CreateFirewallRules(ctx, r)
+	})
+}
+
+func (s *interceptedService) CreateGateway(ctx context.Context, r *request.CreateGatewayRequest) (*upcloud.Gateway, error) {
+	var res *upcloud.Gateway
+	err := s.fn(ctx, Call{Method: "CreateGateway", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateGateway(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateGatewayConnection(ctx context.Context, r *request.CreateGatewayConnectionRequest) (*upcloud.GatewayConnection, error) {
+	var res *upcloud.GatewayConnection
+	err := s.fn(ctx, Call{Method: "CreateGatewayConnection", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateGatewayConnection(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateGatewayConnectionTunnel(ctx context.Context, r *request.CreateGatewayConnectionTunnelRequest) (*upcloud.GatewayTunnel, error) {
+	var res *upcloud.GatewayTunnel
+	err := s.fn(ctx, Call{Method: "CreateGatewayConnectionTunnel", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateGatewayConnectionTunnel(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateKubernetesCluster(ctx context.Context, r *request.CreateKubernetesClusterRequest) (*upcloud.KubernetesCluster, error) {
+	var res *upcloud.KubernetesCluster
+	err := s.fn(ctx, Call{Method: "CreateKubernetesCluster", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateKubernetesCluster(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateKubernetesNodeGroup(ctx context.Context, r *request.CreateKubernetesNodeGroupRequest) (*upcloud.KubernetesNodeGroup, error) {
+	var res *upcloud.KubernetesNodeGroup
+	err := s.fn(ctx, Call{Method: "CreateKubernetesNodeGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateKubernetesNodeGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancer(ctx context.Context, r *request.CreateLoadBalancerRequest) (*upcloud.LoadBalancer, error) {
+	var res *upcloud.LoadBalancer
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerBackend(ctx context.Context, r *request.CreateLoadBalancerBackendRequest) (*upcloud.LoadBalancerBackend, error) {
+	var res *upcloud.LoadBalancerBackend
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerBackend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerBackend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerBackendMember(ctx context.Context, r *request.CreateLoadBalancerBackendMemberRequest) (*upcloud.LoadBalancerBackendMember, error) {
+	var res *upcloud.LoadBalancerBackendMember
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerBackendMember", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerBackendMember(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerBackendTLSConfig(ctx context.Context, r *request.CreateLoadBalancerBackendTLSConfigRequest) (*upcloud.LoadBalancerBackendTLSConfig, error) {
+	var res *upcloud.LoadBalancerBackendTLSConfig
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerBackendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerBackendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerCertificateBundle(ctx context.Context, r *request.CreateLoadBalancerCertificateBundleRequest) (*upcloud.LoadBalancerCertificateBundle, error) {
+	var res *upcloud.LoadBalancerCertificateBundle
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerCertificateBundle", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerCertificateBundle(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerFrontend(ctx context.Context, r *request.CreateLoadBalancerFrontendRequest) (*upcloud.LoadBalancerFrontend, error) {
+	var res *upcloud.LoadBalancerFrontend
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerFrontend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerFrontend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerFrontendRule(ctx context.Context, r *request.CreateLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error) {
+	var res *upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerFrontendRule", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerFrontendRule(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerFrontendTLSConfig(ctx context.Context, r *request.CreateLoadBalancerFrontendTLSConfigRequest) (*upcloud.LoadBalancerFrontendTLSConfig, error) {
+	var res *upcloud.LoadBalancerFrontendTLSConfig
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerFrontendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerFrontendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateLoadBalancerResolver(ctx context.Context, r *request.CreateLoadBalancerResolverRequest) (*upcloud.LoadBalancerResolver, error) {
+	var res *upcloud.LoadBalancerResolver
+	err := s.fn(ctx, Call{Method: "CreateLoadBalancerResolver", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateLoadBalancerResolver(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedDatabase(ctx context.Context, r *request.CreateManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "CreateManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedDatabaseLogicalDatabase(ctx context.Context, r *request.CreateManagedDatabaseLogicalDatabaseRequest) (*upcloud.ManagedDatabaseLogicalDatabase, error) {
+	var res *upcloud.ManagedDatabaseLogicalDatabase
+	err := s.fn(ctx, Call{Method: "CreateManagedDatabaseLogicalDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedDatabaseLogicalDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedDatabaseUser(ctx context.Context, r *request.CreateManagedDatabaseUserRequest) (*upcloud.ManagedDatabaseUser, error) {
+	var res *upcloud.ManagedDatabaseUser
+	err := s.fn(ctx, Call{Method: "CreateManagedDatabaseUser", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedDatabaseUser(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedObjectStorage(ctx context.Context, r *request.CreateManagedObjectStorageRequest) (*upcloud.ManagedObjectStorage, error) {
+	var res *upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "CreateManagedObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedObjectStorageNetwork(ctx context.Context, r *request.CreateManagedObjectStorageNetworkRequest) (*upcloud.ManagedObjectStorageNetwork, error) {
+	var res *upcloud.ManagedObjectStorageNetwork
+	err := s.fn(ctx, Call{Method: "CreateManagedObjectStorageNetwork", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedObjectStorageNetwork(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedObjectStoragePolicy(ctx context.Context, r *request.CreateManagedObjectStoragePolicyRequest) (*upcloud.ManagedObjectStoragePolicy, error) {
+	var res *upcloud.ManagedObjectStoragePolicy
+	err := s.fn(ctx, Call{Method: "CreateManagedObjectStoragePolicy", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedObjectStoragePolicy(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedObjectStorageUser(ctx context.Context, r *request.CreateManagedObjectStorageUserRequest) (*upcloud.ManagedObjectStorageUser, error) {
+	var res *upcloud.ManagedObjectStorageUser
+	err := s.fn(ctx, Call{Method: "CreateManagedObjectStorageUser", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedObjectStorageUser(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateManagedObjectStorageUserAccessKey(ctx context.Context, r *request.CreateManagedObjectStorageUserAccessKeyRequest) (*upcloud.ManagedObjectStorageUserAccessKey, error) {
+	var res *upcloud.ManagedObjectStorageUserAccessKey
+	err := s.fn(ctx, Call{Method: "CreateManagedObjectStorageUserAccessKey", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateManagedObjectStorageUserAccessKey(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateNetwork(ctx context.Context, r *request.CreateNetworkRequest) (*upcloud.Network, error) {
+	var res *upcloud.Network
+	err := s.fn(ctx, Call{Method: "CreateNetwork", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateNetwork(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateNetworkInterface(ctx context.Context, r *request.CreateNetworkInterfaceRequest) (*upcloud.Interface, error) {
+	var res *upcloud.Interface
+	err := s.fn(ctx, Call{Method: "CreateNetworkInterface", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateNetworkInterface(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateObjectStorage(ctx context.Context, r *request.CreateObjectStorageRequest) (*upcloud.ObjectStorageDetails, error) {
+	var res *upcloud.ObjectStorageDetails
+	err := s.fn(ctx, Call{Method: "CreateObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateRouter(ctx context.Context, r *request.CreateRouterRequest) (*upcloud.Router, error) {
+	var res *upcloud.Router
+	err := s.fn(ctx, Call{Method: "CreateRouter", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateRouter(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateServer(ctx context.Context, r *request.CreateServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "CreateServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateServerGroup(ctx context.Context, r *request.CreateServerGroupRequest) (*upcloud.ServerGroup, error) {
+	var res *upcloud.ServerGroup
+	err := s.fn(ctx, Call{Method: "CreateServerGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateServerGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateStorage(ctx context.Context, r *request.CreateStorageRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "CreateStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateStorageImport(ctx context.Context, r *request.CreateStorageImportRequest) (*upcloud.StorageImportDetails, error) {
+	var res *upcloud.StorageImportDetails
+	err := s.fn(ctx, Call{Method: "CreateStorageImport", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateStorageImport(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateStorageImportOperation(ctx context.Context, r *request.CreateStorageImportRequest) (*Operation[upcloud.StorageImportDetails], error) {
+	var res *Operation[upcloud.StorageImportDetails]
+	err := s.fn(ctx, Call{Method: "CreateStorageImportOperation", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateStorageImportOperation(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateSubaccount(ctx context.Context, r *request.CreateSubaccountRequest) (*upcloud.AccountDetails, error) {
+	var res *upcloud.AccountDetails
+	err := s.fn(ctx, Call{Method: "CreateSubaccount", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateSubaccount(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) CreateTag(ctx context.Context, r *request.CreateTagRequest) (*upcloud.Tag, error) {
+	var res *upcloud.Tag
+	err := s.fn(ctx, Call{Method: "CreateTag", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.CreateTag(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) DeleteBackupGroup(ctx context.Context, r *request.DeleteBackupGroupRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteBackupGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteBackupGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteFirewallRule(ctx context.Context, r *request.DeleteFirewallRuleRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteFirewallRule", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteFirewallRule(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteGateway(ctx context.Context, r *request.DeleteGatewayRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteGateway", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteGateway(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteGatewayConnection(ctx context.Context, r *request.DeleteGatewayConnectionRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteGatewayConnection", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteGatewayConnection(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteGatewayConnectionTunnel(ctx context.Context, r *request.DeleteGatewayConnectionTunnelRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteGatewayConnectionTunnel", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteGatewayConnectionTunnel(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteKubernetesCluster(ctx context.Context, r *request.DeleteKubernetesClusterRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteKubernetesCluster", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteKubernetesCluster(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteKubernetesNodeGroup(ctx context.Context, r *request.DeleteKubernetesNodeGroupRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteKubernetesNodeGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteKubernetesNodeGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteKubernetesNodeGroupNode(ctx context.Context, r *request.DeleteKubernetesNodeGroupNodeRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteKubernetesNodeGroupNode", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteKubernetesNodeGroupNode(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancer(ctx context.Context, r *request.DeleteLoadBalancerRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancer", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancer(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerBackend(ctx context.Context, r *request.DeleteLoadBalancerBackendRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerBackend", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerBackend(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerBackendMember(ctx context.Context, r *request.DeleteLoadBalancerBackendMemberRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerBackendMember", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerBackendMember(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerBackendTLSConfig(ctx context.Context, r *request.DeleteLoadBalancerBackendTLSConfigRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerBackendTLSConfig", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerBackendTLSConfig(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerCertificateBundle(ctx context.Context, r *request.DeleteLoadBalancerCertificateBundleRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerCertificateBundle", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerCertificateBundle(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerFrontend(ctx context.Context, r *request.DeleteLoadBalancerFrontendRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerFrontend", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerFrontend(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerFrontendRule(ctx context.Context, r *request.DeleteLoadBalancerFrontendRuleRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerFrontendRule", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerFrontendRule(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerFrontendTLSConfig(ctx context.Context, r *request.DeleteLoadBalancerFrontendTLSConfigRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerFrontendTLSConfig", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerFrontendTLSConfig(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteLoadBalancerResolver(ctx context.Context, r *request.DeleteLoadBalancerResolverRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteLoadBalancerResolver", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteLoadBalancerResolver(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedDatabase(ctx context.Context, r *request.DeleteManagedDatabaseRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedDatabase", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedDatabase(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedDatabaseIndex(ctx context.Context, r *request.DeleteManagedDatabaseIndexRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedDatabaseIndex", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedDatabaseIndex(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedDatabaseLogicalDatabase(ctx context.Context, r *request.DeleteManagedDatabaseLogicalDatabaseRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedDatabaseLogicalDatabase", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedDatabaseLogicalDatabase(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedDatabaseUser(ctx context.Context, r *request.DeleteManagedDatabaseUserRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedDatabaseUser", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedDatabaseUser(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedObjectStorage(ctx context.Context, r *request.DeleteManagedObjectStorageRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedObjectStorage", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedObjectStorage(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedObjectStorageNetwork(ctx context.Context, r *request.DeleteManagedObjectStorageNetworkRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedObjectStorageNetwork", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedObjectStorageNetwork(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedObjectStoragePolicy(ctx context.Context, r *request.DeleteManagedObjectStoragePolicyRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedObjectStoragePolicy", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedObjectStoragePolicy(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedObjectStorageUser(ctx context.Context, r *request.DeleteManagedObjectStorageUserRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedObjectStorageUser", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedObjectStorageUser(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteManagedObjectStorageUserAccessKey(ctx context.Context, r *request.DeleteManagedObjectStorageUserAccessKeyRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteManagedObjectStorageUserAccessKey", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteManagedObjectStorageUserAccessKey(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteNetwork(ctx context.Context, r *request.DeleteNetworkRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteNetwork", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteNetwork(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteNetworkInterface(ctx context.Context, r *request.DeleteNetworkInterfaceRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteNetworkInterface", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteNetworkInterface(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteObjectStorage(ctx context.Context, r *request.DeleteObjectStorageRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteObjectStorage", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteObjectStorage(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteRouter(ctx context.Context, r *request.DeleteRouterRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteRouter", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteRouter(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteServer(ctx context.Context, r *request.DeleteServerRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteServer", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteServer(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteServerAndStorages(ctx context.Context, r *request.DeleteServerAndStoragesRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteServerAndStorages", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteServerAndStorages(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteServerGroup(ctx context.Context, r *request.DeleteServerGroupRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteServerGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteServerGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteStorage(ctx context.Context, r *request.DeleteStorageRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteStorage", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteStorage(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteSubaccount(ctx context.Context, r *request.DeleteSubaccountRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteSubaccount", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteSubaccount(ctx, r)
+	})
+}
+
+func (s *interceptedService) DeleteTag(ctx context.Context, r *request.DeleteTagRequest) error {
+	return s.fn(ctx, Call{Method: "DeleteTag", Request: r}, func(ctx context.Context) error {
+		return s.next.DeleteTag(ctx, r)
+	})
+}
+
+func (s *interceptedService) DetachAllStorages(ctx context.Context, r *request.DetachAllStoragesRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "DetachAllStorages", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.DetachAllStorages(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) DetachManagedObjectStorageUserPolicy(ctx context.Context, r *request.DetachManagedObjectStorageUserPolicyRequest) error {
+	return s.fn(ctx, Call{Method: "DetachManagedObjectStorageUserPolicy", Request: r}, func(ctx context.Context) error {
+		return s.next.DetachManagedObjectStorageUserPolicy(ctx, r)
+	})
+}
+
+func (s *interceptedService) DetachNetworkRouter(ctx context.Context, r *request.DetachNetworkRouterRequest) error {
+	return s.fn(ctx, Call{Method: "DetachNetworkRouter", Request: r}, func(ctx context.Context) error {
+		return s.next.DetachNetworkRouter(ctx, r)
+	})
+}
+
+func (s *interceptedService) DetachStorage(ctx context.Context, r *request.DetachStorageRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "DetachStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.DetachStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) EjectCDROM(ctx context.Context, r *request.EjectCDROMRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "EjectCDROM", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.EjectCDROM(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ExitRescue(ctx context.Context, r *request.ExitRescueRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "ExitRescue", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ExitRescue(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ExportFirewallRules(ctx context.Context, r *request.GetFirewallRulesRequest) (*upcloud.FirewallRuleset, error) {
+	var res *upcloud.FirewallRuleset
+	err := s.fn(ctx, Call{Method: "ExportFirewallRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ExportFirewallRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ForEachStorage(ctx context.Context, r *request.GetStoragesRequest, p2 func(upcloud.Storage) bool) error {
+	return s.fn(ctx, Call{Method: "ForEachStorage", Request: r}, func(ctx context.Context) error {
+		return s.next.ForEachStorage(ctx, r, p2)
+	})
+}
+
+func (s *interceptedService) GetAccount(ctx context.Context) (*upcloud.Account, error) {
+	var res *upcloud.Account
+	err := s.fn(ctx, Call{Method: "GetAccount", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetAccount(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetAccountDetails(ctx context.Context, r *request.GetAccountDetailsRequest) (*upcloud.AccountDetails, error) {
+	var res *upcloud.AccountDetails
+	err := s.fn(ctx, Call{Method: "GetAccountDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetAccountDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetAccountList(ctx context.Context) (upcloud.AccountList, error) {
+	var res upcloud.AccountList
+	err := s.fn(ctx, Call{Method: "GetAccountList", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetAccountList(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetBackupGroup(ctx context.Context, r *request.GetBackupGroupRequest) (*upcloud.BackupGroup, error) {
+	var res *upcloud.BackupGroup
+	err := s.fn(ctx, Call{Method: "GetBackupGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetBackupGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetBackups(ctx context.Context, r *request.GetBackupsRequest) (*upcloud.Backups, error) {
+	var res *upcloud.Backups
+	err := s.fn(ctx, Call{Method: "GetBackups", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetBackups(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetCDROMs(ctx context.Context) (upcloud.CDROMs, error) {
+	var res upcloud.CDROMs
+	err := s.fn(ctx, Call{Method: "GetCDROMs", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetCDROMs(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetFirewallRuleDetails(ctx context.Context, r *request.GetFirewallRuleDetailsRequest) (*upcloud.FirewallRule, error) {
+	var res *upcloud.FirewallRule
+	err := s.fn(ctx, Call{Method: "GetFirewallRuleDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetFirewallRuleDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetFirewallRules(ctx context.Context, r *request.GetFirewallRulesRequest) (*upcloud.FirewallRules, error) {
+	var res *upcloud.FirewallRules
+	err := s.fn(ctx, Call{Method: "GetFirewallRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetFirewallRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGateway(ctx context.Context, r *request.GetGatewayRequest) (*upcloud.Gateway, error) {
+	var res *upcloud.Gateway
+	err := s.fn(ctx, Call{Method: "GetGateway", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGateway(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGatewayConnection(ctx context.Context, r *request.GetGatewayConnectionRequest) (*upcloud.GatewayConnection, error) {
+	var res *upcloud.GatewayConnection
+	err := s.fn(ctx, Call{Method: "GetGatewayConnection", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGatewayConnection(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGatewayConnectionTunnel(ctx context.Context, r *request.GetGatewayConnectionTunnelRequest) (*upcloud.GatewayTunnel, error) {
+	var res *upcloud.GatewayTunnel
+	err := s.fn(ctx, Call{Method: "GetGatewayConnectionTunnel", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGatewayConnectionTunnel(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGatewayConnectionTunnels(ctx context.Context, r *request.GetGatewayConnectionTunnelsRequest) ([]upcloud.GatewayTunnel, error) {
+	var res []upcloud.GatewayTunnel
+	err := s.fn(ctx, Call{Method: "GetGatewayConnectionTunnels", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGatewayConnectionTunnels(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGatewayConnections(ctx context.Context, r *request.GetGatewayConnectionsRequest) ([]upcloud.GatewayConnection, error) {
+	var res []upcloud.GatewayConnection
+	err := s.fn(ctx, Call{Method: "GetGatewayConnections", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGatewayConnections(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGatewayPlans(ctx context.Context) ([]upcloud.GatewayPlan, error) {
+	var res []upcloud.GatewayPlan
+	err := s.fn(ctx, Call{Method: "GetGatewayPlans", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGatewayPlans(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetGateways(ctx context.Context, r ...request.QueryFilter) ([]upcloud.Gateway, error) {
+	var res []upcloud.Gateway
+	err := s.fn(ctx, Call{Method: "GetGateways", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetGateways(ctx, r...)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetHostDetails(ctx context.Context, r *request.GetHostDetailsRequest) (*upcloud.Host, error) {
+	var res *upcloud.Host
+	err := s.fn(ctx, Call{Method: "GetHostDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetHostDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetHosts(ctx context.Context) (*upcloud.Hosts, error) {
+	var res *upcloud.Hosts
+	err := s.fn(ctx, Call{Method: "GetHosts", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetHosts(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetIPAddressDetails(ctx context.Context, r *request.GetIPAddressDetailsRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "GetIPAddressDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetIPAddressDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetIPAddresses(ctx context.Context) (*upcloud.IPAddresses, error) {
+	var res *upcloud.IPAddresses
+	err := s.fn(ctx, Call{Method: "GetIPAddresses", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetIPAddresses(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetIPAddressesWithoutPTR(ctx context.Context) (upcloud.IPAddressSlice, error) {
+	var res upcloud.IPAddressSlice
+	err := s.fn(ctx, Call{Method: "GetIPAddressesWithoutPTR", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetIPAddressesWithoutPTR(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetInventory(ctx context.Context, r *request.GetInventoryRequest) (*upcloud.Inventory, error) {
+	var res *upcloud.Inventory
+	err := s.fn(ctx, Call{Method: "GetInventory", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetInventory(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesCluster(ctx context.Context, r *request.GetKubernetesClusterRequest) (*upcloud.KubernetesCluster, error) {
+	var res *upcloud.KubernetesCluster
+	err := s.fn(ctx, Call{Method: "GetKubernetesCluster", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesCluster(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesClusters(ctx context.Context, r *request.GetKubernetesClustersRequest) ([]upcloud.KubernetesCluster, error) {
+	var res []upcloud.KubernetesCluster
+	err := s.fn(ctx, Call{Method: "GetKubernetesClusters", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesClusters(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesKubeconfig(ctx context.Context, r *request.GetKubernetesKubeconfigRequest) (string, error) {
+	var res string
+	err := s.fn(ctx, Call{Method: "GetKubernetesKubeconfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesKubeconfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesNodeGroup(ctx context.Context, r *request.GetKubernetesNodeGroupRequest) (*upcloud.KubernetesNodeGroupDetails, error) {
+	var res *upcloud.KubernetesNodeGroupDetails
+	err := s.fn(ctx, Call{Method: "GetKubernetesNodeGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesNodeGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesNodeGroups(ctx context.Context, r *request.GetKubernetesNodeGroupsRequest) ([]upcloud.KubernetesNodeGroup, error) {
+	var res []upcloud.KubernetesNodeGroup
+	err := s.fn(ctx, Call{Method: "GetKubernetesNodeGroups", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesNodeGroups(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesPlans(ctx context.Context, r *request.GetKubernetesPlansRequest) ([]upcloud.KubernetesPlan, error) {
+	var res []upcloud.KubernetesPlan
+	err := s.fn(ctx, Call{Method: "GetKubernetesPlans", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesPlans(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetKubernetesVersions(ctx context.Context, r *request.GetKubernetesVersionsRequest) ([]upcloud.KubernetesVersion, error) {
+	var res []upcloud.KubernetesVersion
+	err := s.fn(ctx, Call{Method: "GetKubernetesVersions", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetKubernetesVersions(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancer(ctx context.Context, r *request.GetLoadBalancerRequest) (*upcloud.LoadBalancer, error) {
+	var res *upcloud.LoadBalancer
+	err := s.fn(ctx, Call{Method: "GetLoadBalancer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackend(ctx context.Context, r *request.GetLoadBalancerBackendRequest) (*upcloud.LoadBalancerBackend, error) {
+	var res *upcloud.LoadBalancerBackend
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackendMember(ctx context.Context, r *request.GetLoadBalancerBackendMemberRequest) (*upcloud.LoadBalancerBackendMember, error) {
+	var res *upcloud.LoadBalancerBackendMember
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackendMember", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackendMember(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackendMembers(ctx context.Context, r *request.GetLoadBalancerBackendMembersRequest) ([]upcloud.LoadBalancerBackendMember, error) {
+	var res []upcloud.LoadBalancerBackendMember
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackendMembers", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackendMembers(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackendTLSConfig(ctx context.Context, r *request.GetLoadBalancerBackendTLSConfigRequest) (*upcloud.LoadBalancerBackendTLSConfig, error) {
+	var res *upcloud.LoadBalancerBackendTLSConfig
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackendTLSConfigs(ctx context.Context, r *request.GetLoadBalancerBackendTLSConfigsRequest) ([]upcloud.LoadBalancerBackendTLSConfig, error) {
+	var res []upcloud.LoadBalancerBackendTLSConfig
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackendTLSConfigs", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackendTLSConfigs(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerBackends(ctx context.Context, r *request.GetLoadBalancerBackendsRequest) ([]upcloud.LoadBalancerBackend, error) {
+	var res []upcloud.LoadBalancerBackend
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerBackends", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerBackends(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerCertificateBundle(ctx context.Context, r *request.GetLoadBalancerCertificateBundleRequest) (*upcloud.LoadBalancerCertificateBundle, error) {
+	var res *upcloud.LoadBalancerCertificateBundle
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerCertificateBundle", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerCertificateBundle(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerCertificateBundles(ctx context.Context, r *request.GetLoadBalancerCertificateBundlesRequest) ([]upcloud.LoadBalancerCertificateBundle, error) {
+	var res []upcloud.LoadBalancerCertificateBundle
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerCertificateBundles", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerCertificateBundles(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontend(ctx context.Context, r *request.GetLoadBalancerFrontendRequest) (*upcloud.LoadBalancerFrontend, error) {
+	var res *upcloud.LoadBalancerFrontend
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontendRule(ctx context.Context, r *request.GetLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error) {
+	var res *upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontendRule", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontendRule(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontendRules(ctx context.Context, r *request.GetLoadBalancerFrontendRulesRequest) ([]upcloud.LoadBalancerFrontendRule, error) {
+	var res []upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontendRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontendRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontendTLSConfig(ctx context.Context, r *request.GetLoadBalancerFrontendTLSConfigRequest) (*upcloud.LoadBalancerFrontendTLSConfig, error) {
+	var res *upcloud.LoadBalancerFrontendTLSConfig
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontendTLSConfigs(ctx context.Context, r *request.GetLoadBalancerFrontendTLSConfigsRequest) ([]upcloud.LoadBalancerFrontendTLSConfig, error) {
+	var res []upcloud.LoadBalancerFrontendTLSConfig
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontendTLSConfigs", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontendTLSConfigs(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerFrontends(ctx context.Context, r *request.GetLoadBalancerFrontendsRequest) ([]upcloud.LoadBalancerFrontend, error) {
+	var res []upcloud.LoadBalancerFrontend
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerFrontends", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerFrontends(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerPlans(ctx context.Context, r *request.GetLoadBalancerPlansRequest) ([]upcloud.LoadBalancerPlan, error) {
+	var res []upcloud.LoadBalancerPlan
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerPlans", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerPlans(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerResolver(ctx context.Context, r *request.GetLoadBalancerResolverRequest) (*upcloud.LoadBalancerResolver, error) {
+	var res *upcloud.LoadBalancerResolver
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerResolver", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerResolver(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancerResolvers(ctx context.Context, r *request.GetLoadBalancerResolversRequest) ([]upcloud.LoadBalancerResolver, error) {
+	var res []upcloud.LoadBalancerResolver
+	err := s.fn(ctx, Call{Method: "GetLoadBalancerResolvers", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancerResolvers(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetLoadBalancers(ctx context.Context, r *request.GetLoadBalancersRequest) ([]upcloud.LoadBalancer, error) {
+	var res []upcloud.LoadBalancer
+	err := s.fn(ctx, Call{Method: "GetLoadBalancers", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetLoadBalancers(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabase(ctx context.Context, r *request.GetManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "GetManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseAccessControl(ctx context.Context, r *request.GetManagedDatabaseAccessControlRequest) (*upcloud.ManagedDatabaseAccessControl, error) {
+	var res *upcloud.ManagedDatabaseAccessControl
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseAccessControl", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseAccessControl(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseIndices(ctx context.Context, r *request.GetManagedDatabaseIndicesRequest) ([]upcloud.ManagedDatabaseIndex, error) {
+	var res []upcloud.ManagedDatabaseIndex
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseIndices", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseIndices(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseLogicalDatabases(ctx context.Context, r *request.GetManagedDatabaseLogicalDatabasesRequest) ([]upcloud.ManagedDatabaseLogicalDatabase, error) {
+	var res []upcloud.ManagedDatabaseLogicalDatabase
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseLogicalDatabases", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseLogicalDatabases(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseLogs(ctx context.Context, r *request.GetManagedDatabaseLogsRequest) (*upcloud.ManagedDatabaseLogs, error) {
+	var res *upcloud.ManagedDatabaseLogs
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseLogs", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseLogs(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseMetrics(ctx context.Context, r *request.GetManagedDatabaseMetricsRequest) (*upcloud.ManagedDatabaseMetrics, error) {
+	var res *upcloud.ManagedDatabaseMetrics
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseMetrics", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseMetrics(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseQueryStatisticsMySQL(ctx context.Context, r *request.GetManagedDatabaseQueryStatisticsRequest) ([]upcloud.ManagedDatabaseQueryStatisticsMySQL, error) {
+	var res []upcloud.ManagedDatabaseQueryStatisticsMySQL
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseQueryStatisticsMySQL", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseQueryStatisticsMySQL(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseQueryStatisticsPostgreSQL(ctx context.Context, r *request.GetManagedDatabaseQueryStatisticsRequest) ([]upcloud.ManagedDatabaseQueryStatisticsPostgreSQL, error) {
+	var res []upcloud.ManagedDatabaseQueryStatisticsPostgreSQL
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseQueryStatisticsPostgreSQL", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseQueryStatisticsPostgreSQL(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseServiceType(ctx context.Context, r *request.GetManagedDatabaseServiceTypeRequest) (*upcloud.ManagedDatabaseType, error) {
+	var res *upcloud.ManagedDatabaseType
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseServiceType", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseServiceType(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseServiceTypes(ctx context.Context, r *request.GetManagedDatabaseServiceTypesRequest) (map[string]upcloud.ManagedDatabaseType, error) {
+	var res map[string]upcloud.ManagedDatabaseType
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseServiceTypes", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseServiceTypes(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseSessions(ctx context.Context, r *request.GetManagedDatabaseSessionsRequest) (upcloud.ManagedDatabaseSessions, error) {
+	var res upcloud.ManagedDatabaseSessions
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseSessions", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseSessions(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseUser(ctx context.Context, r *request.GetManagedDatabaseUserRequest) (*upcloud.ManagedDatabaseUser, error) {
+	var res *upcloud.ManagedDatabaseUser
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseUser", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseUser(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseUsers(ctx context.Context, r *request.GetManagedDatabaseUsersRequest) ([]upcloud.ManagedDatabaseUser, error) {
+	var res []upcloud.ManagedDatabaseUser
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseUsers", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseUsers(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabaseVersions(ctx context.Context, r *request.GetManagedDatabaseVersionsRequest) ([]string, error) {
+	var res []string
+	err := s.fn(ctx, Call{Method: "GetManagedDatabaseVersions", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabaseVersions(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedDatabases(ctx context.Context, r *request.GetManagedDatabasesRequest) ([]upcloud.ManagedDatabase, error) {
+	var res []upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "GetManagedDatabases", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedDatabases(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorage(ctx context.Context, r *request.GetManagedObjectStorageRequest) (*upcloud.ManagedObjectStorage, error) {
+	var res *upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageBucketMetrics(ctx context.Context, r *request.GetManagedObjectStorageBucketMetricsRequest) ([]upcloud.ManagedObjectStorageBucketMetrics, error) {
+	var res []upcloud.ManagedObjectStorageBucketMetrics
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageBucketMetrics", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageBucketMetrics(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageMetrics(ctx context.Context, r *request.GetManagedObjectStorageMetricsRequest) (*upcloud.ManagedObjectStorageMetrics, error) {
+	var res *upcloud.ManagedObjectStorageMetrics
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageMetrics", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageMetrics(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageNetwork(ctx context.Context, r *request.GetManagedObjectStorageNetworkRequest) (*upcloud.ManagedObjectStorageNetwork, error) {
+	var res *upcloud.ManagedObjectStorageNetwork
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageNetwork", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageNetwork(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageNetworks(ctx context.Context, r *request.GetManagedObjectStorageNetworksRequest) ([]upcloud.ManagedObjectStorageNetwork, error) {
+	var res []upcloud.ManagedObjectStorageNetwork
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageNetworks", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageNetworks(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStoragePolicies(ctx context.Context, r *request.GetManagedObjectStoragePoliciesRequest) ([]upcloud.ManagedObjectStoragePolicy, error) {
+	var res []upcloud.ManagedObjectStoragePolicy
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStoragePolicies", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStoragePolicies(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStoragePolicy(ctx context.Context, r *request.GetManagedObjectStoragePolicyRequest) (*upcloud.ManagedObjectStoragePolicy, error) {
+	var res *upcloud.ManagedObjectStoragePolicy
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStoragePolicy", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStoragePolicy(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageRegion(ctx context.Context, r *request.GetManagedObjectStorageRegionRequest) (*upcloud.ManagedObjectStorageRegion, error) {
+	var res *upcloud.ManagedObjectStorageRegion
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageRegion", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageRegion(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageRegions(ctx context.Context, r *request.GetManagedObjectStorageRegionsRequest) ([]upcloud.ManagedObjectStorageRegion, error) {
+	var res []upcloud.ManagedObjectStorageRegion
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageRegions", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageRegions(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUsage(ctx context.Context, r *request.GetManagedObjectStorageUsageRequest) (*upcloud.ManagedObjectStorageUsage, error) {
+	var res *upcloud.ManagedObjectStorageUsage
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUsage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUsage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUser(ctx context.Context, r *request.GetManagedObjectStorageUserRequest) (*upcloud.ManagedObjectStorageUser, error) {
+	var res *upcloud.ManagedObjectStorageUser
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUser", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUser(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUserAccessKey(ctx context.Context, r *request.GetManagedObjectStorageUserAccessKeyRequest) (*upcloud.ManagedObjectStorageUserAccessKey, error) {
+	var res *upcloud.ManagedObjectStorageUserAccessKey
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUserAccessKey", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUserAccessKey(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUserAccessKeys(ctx context.Context, r *request.GetManagedObjectStorageUserAccessKeysRequest) ([]upcloud.ManagedObjectStorageUserAccessKey, error) {
+	var res []upcloud.ManagedObjectStorageUserAccessKey
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUserAccessKeys", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUserAccessKeys(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUserPolicies(ctx context.Context, r *request.GetManagedObjectStorageUserPoliciesRequest) ([]upcloud.ManagedObjectStorageUserPolicy, error) {
+	var res []upcloud.ManagedObjectStorageUserPolicy
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUserPolicies", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUserPolicies(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorageUsers(ctx context.Context, r *request.GetManagedObjectStorageUsersRequest) ([]upcloud.ManagedObjectStorageUser, error) {
+	var res []upcloud.ManagedObjectStorageUser
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorageUsers", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorageUsers(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetManagedObjectStorages(ctx context.Context, r *request.GetManagedObjectStoragesRequest) ([]upcloud.ManagedObjectStorage, error) {
+	var res []upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "GetManagedObjectStorages", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetManagedObjectStorages(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetNetworkDetails(ctx context.Context, r *request.GetNetworkDetailsRequest) (*upcloud.Network, error) {
+	var res *upcloud.Network
+	err := s.fn(ctx, Call{Method: "GetNetworkDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetNetworkDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetNetworks(ctx context.Context, r ...request.QueryFilter) (*upcloud.Networks, error) {
+	var res *upcloud.Networks
+	err := s.fn(ctx, Call{Method: "GetNetworks", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetNetworks(ctx, r...)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetNetworksInZone(ctx context.Context, r *request.GetNetworksInZoneRequest) (*upcloud.Networks, error) {
+	var res *upcloud.Networks
+	err := s.fn(ctx, Call{Method: "GetNetworksInZone", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetNetworksInZone(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetObjectStorageDetails(ctx context.Context, r *request.GetObjectStorageDetailsRequest) (*upcloud.ObjectStorageDetails, error) {
+	var res *upcloud.ObjectStorageDetails
+	err := s.fn(ctx, Call{Method: "GetObjectStorageDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetObjectStorageDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetObjectStorages(ctx context.Context) (*upcloud.ObjectStorages, error) {
+	var res *upcloud.ObjectStorages
+	err := s.fn(ctx, Call{Method: "GetObjectStorages", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetObjectStorages(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetPermissions(ctx context.Context, r *request.GetPermissionsRequest) (upcloud.Permissions, error) {
+	var res upcloud.Permissions
+	err := s.fn(ctx, Call{Method: "GetPermissions", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetPermissions(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetPlans(ctx context.Context) (*upcloud.Plans, error) {
+	var res *upcloud.Plans
+	err := s.fn(ctx, Call{Method: "GetPlans", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetPlans(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetPriceZones(ctx context.Context) (*upcloud.PriceZones, error) {
+	var res *upcloud.PriceZones
+	err := s.fn(ctx, Call{Method: "GetPriceZones", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetPriceZones(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetRouterDetails(ctx context.Context, r *request.GetRouterDetailsRequest) (*upcloud.Router, error) {
+	var res *upcloud.Router
+	err := s.fn(ctx, Call{Method: "GetRouterDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetRouterDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetRouters(ctx context.Context, r ...request.QueryFilter) (*upcloud.Routers, error) {
+	var res *upcloud.Routers
+	err := s.fn(ctx, Call{Method: "GetRouters", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetRouters(ctx, r...)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerConfigurations(ctx context.Context) (*upcloud.ServerConfigurations, error) {
+	var res *upcloud.ServerConfigurations
+	err := s.fn(ctx, Call{Method: "GetServerConfigurations", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerConfigurations(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerConfigurationsWithFilters(ctx context.Context, r *request.GetServerConfigurationsRequest) (*upcloud.ServerConfigurations, error) {
+	var res *upcloud.ServerConfigurations
+	err := s.fn(ctx, Call{Method: "GetServerConfigurationsWithFilters", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerConfigurationsWithFilters(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerDetails(ctx context.Context, r *request.GetServerDetailsRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "GetServerDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerGroup(ctx context.Context, r *request.GetServerGroupRequest) (*upcloud.ServerGroup, error) {
+	var res *upcloud.ServerGroup
+	err := s.fn(ctx, Call{Method: "GetServerGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerGroups(ctx context.Context, r *request.GetServerGroupsRequest) (upcloud.ServerGroups, error) {
+	var res upcloud.ServerGroups
+	err := s.fn(ctx, Call{Method: "GetServerGroups", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerGroups(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerNetworks(ctx context.Context, r *request.GetServerNetworksRequest) (*upcloud.Networking, error) {
+	var res *upcloud.Networking
+	err := s.fn(ctx, Call{Method: "GetServerNetworks", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerNetworks(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServerStorageProtection(ctx context.Context, r *request.GetServerDetailsRequest) ([]upcloud.StorageProtection, error) {
+	var res []upcloud.StorageProtection
+	err := s.fn(ctx, Call{Method: "GetServerStorageProtection", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServerStorageProtection(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServers(ctx context.Context) (*upcloud.Servers, error) {
+	var res *upcloud.Servers
+	err := s.fn(ctx, Call{Method: "GetServers", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServers(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServersWithDetails(ctx context.Context, r *request.GetServersWithDetailsRequest) ([]upcloud.ServerDetails, error) {
+	var res []upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "GetServersWithDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServersWithDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetServersWithFilters(ctx context.Context, r *request.GetServersWithFiltersRequest) (*upcloud.Servers, error) {
+	var res *upcloud.Servers
+	err := s.fn(ctx, Call{Method: "GetServersWithFilters", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetServersWithFilters(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetStorageDetails(ctx context.Context, r *request.GetStorageDetailsRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "GetStorageDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetStorageDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetStorageImportDetails(ctx context.Context, r *request.GetStorageImportDetailsRequest) (*upcloud.StorageImportDetails, error) {
+	var res *upcloud.StorageImportDetails
+	err := s.fn(ctx, Call{Method: "GetStorageImportDetails", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetStorageImportDetails(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetStorages(ctx context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error) {
+	var res *upcloud.Storages
+	err := s.fn(ctx, Call{Method: "GetStorages", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetStorages(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetTags(ctx context.Context) (*upcloud.Tags, error) {
+	var res *upcloud.Tags
+	err := s.fn(ctx, Call{Method: "GetTags", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetTags(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetTimeZones(ctx context.Context) (*upcloud.TimeZones, error) {
+	var res *upcloud.TimeZones
+	err := s.fn(ctx, Call{Method: "GetTimeZones", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetTimeZones(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetZoneCapabilities(ctx context.Context) ([]upcloud.ZoneCapabilities, error) {
+	var res []upcloud.ZoneCapabilities
+	err := s.fn(ctx, Call{Method: "GetZoneCapabilities", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetZoneCapabilities(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GetZones(ctx context.Context) (*upcloud.Zones, error) {
+	var res *upcloud.Zones
+	err := s.fn(ctx, Call{Method: "GetZones", Request: nil, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GetZones(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) GrantPermission(ctx context.Context, r *request.GrantPermissionRequest) (*upcloud.Permission, error) {
+	var res *upcloud.Permission
+	err := s.fn(ctx, Call{Method: "GrantPermission", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.GrantPermission(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ImportFirewallRules(ctx context.Context, r *request.ImportFirewallRulesRequest) error {
+	return s.fn(ctx, Call{Method: "ImportFirewallRules", Request: r}, func(ctx context.Context) error {
+		return s.next.ImportFirewallRules(ctx, r)
+	})
+}
+
+func (s *interceptedService) LoadCDROM(ctx context.Context, r *request.LoadCDROMRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "LoadCDROM", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.LoadCDROM(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) LoadCDROMByTitle(ctx context.Context, r *request.LoadCDROMByTitleRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "LoadCDROMByTitle", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.LoadCDROMByTitle(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) MigrateServerToZone(ctx context.Context, r *request.MigrateServerToZoneRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "MigrateServerToZone", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.MigrateServerToZone(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) MigrateToBackupRules(ctx context.Context, r *request.MigrateToBackupRulesRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "MigrateToBackupRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.MigrateToBackupRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) MigrateToSimpleBackup(ctx context.Context, r *request.MigrateToSimpleBackupRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "MigrateToSimpleBackup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.MigrateToSimpleBackup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyGateway(ctx context.Context, r *request.ModifyGatewayRequest) (*upcloud.Gateway, error) {
+	var res *upcloud.Gateway
+	err := s.fn(ctx, Call{Method: "ModifyGateway", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyGateway(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyGatewayConnection(ctx context.Context, r *request.ModifyGatewayConnectionRequest) (*upcloud.GatewayConnection, error) {
+	var res *upcloud.GatewayConnection
+	err := s.fn(ctx, Call{Method: "ModifyGatewayConnection", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyGatewayConnection(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyHost(ctx context.Context, r *request.ModifyHostRequest) (*upcloud.Host, error) {
+	var res *upcloud.Host
+	err := s.fn(ctx, Call{Method: "ModifyHost", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyHost(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyIPAddress(ctx context.Context, r *request.ModifyIPAddressRequest) (*upcloud.IPAddress, error) {
+	var res *upcloud.IPAddress
+	err := s.fn(ctx, Call{Method: "ModifyIPAddress", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyIPAddress(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyKubernetesCluster(ctx context.Context, r *request.ModifyKubernetesClusterRequest) (*upcloud.KubernetesCluster, error) {
+	var res *upcloud.KubernetesCluster
+	err := s.fn(ctx, Call{Method: "ModifyKubernetesCluster", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyKubernetesCluster(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyKubernetesNodeGroup(ctx context.Context, r *request.ModifyKubernetesNodeGroupRequest) (*upcloud.KubernetesNodeGroup, error) {
+	var res *upcloud.KubernetesNodeGroup
+	err := s.fn(ctx, Call{Method: "ModifyKubernetesNodeGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyKubernetesNodeGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancer(ctx context.Context, r *request.ModifyLoadBalancerRequest) (*upcloud.LoadBalancer, error) {
+	var res *upcloud.LoadBalancer
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerBackend(ctx context.Context, r *request.ModifyLoadBalancerBackendRequest) (*upcloud.LoadBalancerBackend, error) {
+	var res *upcloud.LoadBalancerBackend
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerBackend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerBackend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerBackendMember(ctx context.Context, r *request.ModifyLoadBalancerBackendMemberRequest) (*upcloud.LoadBalancerBackendMember, error) {
+	var res *upcloud.LoadBalancerBackendMember
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerBackendMember", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerBackendMember(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerBackendTLSConfig(ctx context.Context, r *request.ModifyLoadBalancerBackendTLSConfigRequest) (*upcloud.LoadBalancerBackendTLSConfig, error) {
+	var res *upcloud.LoadBalancerBackendTLSConfig
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerBackendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerBackendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerCertificateBundle(ctx context.Context, r *request.ModifyLoadBalancerCertificateBundleRequest) (*upcloud.LoadBalancerCertificateBundle, error) {
+	var res *upcloud.LoadBalancerCertificateBundle
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerCertificateBundle", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerCertificateBundle(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerFrontend(ctx context.Context, r *request.ModifyLoadBalancerFrontendRequest) (*upcloud.LoadBalancerFrontend, error) {
+	var res *upcloud.LoadBalancerFrontend
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerFrontend", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerFrontend(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerFrontendRule(ctx context.Context, r *request.ModifyLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error) {
+	var res *upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerFrontendRule", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerFrontendRule(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerFrontendTLSConfig(ctx context.Context, r *request.ModifyLoadBalancerFrontendTLSConfigRequest) (*upcloud.LoadBalancerFrontendTLSConfig, error) {
+	var res *upcloud.LoadBalancerFrontendTLSConfig
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerFrontendTLSConfig", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerFrontendTLSConfig(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerNetwork(ctx context.Context, r *request.ModifyLoadBalancerNetworkRequest) (*upcloud.LoadBalancerNetwork, error) {
+	var res *upcloud.LoadBalancerNetwork
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerNetwork", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerNetwork(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyLoadBalancerResolver(ctx context.Context, r *request.ModifyLoadBalancerResolverRequest) (*upcloud.LoadBalancerResolver, error) {
+	var res *upcloud.LoadBalancerResolver
+	err := s.fn(ctx, Call{Method: "ModifyLoadBalancerResolver", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyLoadBalancerResolver(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedDatabase(ctx context.Context, r *request.ModifyManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "ModifyManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedDatabaseAccessControl(ctx context.Context, r *request.ModifyManagedDatabaseAccessControlRequest) (*upcloud.ManagedDatabaseAccessControl, error) {
+	var res *upcloud.ManagedDatabaseAccessControl
+	err := s.fn(ctx, Call{Method: "ModifyManagedDatabaseAccessControl", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedDatabaseAccessControl(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedDatabaseUser(ctx context.Context, r *request.ModifyManagedDatabaseUserRequest) (*upcloud.ManagedDatabaseUser, error) {
+	var res *upcloud.ManagedDatabaseUser
+	err := s.fn(ctx, Call{Method: "ModifyManagedDatabaseUser", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedDatabaseUser(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedDatabaseUserAccessControl(ctx context.Context, r *request.ModifyManagedDatabaseUserAccessControlRequest) (*upcloud.ManagedDatabaseUser, error) {
+	var res *upcloud.ManagedDatabaseUser
+	err := s.fn(ctx, Call{Method: "ModifyManagedDatabaseUserAccessControl", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedDatabaseUserAccessControl(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedObjectStorage(ctx context.Context, r *request.ModifyManagedObjectStorageRequest) (*upcloud.ManagedObjectStorage, error) {
+	var res *upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "ModifyManagedObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyManagedObjectStorageUserAccessKey(ctx context.Context, r *request.ModifyManagedObjectStorageUserAccessKeyRequest) (*upcloud.ManagedObjectStorageUserAccessKey, error) {
+	var res *upcloud.ManagedObjectStorageUserAccessKey
+	err := s.fn(ctx, Call{Method: "ModifyManagedObjectStorageUserAccessKey", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyManagedObjectStorageUserAccessKey(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyNetwork(ctx context.Context, r *request.ModifyNetworkRequest) (*upcloud.Network, error) {
+	var res *upcloud.Network
+	err := s.fn(ctx, Call{Method: "ModifyNetwork", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyNetwork(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyNetworkInterface(ctx context.Context, r *request.ModifyNetworkInterfaceRequest) (*upcloud.Interface, error) {
+	var res *upcloud.Interface
+	err := s.fn(ctx, Call{Method: "ModifyNetworkInterface", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyNetworkInterface(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyObjectStorage(ctx context.Context, r *request.ModifyObjectStorageRequest) (*upcloud.ObjectStorageDetails, error) {
+	var res *upcloud.ObjectStorageDetails
+	err := s.fn(ctx, Call{Method: "ModifyObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyRouter(ctx context.Context, r *request.ModifyRouterRequest) (*upcloud.Router, error) {
+	var res *upcloud.Router
+	err := s.fn(ctx, Call{Method: "ModifyRouter", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyRouter(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyServer(ctx context.Context, r *request.ModifyServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "ModifyServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyServerGroup(ctx context.Context, r *request.ModifyServerGroupRequest) (*upcloud.ServerGroup, error) {
+	var res *upcloud.ServerGroup
+	err := s.fn(ctx, Call{Method: "ModifyServerGroup", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyServerGroup(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyStorage(ctx context.Context, r *request.ModifyStorageRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "ModifyStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifySubaccount(ctx context.Context, r *request.ModifySubaccountRequest) (*upcloud.AccountDetails, error) {
+	var res *upcloud.AccountDetails
+	err := s.fn(ctx, Call{Method: "ModifySubaccount", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifySubaccount(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ModifyTag(ctx context.Context, r *request.ModifyTagRequest) (*upcloud.Tag, error) {
+	var res *upcloud.Tag
+	err := s.fn(ctx, Call{Method: "ModifyTag", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ModifyTag(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) PreflightCreateServer(ctx context.Context, r *request.CreateServerRequest) error {
+	return s.fn(ctx, Call{Method: "PreflightCreateServer", Request: r}, func(ctx context.Context) error {
+		return s.next.PreflightCreateServer(ctx, r)
+	})
+}
+
+func (s *interceptedService) PreflightModifyServer(ctx context.Context, r *request.ModifyServerRequest) error {
+	return s.fn(ctx, Call{Method: "PreflightModifyServer", Request: r}, func(ctx context.Context) error {
+		return s.next.PreflightModifyServer(ctx, r)
+	})
+}
+
+func (s *interceptedService) PreflightModifyStorage(ctx context.Context, r *request.ModifyStorageRequest) error {
+	return s.fn(ctx, Call{Method: "PreflightModifyStorage", Request: r}, func(ctx context.Context) error {
+		return s.next.PreflightModifyStorage(ctx, r)
+	})
+}
+
+func (s *interceptedService) ReleaseAllIPAddresses(ctx context.Context, r *request.ReleaseAllIPAddressesRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "ReleaseAllIPAddresses", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ReleaseAllIPAddresses(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ReleaseIPAddress(ctx context.Context, r *request.ReleaseIPAddressRequest) error {
+	return s.fn(ctx, Call{Method: "ReleaseIPAddress", Request: r}, func(ctx context.Context) error {
+		return s.next.ReleaseIPAddress(ctx, r)
+	})
+}
+
+func (s *interceptedService) RemoveServerFromServerGroup(ctx context.Context, r *request.RemoveServerFromServerGroupRequest) error {
+	return s.fn(ctx, Call{Method: "RemoveServerFromServerGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.RemoveServerFromServerGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) RenameTag(ctx context.Context, r *request.RenameTagRequest) (*upcloud.Tag, error) {
+	var res *upcloud.Tag
+	err := s.fn(ctx, Call{Method: "RenameTag", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.RenameTag(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ReorderLoadBalancerFrontendRules(ctx context.Context, r *request.ReorderLoadBalancerFrontendRulesRequest) ([]upcloud.LoadBalancerFrontendRule, error) {
+	var res []upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "ReorderLoadBalancerFrontendRules", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ReorderLoadBalancerFrontendRules(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ReplaceLoadBalancerFrontendRule(ctx context.Context, r *request.ReplaceLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error) {
+	var res *upcloud.LoadBalancerFrontendRule
+	err := s.fn(ctx, Call{Method: "ReplaceLoadBalancerFrontendRule", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ReplaceLoadBalancerFrontendRule(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ReplaceManagedObjectStorage(ctx context.Context, r *request.ReplaceManagedObjectStorageRequest) (*upcloud.ManagedObjectStorage, error) {
+	var res *upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "ReplaceManagedObjectStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ReplaceManagedObjectStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) RescueBoot(ctx context.Context, r *request.RescueBootRequest) (*upcloud.RescueSession, error) {
+	var res *upcloud.RescueSession
+	err := s.fn(ctx, Call{Method: "RescueBoot", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.RescueBoot(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ResizeStorageFilesystem(ctx context.Context, r *request.ResizeStorageFilesystemRequest) (*upcloud.ResizeStorageFilesystemBackup, error) {
+	var res *upcloud.ResizeStorageFilesystemBackup
+	err := s.fn(ctx, Call{Method: "ResizeStorageFilesystem", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ResizeStorageFilesystem(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) RestartServer(ctx context.Context, r *request.RestartServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "RestartServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.RestartServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) RestoreBackup(ctx context.Context, r *request.RestoreBackupRequest) error {
+	return s.fn(ctx, Call{Method: "RestoreBackup", Request: r}, func(ctx context.Context) error {
+		return s.next.RestoreBackup(ctx, r)
+	})
+}
+
+func (s *interceptedService) RestoreBackupGroup(ctx context.Context, r *request.RestoreBackupGroupRequest) error {
+	return s.fn(ctx, Call{Method: "RestoreBackupGroup", Request: r}, func(ctx context.Context) error {
+		return s.next.RestoreBackupGroup(ctx, r)
+	})
+}
+
+func (s *interceptedService) RevokePermission(ctx context.Context, r *request.RevokePermissionRequest) error {
+	return s.fn(ctx, Call{Method: "RevokePermission", Request: r}, func(ctx context.Context) error {
+		return s.next.RevokePermission(ctx, r)
+	})
+}
+
+func (s *interceptedService) SetPTRRecords(ctx context.Context, r *request.SetPTRRecordsRequest) ([]PTRRecordResult, error) {
+	var res []PTRRecordResult
+	err := s.fn(ctx, Call{Method: "SetPTRRecords", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.SetPTRRecords(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) ShutdownManagedDatabase(ctx context.Context, r *request.ShutdownManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "ShutdownManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.ShutdownManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) StartManagedDatabase(ctx context.Context, r *request.StartManagedDatabaseRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "StartManagedDatabase", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.StartManagedDatabase(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) StartServer(ctx context.Context, r *request.StartServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "StartServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.StartServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) StopServer(ctx context.Context, r *request.StopServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "StopServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.StopServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) TagServer(ctx context.Context, r *request.TagServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "TagServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.TagServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) TemplatizeStorage(ctx context.Context, r *request.TemplatizeStorageRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "TemplatizeStorage", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.TemplatizeStorage(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) TemplatizeStorageOperation(ctx context.Context, r *request.TemplatizeStorageRequest) (*Operation[upcloud.StorageDetails], error) {
+	var res *Operation[upcloud.StorageDetails]
+	err := s.fn(ctx, Call{Method: "TemplatizeStorageOperation", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.TemplatizeStorageOperation(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) UntagServer(ctx context.Context, r *request.UntagServerRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "UntagServer", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.UntagServer(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) UpgradeManagedDatabaseVersion(ctx context.Context, r *request.UpgradeManagedDatabaseVersionRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "UpgradeManagedDatabaseVersion", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.UpgradeManagedDatabaseVersion(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForGatewayConnectionTunnelOperationalState(ctx context.Context, r *request.WaitForGatewayConnectionTunnelOperationalStateRequest) (*upcloud.GatewayTunnel, error) {
+	var res *upcloud.GatewayTunnel
+	err := s.fn(ctx, Call{Method: "WaitForGatewayConnectionTunnelOperationalState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForGatewayConnectionTunnelOperationalState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForGatewayOperationalState(ctx context.Context, r *request.WaitForGatewayOperationalStateRequest) (*upcloud.Gateway, error) {
+	var res *upcloud.Gateway
+	err := s.fn(ctx, Call{Method: "WaitForGatewayOperationalState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForGatewayOperationalState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForKubernetesClusterState(ctx context.Context, r *request.WaitForKubernetesClusterStateRequest) (*upcloud.KubernetesCluster, error) {
+	var res *upcloud.KubernetesCluster
+	err := s.fn(ctx, Call{Method: "WaitForKubernetesClusterState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForKubernetesClusterState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForKubernetesNodeGroupNodeCount(ctx context.Context, r *request.WaitForKubernetesNodeGroupNodeCountRequest) (*upcloud.KubernetesNodeGroupDetails, error) {
+	var res *upcloud.KubernetesNodeGroupDetails
+	err := s.fn(ctx, Call{Method: "WaitForKubernetesNodeGroupNodeCount", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForKubernetesNodeGroupNodeCount(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForKubernetesNodeGroupState(ctx context.Context, r *request.WaitForKubernetesNodeGroupStateRequest) (*upcloud.KubernetesNodeGroup, error) {
+	var res *upcloud.KubernetesNodeGroup
+	err := s.fn(ctx, Call{Method: "WaitForKubernetesNodeGroupState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForKubernetesNodeGroupState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForLoadBalancerCertificateBundleOperationalState(ctx context.Context, r *request.WaitForLoadBalancerCertificateBundleOperationalStateRequest) (*upcloud.LoadBalancerCertificateBundle, error) {
+	var res *upcloud.LoadBalancerCertificateBundle
+	err := s.fn(ctx, Call{Method: "WaitForLoadBalancerCertificateBundleOperationalState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForLoadBalancerCertificateBundleOperationalState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForManagedDatabaseState(ctx context.Context, r *request.WaitForManagedDatabaseStateRequest) (*upcloud.ManagedDatabase, error) {
+	var res *upcloud.ManagedDatabase
+	err := s.fn(ctx, Call{Method: "WaitForManagedDatabaseState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForManagedDatabaseState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForManagedObjectStorageDeletion(ctx context.Context, r *request.WaitForManagedObjectStorageDeletionRequest) error {
+	return s.fn(ctx, Call{Method: "WaitForManagedObjectStorageDeletion", Request: r}, func(ctx context.Context) error {
+		return s.next.WaitForManagedObjectStorageDeletion(ctx, r)
+	})
+}
+
+func (s *interceptedService) WaitForManagedObjectStorageOperationalState(ctx context.Context, r *request.WaitForManagedObjectStorageOperationalStateRequest) (*upcloud.ManagedObjectStorage, error) {
+	var res *upcloud.ManagedObjectStorage
+	err := s.fn(ctx, Call{Method: "WaitForManagedObjectStorageOperationalState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForManagedObjectStorageOperationalState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForServerState(ctx context.Context, r *request.WaitForServerStateRequest) (*upcloud.ServerDetails, error) {
+	var res *upcloud.ServerDetails
+	err := s.fn(ctx, Call{Method: "WaitForServerState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForServerState(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForStorageImportCompletion(ctx context.Context, r *request.WaitForStorageImportCompletionRequest) (*upcloud.StorageImportDetails, error) {
+	var res *upcloud.StorageImportDetails
+	err := s.fn(ctx, Call{Method: "WaitForStorageImportCompletion", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForStorageImportCompletion(ctx, r)
+		return err
+	})
+	return res, err
+}
+
+func (s *interceptedService) WaitForStorageState(ctx context.Context, r *request.WaitForStorageStateRequest) (*upcloud.StorageDetails, error) {
+	var res *upcloud.StorageDetails
+	err := s.fn(ctx, Call{Method: "WaitForStorageState", Request: r, Result: &res}, func(ctx context.Context) (err error) {
+		res, err = s.next.WaitForStorageState(ctx, r)
+		return err
+	})
+	return res, err
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+type Inventory interface {
+	GetInventory(ctx context.Context, r *request.GetInventoryRequest) (*upcloud.Inventory, error)
+}
+
+// GetInventory gathers the servers, private storages, IP addresses, networks and tags of the account into a single
+// snapshot. The listings are fetched concurrently and the first error cancels the remaining requests. Storages, IP
+// addresses and servers are filtered by zone after they have been retrieved.
+func (s *Service) GetInventory(ctx context.Context, r *request.GetInventoryRequest) (*upcloud.Inventory, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	inventory := upcloud.Inventory{
+		Zone:      r.Zone,
+		StartedAt: time.Now(),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	fetch := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch(func() error {
+		servers, err := s.GetServers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, server := range servers.Servers {
+			if r.Zone == "" || server.Zone == r.Zone {
+				inventory.Servers = append(inventory.Servers, server)
+			}
+		}
+		return nil
+	})
+	fetch(func() error {
+		storages, err := s.GetStorages(ctx, &request.GetStoragesRequest{Access: upcloud.StorageAccessPrivate, Zone: r.Zone})
+		if err != nil {
+			return err
+		}
+		inventory.Storages = storages.Storages
+		return nil
+	})
+	fetch(func() error {
+		addresses, err := s.GetIPAddresses(ctx)
+		if err != nil {
+			return err
+		}
+		for _, address := range addresses.IPAddresses {
+			if r.Zone == "" || address.Zone == r.Zone {
+				inventory.IPAddresses = append(inventory.IPAddresses, address)
+			}
+		}
+		return nil
+	})
+	fetch(func() error {
+		var networks *upcloud.Networks
+		var err error
+		if r.Zone != "" {
+			networks, err = s.GetNetworksInZone(ctx, &request.GetNetworksInZoneRequest{Zone: r.Zone})
+		} else {
+			networks, err = s.GetNetworks(ctx)
+		}
+		if err != nil {
+			return err
+		}
+		inventory.Networks = networks.Networks
+		return nil
+	})
+	fetch(func() error {
+		tags, err := s.GetTags(ctx)
+		if err != nil {
+			return err
+		}
+		inventory.Tags = tags.Tags
+		return nil
+	})
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	inventory.CompletedAt = time.Now()
+	return &inventory, nil
+}
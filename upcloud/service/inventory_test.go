@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInventory(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[{"uuid":"s1","zone":"fi-hel1"},{"uuid":"s2","zone":"de-fra1"}]}}`)
+	m.On(http.MethodGet, "/storage/private").Reply(http.StatusOK, `{"storages":{"storage":[{"uuid":"d1","zone":"fi-hel1"},{"uuid":"d2","zone":"de-fra1"}]}}`)
+	m.On(http.MethodGet, "/ip_address").Reply(http.StatusOK, `{"ip_addresses":{"ip_address":[{"address":"94.237.0.1","server":"s1","zone":"fi-hel1"},{"address":"94.237.0.2","server":"s2","zone":"de-fra1"}]}}`)
+	m.On(http.MethodGet, "/network/?zone=fi-hel1").Reply(http.StatusOK, `{"networks":{"network":[{"uuid":"n1","zone":"fi-hel1"}]}}`)
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[{"name":"prod","servers":{"server":["s1","s2"]}}]}}`)
+
+	inventory, err := svc.GetInventory(context.Background(), &request.GetInventoryRequest{Zone: "fi-hel1"})
+	require.NoError(t, err)
+	assert.Equal(t, "fi-hel1", inventory.Zone)
+	assert.Equal(t, []upcloud.Server{{UUID: "s1", Zone: "fi-hel1"}}, inventory.Servers)
+	require.Len(t, inventory.Storages, 1)
+	assert.Equal(t, "d1", inventory.Storages[0].UUID)
+	require.Len(t, inventory.IPAddresses, 1)
+	assert.Equal(t, "94.237.0.1", inventory.IPAddresses[0].Address)
+	require.Len(t, inventory.Networks, 1)
+	require.Len(t, inventory.Tags, 1)
+	assert.False(t, inventory.CompletedAt.Before(inventory.StartedAt))
+	m.AssertExpectations(t)
+}
+
+func TestGetInventory_error(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[]}}`)
+	m.On(http.MethodGet, "/storage/private").ReplyError(http.StatusForbidden, upcloud.ErrCodeActionForbidden, "no access")
+	m.On(http.MethodGet, "/ip_address").Reply(http.StatusOK, `{"ip_addresses":{"ip_address":[]}}`)
+	m.On(http.MethodGet, "/network").Reply(http.StatusOK, `{"networks":{"network":[]}}`)
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[]}}`)
+
+	_, err := svc.GetInventory(context.Background(), &request.GetInventoryRequest{})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, http.StatusForbidden, problem.Status)
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
@@ -13,6 +17,50 @@ type IPAddress interface {
 	AssignIPAddress(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error)
 	ModifyIPAddress(ctx context.Context, r *request.ModifyIPAddressRequest) (*upcloud.IPAddress, error)
 	ReleaseIPAddress(ctx context.Context, r *request.ReleaseIPAddressRequest) error
+	ReleaseAllIPAddresses(ctx context.Context, r *request.ReleaseAllIPAddressesRequest) (*upcloud.ServerDetails, error)
+	AssignPublicIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error)
+	AssignPublicIPv6(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error)
+	AssignPrivateIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error)
+	GetIPAddressesWithoutPTR(ctx context.Context) (upcloud.IPAddressSlice, error)
+	SetPTRRecords(ctx context.Context, r *request.SetPTRRecordsRequest) ([]PTRRecordResult, error)
+}
+
+// defaultPTRConcurrency is the number of PTR records SetPTRRecords sets at the same time by default
+const defaultPTRConcurrency = 4
+
+// PTRRecordResult is the outcome of setting the PTR record of an IP address with SetPTRRecords
+type PTRRecordResult struct {
+	Address   string
+	PTRRecord string
+	// IPAddress is the modified address, or nil if setting the record failed
+	IPAddress *upcloud.IPAddress
+	Err       error
+}
+
+// defaultIPAddressLimitPerServer is the number of addresses of each family and access type that can be assigned to a
+// server by default
+const defaultIPAddressLimitPerServer = 5
+
+// WithIPAddressLimitPerServer sets the maximum number of addresses of each family and access type that can be assigned
+// to a server, checked by AssignPublicIPv4, AssignPublicIPv6 and AssignPrivateIPv4. The limit defaults to five. Zero
+// disables the check and leaves enforcing the limit to the API.
+func WithIPAddressLimitPerServer(limit int) ConfigFn {
+	return func(c *config) {
+		c.ipAddressLimitPerServer = limit
+	}
+}
+
+// IPAddressQuotaError is returned by the address assignment helpers when the server already has the maximum number of
+// addresses of the requested family and access type
+type IPAddressQuotaError struct {
+	ServerUUID string
+	Family     string
+	Access     string
+	Limit      int
+}
+
+func (e *IPAddressQuotaError) Error() string {
+	return fmt.Sprintf("server %s already has the maximum of %d %s %s addresses", e.ServerUUID, e.Limit, e.Access, e.Family)
 }
 
 // GetIPAddresses returns all IP addresses associated with the account
@@ -43,3 +91,144 @@ func (s *Service) ModifyIPAddress(ctx context.Context, r *request.ModifyIPAddres
 func (s *Service) ReleaseIPAddress(ctx context.Context, r *request.ReleaseIPAddressRequest) error {
 	return s.delete(ctx, r)
 }
+
+// ReleaseAllIPAddresses releases the additional public IP addresses of the server. The first public IPv4 and IPv6
+// addresses, which are created with the server, are kept, as are floating IP addresses, utility and private
+// addresses. Addresses are released one at a time, waiting for the server to leave maintenance state between the
+// operations, and the server details after the last operation are returned.
+func (s *Service) ReleaseAllIPAddresses(ctx context.Context, r *request.ReleaseAllIPAddressesRequest) (*upcloud.ServerDetails, error) {
+	details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool)
+	for _, address := range details.IPAddresses {
+		if address.Access != upcloud.IPAddressAccessPublic || address.Floating.Bool() {
+			continue
+		}
+		if !kept[address.Family] {
+			kept[address.Family] = true
+			continue
+		}
+		details, err = s.waitOutMaintenance(ctx, r.ServerUUID, func() (*upcloud.ServerDetails, error) {
+			if err := s.ReleaseIPAddress(ctx, &request.ReleaseIPAddressRequest{IPAddress: address.Address}); err != nil {
+				return nil, err
+			}
+			return s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("releasing IP address %s: %w", address.Address, err)
+		}
+	}
+	return details, nil
+}
+
+// AssignPublicIPv4 assigns a public IPv4 address to the server of the request, see assignServerIPAddress
+func (s *Service) AssignPublicIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	return s.assignServerIPAddress(ctx, r, upcloud.IPAddressFamilyIPv4, upcloud.IPAddressAccessPublic)
+}
+
+// AssignPublicIPv6 assigns a public IPv6 address to the server of the request, see assignServerIPAddress
+func (s *Service) AssignPublicIPv6(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	return s.assignServerIPAddress(ctx, r, upcloud.IPAddressFamilyIPv6, upcloud.IPAddressAccessPublic)
+}
+
+// AssignPrivateIPv4 assigns a private IPv4 address to the server of the request, see assignServerIPAddress
+func (s *Service) AssignPrivateIPv4(ctx context.Context, r *request.AssignIPAddressRequest) (*upcloud.IPAddress, error) {
+	return s.assignServerIPAddress(ctx, r, upcloud.IPAddressFamilyIPv4, upcloud.IPAddressAccessPrivate)
+}
+
+// assignServerIPAddress assigns an address of the family and access type. The family and access of the request are
+// overridden. If the request has a server, its addresses are checked first and *IPAddressQuotaError is returned if
+// the server is at the limit set with WithIPAddressLimitPerServer. Floating addresses are not counted. The zone of floating addresses defaults to the zone of the server.
+func (s *Service) assignServerIPAddress(ctx context.Context, r *request.AssignIPAddressRequest, family, access string) (*upcloud.IPAddress, error) {
+	assign := *r
+	assign.Family = family
+	assign.Access = access
+
+	if assign.ServerUUID != "" {
+		details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: assign.ServerUUID})
+		if err != nil {
+			return nil, err
+		}
+		var count int
+		for _, address := range details.IPAddresses.Filter(family, access) {
+			if !address.Floating.Bool() {
+				count++
+			}
+		}
+		if limit := s.config.ipAddressLimitPerServer; limit > 0 && count >= limit && !assign.Floating.Bool() {
+			return nil, &IPAddressQuotaError{ServerUUID: assign.ServerUUID, Family: family, Access: access, Limit: limit}
+		}
+		if assign.Floating.Bool() && assign.Zone == "" && assign.MAC == "" {
+			assign.Zone = details.Zone
+		}
+	}
+	return s.AssignIPAddress(ctx, &assign)
+}
+
+// GetIPAddressesWithoutPTR returns the public IP addresses of the account that do not have a PTR record
+func (s *Service) GetIPAddressesWithoutPTR(ctx context.Context) (upcloud.IPAddressSlice, error) {
+	addresses, err := s.GetIPAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var missing upcloud.IPAddressSlice
+	for _, address := range upcloud.IPAddressSlice(addresses.IPAddresses).Filter("", upcloud.IPAddressAccessPublic) {
+		if address.PTRRecord == "" {
+			missing = append(missing, address)
+		}
+	}
+	return missing, nil
+}
+
+// SetPTRRecords sets the PTR records of the addresses in the request concurrently. A result is returned for each
+// address, sorted by address, and the errors of the failed addresses are also returned joined. Addresses that have not
+// been started when the context is done are not modified.
+func (s *Service) SetPTRRecords(ctx context.Context, r *request.SetPTRRecordsRequest) ([]PTRRecordResult, error) {
+	results := make([]PTRRecordResult, 0, len(r.Records))
+	for address, ptr := range r.Records {
+		results = append(results, PTRRecordResult{Address: address, PTRRecord: ptr})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Address < results[j].Address })
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPTRConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// Records that were not started yet fail with the context error
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(result *PTRRecordResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.IPAddress, result.Err = s.ModifyIPAddress(ctx, &request.ModifyIPAddressRequest{
+				IPAddress: result.Address,
+				PTRRecord: result.PTRRecord,
+			})
+			if result.Err != nil {
+				result.IPAddress = nil
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("setting PTR record of %s: %w", result.Address, result.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
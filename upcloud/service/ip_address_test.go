@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
@@ -222,3 +225,135 @@ func TestAttachModifyReleaseFloatingIPAddress(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestReleaseAllIPAddresses(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped","ip_addresses":{"ip_address":[
+		{"access":"utility","address":"10.0.0.1","family":"IPv4"},
+		{"access":"public","address":"94.237.0.1","family":"IPv4"},
+		{"access":"public","address":"2a04:3540::1","family":"IPv6"},
+		{"access":"public","address":"94.237.0.2","family":"IPv4"},
+		{"access":"public","address":"94.237.0.3","family":"IPv4","floating":"yes"},
+		{"access":"public","address":"2a04:3540::2","family":"IPv6"}
+	]}}}`)
+	m.On(http.MethodDelete, "/ip_address/94.237.0.2").Reply(http.StatusNoContent, "")
+	m.On(http.MethodDelete, "/ip_address/2a04:3540::2").Reply(http.StatusNoContent, "")
+
+	_, err := svc.ReleaseAllIPAddresses(context.Background(), &request.ReleaseAllIPAddressesRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestAssignServerIPAddress(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","zone":"fi-hel1","ip_addresses":{"ip_address":[
+		{"access":"public","address":"94.237.0.1","family":"IPv4"},
+		{"access":"public","address":"94.237.0.2","family":"IPv4"},
+		{"access":"public","address":"94.237.0.3","family":"IPv4"},
+		{"access":"public","address":"94.237.0.4","family":"IPv4"},
+		{"access":"public","address":"94.237.0.5","family":"IPv4"},
+		{"access":"public","address":"94.237.0.6","family":"IPv4","floating":"yes"},
+		{"access":"public","address":"2a04:3540::1","family":"IPv6"}
+	]}}}`)
+	m.On(http.MethodPost, "/ip_address").Reply(http.StatusCreated, `{"ip_address":{"address":"2a04:3540::2"}}`)
+
+	ip, err := svc.AssignPublicIPv6(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, "2a04:3540::2", ip.Address)
+
+	_, err = svc.AssignPublicIPv4(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid"})
+	var quotaErr *IPAddressQuotaError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.EqualError(t, err, "server uuid already has the maximum of 5 public IPv4 addresses")
+
+	_, err = svc.AssignPublicIPv4(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid", Floating: upcloud.True})
+	require.NoError(t, err)
+	_, err = svc.AssignPrivateIPv4(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid", Family: upcloud.IPAddressFamilyIPv6})
+	require.NoError(t, err)
+
+	var bodies []string
+	for _, call := range m.Calls() {
+		if call.Method == http.MethodPost {
+			bodies = append(bodies, string(call.Body))
+		}
+	}
+	require.Len(t, bodies, 3)
+	assert.JSONEq(t, `{"ip_address":{"access":"public","family":"IPv6","server":"uuid"}}`, bodies[0])
+	assert.JSONEq(t, `{"ip_address":{"access":"public","family":"IPv4","server":"uuid","floating":"yes","zone":"fi-hel1"}}`, bodies[1])
+	assert.JSONEq(t, `{"ip_address":{"access":"private","family":"IPv4","server":"uuid"}}`, bodies[2])
+}
+
+func TestAssignServerIPAddress_limit(t *testing.T) {
+	t.Parallel()
+
+	server := `{"server":{"uuid":"uuid","ip_addresses":{"ip_address":[
+		{"access":"public","address":"94.237.0.1","family":"IPv4"},
+		{"access":"public","address":"94.237.0.2","family":"IPv4"}
+	]}}}`
+	m, svc := setupMockTransportAndService(WithIPAddressLimitPerServer(2))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, server)
+	_, err := svc.AssignPublicIPv4(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid"})
+	assert.EqualError(t, err, "server uuid already has the maximum of 2 public IPv4 addresses")
+
+	// Zero limit leaves the check to the API
+	m, svc = setupMockTransportAndService(WithIPAddressLimitPerServer(0))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, server)
+	m.On(http.MethodPost, "/ip_address").Reply(http.StatusCreated, `{"ip_address":{"address":"94.237.0.3"}}`)
+	_, err = svc.AssignPublicIPv4(context.Background(), &request.AssignIPAddressRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+}
+
+func TestPTRRecords(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/ip_address").Reply(http.StatusOK, `{"ip_addresses":{"ip_address":[
+		{"access":"public","address":"94.237.0.1","family":"IPv4","ptr_record":"mail.example.com"},
+		{"access":"public","address":"94.237.0.2","family":"IPv4"},
+		{"access":"utility","address":"10.0.0.1","family":"IPv4"},
+		{"access":"public","address":"2a04:3540::1","family":"IPv6"}
+	]}}`)
+	m.On(http.MethodPatch, "/ip_address/94.237.0.2").Reply(http.StatusAccepted, `{"ip_address":{"address":"94.237.0.2","ptr_record":"mx.example.com"}}`)
+	m.On(http.MethodPatch, "/ip_address/2a04:3540::1").ReplyError(http.StatusBadRequest, upcloud.ErrCodeInvalidRequest, "invalid PTR record")
+
+	missing, err := svc.GetIPAddressesWithoutPTR(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"94.237.0.2", "2a04:3540::1"}, missing.AllAddresses("", ""))
+
+	results, err := svc.SetPTRRecords(context.Background(), &request.SetPTRRecordsRequest{
+		Records: map[string]string{"94.237.0.2": "mx.example.com", "2a04:3540::1": "invalid"},
+	})
+	assert.ErrorContains(t, err, "setting PTR record of 2a04:3540::1")
+	require.Len(t, results, 2)
+	assert.Equal(t, "2a04:3540::1", results[0].Address)
+	assert.Nil(t, results[0].IPAddress)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, "94.237.0.2", results[1].Address)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "mx.example.com", results[1].IPAddress.PTRRecord)
+}
+
+func TestSetPTRRecords_cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	svc := New(client.New("user", "pass", client.WithHTTPClient(&http.Client{Transport: &customRoundTripper{fn: func(r *http.Request) (*http.Response, error) {
+		// The first request cancels the context and keeps its slot while the remaining records are given up on
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		return nil, r.Context().Err()
+	}}})))
+	results, err := svc.SetPTRRecords(ctx, &request.SetPTRRecordsRequest{
+		Records:     map[string]string{"94.237.0.1": "a.example.com", "94.237.0.2": "b.example.com", "94.237.0.3": "c.example.com"},
+		Concurrency: 1,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
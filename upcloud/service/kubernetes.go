@@ -25,6 +25,7 @@ type Kubernetes interface {
 	CreateKubernetesNodeGroup(ctx context.Context, r *request.CreateKubernetesNodeGroupRequest) (*upcloud.KubernetesNodeGroup, error)
 	ModifyKubernetesNodeGroup(ctx context.Context, r *request.ModifyKubernetesNodeGroupRequest) (*upcloud.KubernetesNodeGroup, error)
 	WaitForKubernetesNodeGroupState(ctx context.Context, r *request.WaitForKubernetesNodeGroupStateRequest) (*upcloud.KubernetesNodeGroup, error)
+	WaitForKubernetesNodeGroupNodeCount(ctx context.Context, r *request.WaitForKubernetesNodeGroupNodeCountRequest) (*upcloud.KubernetesNodeGroupDetails, error)
 	DeleteKubernetesNodeGroup(ctx context.Context, r *request.DeleteKubernetesNodeGroupRequest) error
 	DeleteKubernetesNodeGroupNode(ctx context.Context, r *request.DeleteKubernetesNodeGroupNodeRequest) error
 	GetKubernetesPlans(ctx context.Context, r *request.GetKubernetesPlansRequest) ([]upcloud.KubernetesPlan, error)
@@ -94,7 +95,7 @@ func (s *Service) WaitForKubernetesClusterState(ctx context.Context, r *request.
 			return details, nil
 		}
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
 }
 
 // WaitForKubernetesNodeGroupState blocks execution until the specified Kubernetes node group has entered the
@@ -120,10 +121,41 @@ func (s *Service) WaitForKubernetesNodeGroupState(ctx context.Context, r *reques
 			return &ng.KubernetesNodeGroup, nil
 		}
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
 }
 
-// GetKubernetesKubeconfig retrieves kubeconfig of a Kubernetes cluster.
+// WaitForKubernetesNodeGroupNodeCount blocks execution until the specified Kubernetes node group has the desired
+// number of running nodes and no other nodes. If the count is reached, node group details is returned. The method will
+// give up after the specified timeout, in which case the error contains the states of the nodes seen last.
+func (s *Service) WaitForKubernetesNodeGroupNodeCount(ctx context.Context, r *request.WaitForKubernetesNodeGroupNodeCountRequest) (*upcloud.KubernetesNodeGroupDetails, error) {
+	var last *upcloud.KubernetesNodeGroupDetails
+	ng, err := retry(ctx, func(_ int, c context.Context) (*upcloud.KubernetesNodeGroupDetails, error) {
+		ng, err := s.GetKubernetesNodeGroup(c, &request.GetKubernetesNodeGroupRequest{
+			ClusterUUID: r.ClusterUUID,
+			Name:        r.Name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		last = ng
+
+		count := r.Count
+		if count == 0 {
+			count = ng.Count
+		}
+		if len(ng.Nodes) == count && len(ng.RunningNodes()) == count {
+			return ng, nil
+		}
+		return nil, nil
+	}, s.retryConfig())
+	if err != nil && last != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("node group %s has %d running nodes, node states %v: %w", r.Name, len(last.RunningNodes()), last.NodeStates(), err)
+	}
+	return ng, err
+}
+
+// GetKubernetesKubeconfig retrieves kubeconfig of a Kubernetes cluster. Use kubeconfig.MergeFile to merge it into an
+// existing kubeconfig file.
 func (s *Service) GetKubernetesKubeconfig(ctx context.Context, r *request.GetKubernetesKubeconfigRequest) (string, error) {
 	data := struct {
 		Kubeconfig string `json:"kubeconfig"`
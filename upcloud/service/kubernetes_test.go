@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
@@ -369,6 +370,36 @@ func TestGetKubernetesNodeGroupDetails(t *testing.T) {
 	require.Equal(t, upcloud.KubernetesNodeStateTerminating, res.Nodes[1].State)
 }
 
+func TestWaitForKubernetesNodeGroupNodeCount(t *testing.T) {
+	t.Parallel()
+
+	const path = "/kubernetes/_UUID_/node-groups/grp-1"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, path).Reply(http.StatusOK, exampleNodeGroupDetailsResponse).Once()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"count":2,"name":"grp-1","state":"running","nodes":[{"name":"grp-1-7l7zj","state":"running"},{"name":"grp-1-glkwv","state":"running"}]}`)
+
+	ng, err := svc.WaitForKubernetesNodeGroupNodeCount(context.Background(), &request.WaitForKubernetesNodeGroupNodeCountRequest{
+		ClusterUUID: "_UUID_",
+		Name:        "grp-1",
+	})
+	require.NoError(t, err)
+	assert.Len(t, ng.RunningNodes(), 2)
+	assert.Equal(t, 2, m.Called(http.MethodGet, path))
+
+	// Timeout error reports the states of the nodes
+	m, svc = setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, path).Reply(http.StatusOK, exampleNodeGroupDetailsResponse)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = svc.WaitForKubernetesNodeGroupNodeCount(ctx, &request.WaitForKubernetesNodeGroupNodeCountRequest{
+		ClusterUUID: "_UUID_",
+		Name:        "grp-1",
+		Count:       1,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "node group grp-1 has 1 running nodes, node states map[running:1 terminating:1]")
+}
+
 func TestCreateKubernetesNodeGroup(t *testing.T) {
 	t.Parallel()
 
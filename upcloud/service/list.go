@@ -0,0 +1,180 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// Sort keys of ListOptions. Prefix a key with "-" to sort in descending order, e.g. "-name".
+const (
+	// ListSortName sorts by the title of servers and storages and the name of the other resources
+	ListSortName = "name"
+	ListSortZone = "zone"
+)
+
+// Listable is the set of resource types that can be listed with List
+type Listable interface {
+	upcloud.Server | upcloud.Storage | upcloud.Network | upcloud.LoadBalancer
+}
+
+// ListOptions are the options common to listing all resource types with List
+type ListOptions struct {
+	// Zone selects the resources in the zone
+	Zone string
+	// Labels selects the resources that have all the labels
+	Labels []upcloud.Label
+	// Sort is the key the resources are sorted by, see ListSortName and ListSortZone. The order of the API is kept
+	// if empty.
+	Sort string
+	// Page selects a page of the selected and sorted resources. All resources are returned if nil. The resources are
+	// paged by the SDK after all of them have been retrieved from the API, so that the pages are sorted as a whole.
+	Page *request.Page
+}
+
+// listFields describes how the common options apply to a resource type
+type listFields[T Listable] struct {
+	list func(ctx context.Context, api ServiceAPI, opts ListOptions, filters []request.QueryFilter) ([]T, error)
+	name func(T) string
+	zone func(T) string
+}
+
+var serverListFields = listFields[upcloud.Server]{
+	list: func(ctx context.Context, api ServiceAPI, _ ListOptions, filters []request.QueryFilter) ([]upcloud.Server, error) {
+		servers, err := api.GetServersWithFilters(ctx, &request.GetServersWithFiltersRequest{Filters: filters})
+		if err != nil {
+			return nil, err
+		}
+		return servers.Servers, nil
+	},
+	name: func(s upcloud.Server) string { return s.Title },
+	zone: func(s upcloud.Server) string { return s.Zone },
+}
+
+var storageListFields = listFields[upcloud.Storage]{
+	list: func(ctx context.Context, api ServiceAPI, _ ListOptions, filters []request.QueryFilter) ([]upcloud.Storage, error) {
+		return listPages(func(page *request.Page) ([]upcloud.Storage, int, error) {
+			storages, err := api.GetStorages(ctx, &request.GetStoragesRequest{Page: page, Filters: filters})
+			if err != nil {
+				return nil, 0, err
+			}
+			return storages.Storages, len(storages.Storages), nil
+		})
+	},
+	name: func(s upcloud.Storage) string { return s.Title },
+	zone: func(s upcloud.Storage) string { return s.Zone },
+}
+
+var networkListFields = listFields[upcloud.Network]{
+	list: func(ctx context.Context, api ServiceAPI, opts ListOptions, filters []request.QueryFilter) ([]upcloud.Network, error) {
+		var networks *upcloud.Networks
+		var err error
+		if opts.Zone != "" {
+			networks, err = api.GetNetworksInZone(ctx, &request.GetNetworksInZoneRequest{Zone: opts.Zone, Filters: filters})
+		} else {
+			networks, err = api.GetNetworks(ctx, filters...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return networks.Networks, nil
+	},
+	name: func(n upcloud.Network) string { return n.Name },
+	zone: func(n upcloud.Network) string { return n.Zone },
+}
+
+var loadBalancerListFields = listFields[upcloud.LoadBalancer]{
+	list: func(ctx context.Context, api ServiceAPI, _ ListOptions, filters []request.QueryFilter) ([]upcloud.LoadBalancer, error) {
+		return listPages(func(page *request.Page) ([]upcloud.LoadBalancer, int, error) {
+			loadBalancers, err := api.GetLoadBalancers(ctx, &request.GetLoadBalancersRequest{Page: page, Filters: filters})
+			return loadBalancers, len(loadBalancers), err
+		})
+	},
+	name: func(lb upcloud.LoadBalancer) string { return lb.Name },
+	zone: func(lb upcloud.LoadBalancer) string { return lb.Zone },
+}
+
+// List lists the resources of type T, see Listable, with the common options. Labels are filtered by the API and all
+// pages of the API are retrieved; the resources are then filtered by zone, sorted and paged by the SDK, so that the
+// options behave the same for all resource types.
+//
+//	servers, err := service.List[upcloud.Server](ctx, svc, service.ListOptions{Zone: "fi-hel1", Sort: service.ListSortName})
+func List[T Listable](ctx context.Context, api ServiceAPI, opts ListOptions) ([]T, error) {
+	var items any
+	var err error
+	switch any((*T)(nil)).(type) {
+	case *upcloud.Server:
+		items, err = listResources(ctx, api, opts, serverListFields)
+	case *upcloud.Storage:
+		items, err = listResources(ctx, api, opts, storageListFields)
+	case *upcloud.Network:
+		items, err = listResources(ctx, api, opts, networkListFields)
+	case *upcloud.LoadBalancer:
+		items, err = listResources(ctx, api, opts, loadBalancerListFields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return items.([]T), nil
+}
+
+// listPages calls list for each page until a page is not full and returns the items of all pages. list returns the
+// items of the page and the number of items the API returned.
+func listPages[T any](list func(page *request.Page) (items []T, n int, err error)) ([]T, error) {
+	var all []T
+	for page := request.DefaultPage; ; page = page.Next() {
+		items, n, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if n < page.Size {
+			return all, nil
+		}
+	}
+}
+
+func listResources[T Listable](ctx context.Context, api ServiceAPI, opts ListOptions, fields listFields[T]) ([]T, error) {
+	key, desc := strings.CutPrefix(opts.Sort, "-")
+	var sortKey func(T) string
+	switch key {
+	case "":
+	case ListSortName:
+		sortKey = fields.name
+	case ListSortZone:
+		sortKey = fields.zone
+	default:
+		return nil, fmt.Errorf("invalid sort key %q", opts.Sort)
+	}
+
+	filters := make([]request.QueryFilter, 0, len(opts.Labels))
+	for _, label := range opts.Labels {
+		filters = append(filters, request.FilterLabel{Label: label})
+	}
+	items, err := fields.list(ctx, api, opts, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Zone != "" {
+		items = slices.DeleteFunc(items, func(item T) bool { return fields.zone(item) != opts.Zone })
+	}
+	if sortKey != nil {
+		slices.SortStableFunc(items, func(a, b T) int {
+			if desc {
+				return cmp.Compare(sortKey(b), sortKey(a))
+			}
+			return cmp.Compare(sortKey(a), sortKey(b))
+		})
+	}
+	if opts.Page != nil && opts.Page.Size > 0 {
+		offset := min(max(opts.Page.Number-1, 0)*opts.Page.Size, len(items))
+		items = items[offset:min(offset+opts.Page.Size, len(items))]
+	}
+	return items, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/?label=env%3Dprod").Reply(http.StatusOK, `{"servers":{"server":[
+		{"uuid":"a","title":"charlie","zone":"fi-hel1"},
+		{"uuid":"b","title":"alpha","zone":"de-fra1"},
+		{"uuid":"c","title":"bravo","zone":"fi-hel1"},
+		{"uuid":"d","title":"delta","zone":"fi-hel1"}
+	]}}`)
+	m.On(http.MethodGet, "/storage?limit=100&offset=0").Reply(http.StatusOK, `{"storages":{"storage":[
+		{"uuid":"s1","title":"disk","zone":"fi-hel1"}
+	]}}`)
+	m.On(http.MethodGet, "/network/?zone=fi-hel1").Reply(http.StatusOK, `{"networks":{"network":[
+		{"uuid":"n1","name":"net","zone":"fi-hel1"}
+	]}}`)
+	m.On(http.MethodGet, "/load-balancer?limit=100&offset=0").Reply(http.StatusOK, `[
+		{"uuid":"lb1","name":"web","zone":"fi-hel1"},
+		{"uuid":"lb2","name":"api","zone":"de-fra1"}
+	]`)
+
+	uuids := func(servers []upcloud.Server) []string {
+		var uuids []string
+		for _, server := range servers {
+			uuids = append(uuids, server.UUID)
+		}
+		return uuids
+	}
+	labels := []upcloud.Label{{Key: "env", Value: "prod"}}
+
+	servers, err := List[upcloud.Server](context.Background(), svc, ListOptions{Labels: labels})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, uuids(servers))
+
+	servers, err = List[upcloud.Server](context.Background(), svc, ListOptions{Zone: "fi-hel1", Labels: labels, Sort: ListSortName})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "d"}, uuids(servers))
+
+	servers, err = List[upcloud.Server](context.Background(), svc, ListOptions{
+		Labels: labels,
+		Sort:   "-" + ListSortName,
+		Page:   &request.Page{Size: 3, Number: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, uuids(servers))
+
+	servers, err = List[upcloud.Server](context.Background(), svc, ListOptions{Labels: labels, Page: &request.Page{Size: 3, Number: 3}})
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+
+	storages, err := List[upcloud.Storage](context.Background(), svc, ListOptions{Zone: "fi-hel1"})
+	require.NoError(t, err)
+	require.Len(t, storages, 1)
+	assert.Equal(t, "s1", storages[0].UUID)
+
+	networks, err := List[upcloud.Network](context.Background(), svc, ListOptions{Zone: "fi-hel1"})
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.Equal(t, "n1", networks[0].UUID)
+
+	loadBalancers, err := List[upcloud.LoadBalancer](context.Background(), svc, ListOptions{Sort: ListSortZone})
+	require.NoError(t, err)
+	require.Len(t, loadBalancers, 2)
+	assert.Equal(t, "lb2", loadBalancers[0].UUID)
+
+	_, err = List[upcloud.Server](context.Background(), svc, ListOptions{Sort: "created"})
+	assert.EqualError(t, err, `invalid sort key "created"`)
+	m.AssertExpectations(t)
+}
+
+func TestList_allPages(t *testing.T) {
+	t.Parallel()
+
+	loadBalancers := make([]string, request.PageSizeMax)
+	for i := range loadBalancers {
+		loadBalancers[i] = fmt.Sprintf(`{"uuid":"lb%d"}`, i)
+	}
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/load-balancer?limit=100&offset=0").Reply(http.StatusOK, "["+strings.Join(loadBalancers, ",")+"]")
+	m.On(http.MethodGet, "/load-balancer?limit=100&offset=100").Reply(http.StatusOK, `[{"uuid":"last"}]`)
+
+	page, err := List[upcloud.LoadBalancer](context.Background(), svc, ListOptions{Page: &request.Page{Size: 100, Number: 2}})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "last", page[0].UUID)
+	m.AssertExpectations(t)
+}
@@ -52,6 +52,7 @@ type LoadBalancer interface {
 	ModifyLoadBalancerFrontendRule(ctx context.Context, r *request.ModifyLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error)
 	ReplaceLoadBalancerFrontendRule(ctx context.Context, r *request.ReplaceLoadBalancerFrontendRuleRequest) (*upcloud.LoadBalancerFrontendRule, error)
 	DeleteLoadBalancerFrontendRule(ctx context.Context, r *request.DeleteLoadBalancerFrontendRuleRequest) error
+	ReorderLoadBalancerFrontendRules(ctx context.Context, r *request.ReorderLoadBalancerFrontendRulesRequest) ([]upcloud.LoadBalancerFrontendRule, error)
 	// Frontend TLS Config
 	GetLoadBalancerFrontendTLSConfigs(ctx context.Context, r *request.GetLoadBalancerFrontendTLSConfigsRequest) ([]upcloud.LoadBalancerFrontendTLSConfig, error)
 	GetLoadBalancerFrontendTLSConfig(ctx context.Context, r *request.GetLoadBalancerFrontendTLSConfigRequest) (*upcloud.LoadBalancerFrontendTLSConfig, error)
@@ -64,6 +65,7 @@ type LoadBalancer interface {
 	CreateLoadBalancerCertificateBundle(ctx context.Context, r *request.CreateLoadBalancerCertificateBundleRequest) (*upcloud.LoadBalancerCertificateBundle, error)
 	ModifyLoadBalancerCertificateBundle(ctx context.Context, r *request.ModifyLoadBalancerCertificateBundleRequest) (*upcloud.LoadBalancerCertificateBundle, error)
 	DeleteLoadBalancerCertificateBundle(ctx context.Context, r *request.DeleteLoadBalancerCertificateBundleRequest) error
+	WaitForLoadBalancerCertificateBundleOperationalState(ctx context.Context, r *request.WaitForLoadBalancerCertificateBundleOperationalStateRequest) (*upcloud.LoadBalancerCertificateBundle, error)
 	// Networks
 	ModifyLoadBalancerNetwork(ctx context.Context, r *request.ModifyLoadBalancerNetworkRequest) (*upcloud.LoadBalancerNetwork, error)
 }
@@ -334,6 +336,46 @@ func (s *Service) DeleteLoadBalancerFrontendRule(ctx context.Context, r *request
 	return s.delete(ctx, r)
 }
 
+// ReorderLoadBalancerFrontendRules changes the priorities of frontend rules so that the rules are evaluated in the
+// specified order. Only the rules whose priority needs to change are modified. Rules are returned in the new order.
+func (s *Service) ReorderLoadBalancerFrontendRules(ctx context.Context, r *request.ReorderLoadBalancerFrontendRulesRequest) ([]upcloud.LoadBalancerFrontendRule, error) {
+	rules, err := s.GetLoadBalancerFrontendRules(ctx, &request.GetLoadBalancerFrontendRulesRequest{
+		ServiceUUID:  r.ServiceUUID,
+		FrontendName: r.FrontendName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := upcloud.LoadBalancerFrontendRulePriorities(rules, r.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]upcloud.LoadBalancerFrontendRule, len(rules))
+	for _, rule := range rules {
+		byName[rule.Name] = rule
+	}
+	ordered := make([]upcloud.LoadBalancerFrontendRule, 0, len(r.Order))
+	for _, name := range r.Order {
+		rule := byName[name]
+		if priority, ok := changes[name]; ok {
+			modified, err := s.ModifyLoadBalancerFrontendRule(ctx, &request.ModifyLoadBalancerFrontendRuleRequest{
+				ServiceUUID:  r.ServiceUUID,
+				FrontendName: r.FrontendName,
+				Name:         name,
+				Rule:         request.ModifyLoadBalancerFrontendRule{Priority: &priority},
+			})
+			if err != nil {
+				return nil, err
+			}
+			rule = *modified
+		}
+		ordered = append(ordered, rule)
+	}
+	return ordered, nil
+}
+
 // GetLoadBalancerFrontendTLSConfigs retrieves a list of load balancer frontend TLS configs.
 func (s *Service) GetLoadBalancerFrontendTLSConfigs(ctx context.Context, r *request.GetLoadBalancerFrontendTLSConfigsRequest) ([]upcloud.LoadBalancerFrontendTLSConfig, error) {
 	configs := make([]upcloud.LoadBalancerFrontendTLSConfig, 0)
@@ -418,6 +460,25 @@ func (s *Service) DeleteLoadBalancerCertificateBundle(ctx context.Context, r *re
 	return s.delete(ctx, r)
 }
 
+// WaitForLoadBalancerCertificateBundleOperationalState blocks execution until the specified certificate bundle
+// has entered the specified operational state. Dynamic bundles enter idle state once the certificate has been issued.
+// If the state changes favorably, bundle details is returned. The method will give up after the specified timeout
+func (s *Service) WaitForLoadBalancerCertificateBundleOperationalState(ctx context.Context, r *request.WaitForLoadBalancerCertificateBundleOperationalStateRequest) (*upcloud.LoadBalancerCertificateBundle, error) {
+	return retry(ctx, func(_ int, c context.Context) (*upcloud.LoadBalancerCertificateBundle, error) {
+		details, err := s.GetLoadBalancerCertificateBundle(c, &request.GetLoadBalancerCertificateBundleRequest{
+			UUID: r.UUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if details.OperationalState == r.DesiredState {
+			return details, nil
+		}
+		return nil, nil
+	}, s.retryConfig())
+}
+
 func (s *Service) ModifyLoadBalancerNetwork(ctx context.Context, r *request.ModifyLoadBalancerNetworkRequest) (*upcloud.LoadBalancerNetwork, error) {
 	n := upcloud.LoadBalancerNetwork{}
 	return &n, s.modify(ctx, r, &n)
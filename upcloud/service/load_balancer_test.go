@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
@@ -1462,3 +1463,53 @@ func createLoadBalancerPrivateNetwork(ctx context.Context, svc *Service, zone, a
 		},
 	})
 }
+
+func TestWaitForLoadBalancerCertificateBundleOperationalState(t *testing.T) {
+	t.Parallel()
+
+	const path = "/load-balancer/certificate-bundles/0ae3b513-0bdb-4307-923e-297a084429a0"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"uuid":"0ae3b513-0bdb-4307-923e-297a084429a0","operational_state":"pending"}`).Once()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"uuid":"0ae3b513-0bdb-4307-923e-297a084429a0","operational_state":"setup-challenge"}`).Once()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"uuid":"0ae3b513-0bdb-4307-923e-297a084429a0","operational_state":"idle","not_after":"2024-03-17T13:15:08Z"}`)
+
+	bundle, err := svc.WaitForLoadBalancerCertificateBundleOperationalState(context.Background(), &request.WaitForLoadBalancerCertificateBundleOperationalStateRequest{
+		UUID:         "0ae3b513-0bdb-4307-923e-297a084429a0",
+		DesiredState: upcloud.LoadBalancerCertificateBundleOperationalStateIdle,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.LoadBalancerCertificateBundleOperationalStateIdle, bundle.OperationalState)
+	assert.Equal(t, time.Date(2024, 3, 17, 13, 15, 8, 0, time.UTC), bundle.NotAfter)
+	assert.Equal(t, 3, m.Called(http.MethodGet, path))
+}
+
+func TestReorderLoadBalancerFrontendRules(t *testing.T) {
+	t.Parallel()
+
+	const path = "/load-balancer/0aded5c1-c7a3-498a-b9c8-a871611c47a2/frontends/fe-1/rules"
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `[{"name":"api","priority":50},{"name":"static","priority":40},{"name":"health","priority":0}]`)
+	m.On(http.MethodPatch, path+"/health").Reply(http.StatusOK, `{"name":"health","priority":49}`)
+
+	rules, err := svc.ReorderLoadBalancerFrontendRules(context.Background(), &request.ReorderLoadBalancerFrontendRulesRequest{
+		ServiceUUID:  "0aded5c1-c7a3-498a-b9c8-a871611c47a2",
+		FrontendName: "fe-1",
+		Order:        []string{"api", "health", "static"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []upcloud.LoadBalancerFrontendRule{
+		{Name: "api", Priority: 50},
+		{Name: "health", Priority: 49},
+		{Name: "static", Priority: 40},
+	}, rules)
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.JSONEq(t, `{"priority":49}`, string(calls[1].Body))
+
+	_, err = svc.ReorderLoadBalancerFrontendRules(context.Background(), &request.ReorderLoadBalancerFrontendRulesRequest{
+		ServiceUUID:  "0aded5c1-c7a3-498a-b9c8-a871611c47a2",
+		FrontendName: "fe-1",
+		Order:        []string{"api", "static"},
+	})
+	assert.EqualError(t, err, "order has 2 rules, frontend has 3 rules")
+}
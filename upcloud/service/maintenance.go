@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// MaintenanceDeferral describes a method call that failed because of platform maintenance and is retried once the
+// server or storage it targets has left maintenance state
+type MaintenanceDeferral struct {
+	// Method is the name of the ServiceAPI method
+	Method string
+	// Resource is "server" or "storage"
+	Resource string
+	UUID     string
+	// Deferral counts the deferrals of the call from one
+	Deferral int
+	// Err is the error of the failed call
+	Err error
+}
+
+// MaintenanceDeferFunc is called before a call is deferred, e.g. for logging the deferral
+type MaintenanceDeferFunc func(ctx context.Context, d MaintenanceDeferral)
+
+// DeferMaintenance returns a decorator that defers method calls failing with an error for which upcloud.IsMaintenance
+// is true: the call waits for the server or storage in the request URL to leave maintenance state, using the WaitFor
+// methods of the wrapped ServiceAPI, and is then retried. A call is deferred at most maxDeferrals times; errors of
+// calls that do not target a server or storage are returned as is. notify, if not nil, is called before each deferral.
+func DeferMaintenance(maxDeferrals int, notify MaintenanceDeferFunc) Decorator {
+	return func(next ServiceAPI) ServiceAPI {
+		return &interceptedService{next: next, fn: func(ctx context.Context, call Call, fn func(ctx context.Context) error) error {
+			if strings.HasPrefix(call.Method, "WaitFor") {
+				return fn(ctx)
+			}
+			for deferral := 1; ; deferral++ {
+				err := fn(ctx)
+				if err == nil || deferral > maxDeferrals || !upcloud.IsMaintenance(err) {
+					return err
+				}
+				resource, uuid := maintenanceResource(call.Request)
+				if uuid == "" {
+					return err
+				}
+				if notify != nil {
+					notify(ctx, MaintenanceDeferral{Method: call.Method, Resource: resource, UUID: uuid, Deferral: deferral, Err: err})
+				}
+				if err := waitForMaintenance(ctx, next, resource, uuid); err != nil {
+					return err
+				}
+			}
+		}}
+	}
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// maintenanceResource returns the kind and UUID of the server or storage targeted by the request
+func maintenanceResource(r any) (resource, uuid string) {
+	req, ok := r.(requestable)
+	if !ok {
+		return "", ""
+	}
+	segments := strings.Split(strings.TrimPrefix(req.RequestURL(), "/"), "/")
+	if len(segments) < 2 || (segments[0] != "server" && segments[0] != "storage") {
+		return "", ""
+	}
+	// Skip collections such as /storage/template
+	if !uuidPattern.MatchString(segments[1]) {
+		return "", ""
+	}
+	return segments[0], segments[1]
+}
+
+// waitForMaintenance waits for the server or storage to leave maintenance state
+func waitForMaintenance(ctx context.Context, api ServiceAPI, resource, uuid string) error {
+	if resource == "server" {
+		_, err := api.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: uuid, UndesiredState: upcloud.ServerStateMaintenance})
+		return err
+	}
+	_, err := api.WaitForStorageState(ctx, &request.WaitForStorageStateRequest{UUID: uuid, DesiredState: upcloud.StorageStateOnline})
+	return err
+}
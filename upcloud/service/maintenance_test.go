@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferMaintenance(t *testing.T) {
+	t.Parallel()
+
+	const uuid = "00798b85-efdc-41ca-8021-f6ef457b8531"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/server/"+uuid+"/start").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "The server is in maintenance state.").Once()
+	m.On(http.MethodPost, "/server/"+uuid+"/start").Reply(http.StatusAccepted, `{"server":{"uuid":"`+uuid+`","state":"started"}}`)
+	m.On(http.MethodGet, "/server/"+uuid).Reply(http.StatusOK, `{"server":{"uuid":"`+uuid+`","state":"maintenance"}}`).Once()
+	m.On(http.MethodGet, "/server/"+uuid).Reply(http.StatusOK, `{"server":{"uuid":"`+uuid+`","state":"stopped"}}`)
+	m.On(http.MethodPost, "/server/"+uuid+"/stop").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "The server is in maintenance state.")
+
+	var deferrals []MaintenanceDeferral
+	api := Decorate(svc, DeferMaintenance(1, func(_ context.Context, d MaintenanceDeferral) {
+		deferrals = append(deferrals, d)
+	}))
+
+	server, err := api.StartServer(context.Background(), &request.StartServerRequest{UUID: uuid})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStarted, server.State)
+	require.Len(t, deferrals, 1)
+	assert.Equal(t, MaintenanceDeferral{Method: "StartServer", Resource: "server", UUID: uuid, Deferral: 1, Err: deferrals[0].Err}, deferrals[0])
+	assert.True(t, upcloud.IsMaintenance(deferrals[0].Err))
+
+	// The call fails once the deferrals have been used
+	_, err = api.StopServer(context.Background(), &request.StopServerRequest{UUID: uuid})
+	assert.True(t, upcloud.IsMaintenance(err))
+	assert.Len(t, deferrals, 2)
+	m.AssertExpectations(t)
+}
+
+func TestMaintenanceResource(t *testing.T) {
+	t.Parallel()
+
+	const uuid = "01000000-0000-4000-8000-000030240200"
+	for _, test := range []struct {
+		request  any
+		resource string
+		uuid     string
+	}{
+		{&request.StartServerRequest{UUID: uuid}, "server", uuid},
+		{&request.LoadCDROMRequest{ServerUUID: uuid}, "server", uuid},
+		{&request.CreateBackupRequest{UUID: uuid}, "storage", uuid},
+		{&request.GetStoragesRequest{Type: upcloud.StorageTypeTemplate}, "", ""},
+		{&request.GetNetworkDetailsRequest{UUID: uuid}, "", ""},
+		{nil, "", ""},
+	} {
+		resource, id := maintenanceResource(test.request)
+		assert.Equal(t, test.resource, resource)
+		assert.Equal(t, test.uuid, id)
+	}
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -64,10 +63,10 @@ func (s *Service) CancelManagedDatabaseSession(ctx context.Context, r *request.C
 	}{}
 	response, err := s.client.Delete(ctx, r.RequestURL())
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 
-	err = json.Unmarshal(response, &res)
+	err = s.codec().Unmarshal(response, &res)
 	if err != nil {
 		return fmt.Errorf("unable to unmarshal JSON: %w", err)
 	}
@@ -181,7 +180,7 @@ func (s *Service) WaitForManagedDatabaseState(ctx context.Context, r *request.Wa
 			return details, nil
 		}
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
 }
 
 // StartManagedDatabase starts a shut down existing managed database instance
@@ -247,7 +246,8 @@ func (s *Service) DeleteManagedDatabaseLogicalDatabase(ctx context.Context, r *r
 	return s.delete(ctx, r)
 }
 
-// GetManagedDatabaseServiceType returns details of requested service type
+// GetManagedDatabaseServiceType returns details of requested service type. Use ManagedDatabaseType.Validate to check
+// database properties against the property metadata of the service type.
 func (s *Service) GetManagedDatabaseServiceType(ctx context.Context, r *request.GetManagedDatabaseServiceTypeRequest) (*upcloud.ManagedDatabaseType, error) {
 	var serviceType upcloud.ManagedDatabaseType
 	return &serviceType, s.get(ctx, r.RequestURL(), &serviceType)
@@ -20,6 +20,7 @@ type ManagedObjectStorage interface {
 	DeleteManagedObjectStorage(ctx context.Context, r *request.DeleteManagedObjectStorageRequest) error
 	GetManagedObjectStorageMetrics(ctx context.Context, r *request.GetManagedObjectStorageMetricsRequest) (*upcloud.ManagedObjectStorageMetrics, error)
 	GetManagedObjectStorageBucketMetrics(ctx context.Context, r *request.GetManagedObjectStorageBucketMetricsRequest) ([]upcloud.ManagedObjectStorageBucketMetrics, error)
+	GetManagedObjectStorageUsage(ctx context.Context, r *request.GetManagedObjectStorageUsageRequest) (*upcloud.ManagedObjectStorageUsage, error)
 	CreateManagedObjectStorageNetwork(ctx context.Context, r *request.CreateManagedObjectStorageNetworkRequest) (*upcloud.ManagedObjectStorageNetwork, error)
 	GetManagedObjectStorageNetworks(ctx context.Context, r *request.GetManagedObjectStorageNetworksRequest) ([]upcloud.ManagedObjectStorageNetwork, error)
 	GetManagedObjectStorageNetwork(ctx context.Context, r *request.GetManagedObjectStorageNetworkRequest) (*upcloud.ManagedObjectStorageNetwork, error)
@@ -93,6 +94,38 @@ func (s *Service) GetManagedObjectStorageBucketMetrics(ctx context.Context, r *r
 	return bucketMetrics, s.get(ctx, r.RequestURL(), &bucketMetrics)
 }
 
+// GetManagedObjectStorageUsage retrieves total object count and size of a Managed Object Storage service and the
+// metrics of all of its buckets.
+func (s *Service) GetManagedObjectStorageUsage(ctx context.Context, r *request.GetManagedObjectStorageUsageRequest) (*upcloud.ManagedObjectStorageUsage, error) {
+	metrics, err := s.GetManagedObjectStorageMetrics(ctx, &request.GetManagedObjectStorageMetricsRequest{ServiceUUID: r.ServiceUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := upcloud.ManagedObjectStorageUsage{
+		ManagedObjectStorageMetrics: *metrics,
+		Buckets:                     make([]upcloud.ManagedObjectStorageBucketMetrics, 0),
+	}
+	req := request.GetManagedObjectStorageBucketMetricsRequest{ServiceUUID: r.ServiceUUID, Page: request.DefaultPage}
+
+	// loop until max result is reached or until response doesn't fill our page anymore
+	for len(usage.Buckets) <= request.PageResultMaxSize {
+		b, err := s.GetManagedObjectStorageBucketMetrics(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		usage.Buckets = append(usage.Buckets, b...)
+		if len(b) < req.Page.Size {
+			break
+		}
+
+		req.Page = req.Page.Next()
+	}
+
+	return &usage, nil
+}
+
 func (s *Service) CreateManagedObjectStorageNetwork(ctx context.Context, r *request.CreateManagedObjectStorageNetworkRequest) (*upcloud.ManagedObjectStorageNetwork, error) {
 	network := upcloud.ManagedObjectStorageNetwork{}
 	return &network, s.create(ctx, r, &network)
@@ -203,7 +236,7 @@ func (s *Service) WaitForManagedObjectStorageOperationalState(ctx context.Contex
 			return details, nil
 		}
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
 }
 
 // WaitForManagedObjectStorageDeletion blocks execution until the specified Managed Object Storage service
@@ -223,6 +256,6 @@ func (s *Service) WaitForManagedObjectStorageDeletion(ctx context.Context, r *re
 		}
 
 		return details, err
-	}, &retryConfig{inverse: true})
+	}, &retryConfig{inverse: true, backoff: s.config.backoff, timeout: s.config.defaultTimeout, logger: s.config.logger})
 	return err
 }
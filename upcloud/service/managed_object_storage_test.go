@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
@@ -887,3 +890,25 @@ func deleteManagedObjectStorageAndUsers(ctx context.Context, svc *Service, uuid
 		UUID: uuid,
 	})
 }
+
+func TestGetManagedObjectStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	const path = "/object-storage-2/1200ecde-db95-4d1c-9133-6508f3232567"
+	buckets := make([]string, request.PageSizeMax)
+	for i := range buckets {
+		buckets[i] = fmt.Sprintf(`{"name":"bucket-%d","total_objects":1,"total_size_bytes":10}`, i)
+	}
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, path+"/metrics").Reply(http.StatusOK, `{"total_objects":102,"total_size_bytes":2068}`)
+	m.On(http.MethodGet, path+"/buckets?limit=100&offset=0").Reply(http.StatusOK, "["+strings.Join(buckets, ",")+"]")
+	m.On(http.MethodGet, path+"/buckets?limit=100&offset=100").Reply(http.StatusOK, `[{"name":"logs","total_objects":2,"total_size_bytes":1068}]`)
+
+	usage, err := svc.GetManagedObjectStorageUsage(context.Background(), &request.GetManagedObjectStorageUsageRequest{ServiceUUID: "1200ecde-db95-4d1c-9133-6508f3232567"})
+	require.NoError(t, err)
+	assert.Equal(t, 102, usage.TotalObjects)
+	assert.Equal(t, 2068, usage.TotalSizeBytes)
+	require.Len(t, usage.Buckets, 101)
+	assert.Equal(t, upcloud.ManagedObjectStorageBucketMetrics{Name: "logs", TotalObjects: 2, TotalSizeBytes: 1068}, usage.Buckets[100])
+	m.AssertExpectations(t)
+}
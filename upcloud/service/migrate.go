@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// MigrateServerToZone moves a stopped server to another zone. The disks of the server are cloned to the target zone
+// and a server with the same plan, settings, public and utility interfaces, tags and labels is created using the
+// clones. Private interfaces are recreated only for the networks mapped in the request. If FloatingIPAddress is set,
+// the floating IP address is attached to the new server. If the server is not stopped, *upcloud.ServerTransitionError
+// is returned.
+//
+// The source server is left as is, unless DeleteSource is set, in which case it is deleted together with its disks
+// once the new server is running. If cloning or creating the server fails, the clones created so far are deleted. If
+// the new server fails to start, or tagging it or attaching the floating IP address fails, the new server is deleted
+// together with the clones. The cleanup is done even if ctx is done. The details of the new server are returned, also
+// together with the error if deleting the source server fails, as the new server is running by then.
+func (s *Service) MigrateServerToZone(ctx context.Context, r *request.MigrateServerToZoneRequest) (*upcloud.ServerDetails, error) {
+	source, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+	if source.Zone == r.Zone {
+		return nil, fmt.Errorf("server %s is already in zone %s", source.UUID, r.Zone)
+	}
+	if err := upcloud.CheckServerTransition(source, upcloud.ServerOperationMigrate); err != nil {
+		return nil, err
+	}
+
+	clones, err := s.cloneServerDisks(ctx, source, r.Zone)
+	if err != nil {
+		return nil, s.deleteClones(ctx, clones, err)
+	}
+
+	devices := make(request.CreateServerStorageDeviceSlice, 0, len(clones))
+	for _, uuid := range clones {
+		devices = append(devices, request.CreateServerStorageDevice{
+			Action:  request.CreateServerStorageDeviceActionAttach,
+			Storage: uuid,
+		})
+	}
+	create := migratedServerRequest(source, r)
+	create.StorageDevices = devices
+	server, err := s.CreateServer(ctx, create)
+	if err != nil {
+		return nil, s.deleteClones(ctx, clones, err)
+	}
+
+	if err := s.setUpMigratedServer(ctx, server.UUID, source, r); err != nil {
+		if cleanupErr := s.deleteServerWithStorages(ctx, server.UUID); cleanupErr != nil {
+			err = errors.Join(err, fmt.Errorf("deleting server %s: %w", server.UUID, cleanupErr))
+		}
+		return nil, err
+	}
+
+	var deleteErr error
+	if r.DeleteSource {
+		if err := s.DeleteServer(ctx, &request.DeleteServerRequest{UUID: source.UUID, Storages: true}); err != nil {
+			deleteErr = fmt.Errorf("deleting source server %s: %w", source.UUID, err)
+		}
+	}
+
+	details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: server.UUID})
+	if deleteErr != nil {
+		if err != nil {
+			details = server
+		}
+		return details, deleteErr
+	}
+	return details, err
+}
+
+// setUpMigratedServer waits for the migrated server to start, tags it and attaches the floating IP address to it
+func (s *Service) setUpMigratedServer(ctx context.Context, serverUUID string, source *upcloud.ServerDetails, r *request.MigrateServerToZoneRequest) error {
+	server, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{
+		UUID:         serverUUID,
+		DesiredState: upcloud.ServerStateStarted,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(source.Tags) > 0 {
+		if _, err := s.TagServer(ctx, &request.TagServerRequest{UUID: server.UUID, Tags: source.Tags}); err != nil {
+			return fmt.Errorf("tagging server %s: %w", server.UUID, err)
+		}
+	}
+
+	if r.FloatingIPAddress != "" {
+		mac := publicIPv4MAC(server)
+		if mac == "" {
+			return fmt.Errorf("server %s has no public IPv4 interface for floating IP address %s", server.UUID, r.FloatingIPAddress)
+		}
+		if _, err := s.ModifyIPAddress(ctx, &request.ModifyIPAddressRequest{IPAddress: r.FloatingIPAddress, MAC: mac}); err != nil {
+			return fmt.Errorf("attaching floating IP address %s: %w", r.FloatingIPAddress, err)
+		}
+	}
+	return nil
+}
+
+// cloneServerDisks clones the disks of the server to the zone in the order they are attached and returns the UUIDs
+// of the clones. On error, the clones created so far are returned with the error.
+func (s *Service) cloneServerDisks(ctx context.Context, server *upcloud.ServerDetails, zone string) ([]string, error) {
+	var clones []string
+	var ops []*Operation[upcloud.StorageDetails]
+	for _, device := range server.StorageDevices {
+		if !device.IsDisk() {
+			continue
+		}
+		op, err := s.CloneStorageOperation(ctx, &request.CloneStorageRequest{
+			UUID:      device.UUID,
+			Zone:      zone,
+			Tier:      device.Tier,
+			Title:     device.Title,
+			Encrypted: device.Encrypted,
+		})
+		if err != nil {
+			return clones, fmt.Errorf("cloning storage %s: %w", device.UUID, err)
+		}
+		clones = append(clones, op.Details.UUID)
+		ops = append(ops, op)
+	}
+	for _, op := range ops {
+		if _, err := op.Wait(ctx); err != nil {
+			return clones, fmt.Errorf("waiting for clone %s: %w", op.Details.UUID, err)
+		}
+	}
+	return clones, nil
+}
+
+// cleanupTimeout limits how long deleting the resources left behind by a failed operation may take
+const cleanupTimeout = 15 * time.Minute
+
+// cleanupContext returns a context for deleting the resources left behind by a failed operation. The context is not
+// cancelled when ctx is done, because a cancelled or timed out ctx is a common reason for the failure, but it has a
+// timeout of its own.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+}
+
+// deleteServerWithStorages stops the server, if it is not stopped already, and deletes it together with its disks and
+// their backups. It is used for cleaning up after failed operations, so it runs on a cleanup context. The server was
+// created by the service, so the deletion guards are not checked.
+func (s *Service) deleteServerWithStorages(ctx context.Context, serverUUID string) error {
+	ctx, cancel := cleanupContext(ctx)
+	defer cancel()
+
+	server, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: serverUUID, UndesiredState: upcloud.ServerStateMaintenance})
+	if err != nil {
+		return err
+	}
+	if server.State != upcloud.ServerStateStopped {
+		if _, err := s.StopServer(ctx, &request.StopServerRequest{UUID: serverUUID, StopType: request.ServerStopTypeHard}); err != nil {
+			return err
+		}
+		if _, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: serverUUID, DesiredState: upcloud.ServerStateStopped}); err != nil {
+			return err
+		}
+	}
+	return s.delete(ctx, &request.DeleteServerRequest{UUID: serverUUID, Storages: true, Backups: request.DeleteStorageBackupsModeDelete})
+}
+
+// deleteClones deletes the cloned storages after a failed operation and returns err joined with the deletion errors.
+// The clones are deleted on a cleanup context without checking the deletion guards, as they were created by the
+// service.
+func (s *Service) deleteClones(ctx context.Context, clones []string, err error) error {
+	ctx, cancel := cleanupContext(ctx)
+	defer cancel()
+
+	errs := []error{err}
+	for _, uuid := range clones {
+		if _, waitErr := s.WaitForStorageState(ctx, &request.WaitForStorageStateRequest{UUID: uuid, DesiredState: upcloud.StorageStateOnline}); waitErr != nil {
+			errs = append(errs, fmt.Errorf("deleting clone %s: %w", uuid, waitErr))
+			continue
+		}
+		if delErr := s.delete(ctx, &request.DeleteStorageRequest{UUID: uuid}); delErr != nil {
+			errs = append(errs, fmt.Errorf("deleting clone %s: %w", uuid, delErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// migratedServerRequest returns a request for creating a server with the settings of the source server in the
+// target zone. Storage devices are left for the caller to fill.
+func migratedServerRequest(source *upcloud.ServerDetails, r *request.MigrateServerToZoneRequest) *request.CreateServerRequest {
+	create := &request.CreateServerRequest{
+		Zone:                r.Zone,
+		Title:               source.Title,
+		Hostname:            source.Hostname,
+		Plan:                source.Plan,
+		BootOrder:           source.BootOrder,
+		Firewall:            source.Firewall,
+		Metadata:            source.Metadata,
+		NICModel:            source.NICModel,
+		VideoModel:          source.VideoModel,
+		TimeZone:            source.Timezone,
+		SimpleBackup:        source.SimpleBackup,
+		RemoteAccessEnabled: source.RemoteAccessEnabled,
+		RemoteAccessType:    source.RemoteAccessType,
+		Networking:          &request.CreateServerNetworking{},
+	}
+	if r.Plan != "" {
+		create.Plan = r.Plan
+	}
+	if create.Plan == "custom" {
+		create.CoreNumber = source.CoreNumber
+		create.MemoryAmount = source.MemoryAmount
+	}
+	if len(source.Labels) > 0 {
+		labels := append(upcloud.LabelSlice(nil), source.Labels...)
+		create.Labels = &labels
+	}
+
+	for _, iface := range source.Networking.Interfaces {
+		network := ""
+		if iface.Type == upcloud.IPAddressAccessPrivate {
+			var ok bool
+			if network, ok = r.PrivateNetworks[iface.Network]; !ok {
+				continue
+			}
+		}
+		var addresses request.CreateServerIPAddressSlice
+		for _, address := range iface.IPAddresses {
+			// Floating IP addresses are zone specific and attached separately
+			if !address.Floating.Bool() {
+				addresses = append(addresses, request.CreateServerIPAddress{Family: address.Family})
+			}
+		}
+		create.Networking.Interfaces = append(create.Networking.Interfaces, request.CreateServerInterface{
+			Type:              iface.Type,
+			Network:           network,
+			IPAddresses:       addresses,
+			SourceIPFiltering: iface.SourceIPFiltering,
+			Bootable:          iface.Bootable,
+		})
+	}
+	return create
+}
+
+// publicIPv4MAC returns the MAC address of the first public interface with an IPv4 address
+func publicIPv4MAC(server *upcloud.ServerDetails) string {
+	for _, iface := range server.Networking.Interfaces {
+		if iface.Type != upcloud.IPAddressAccessPublic {
+			continue
+		}
+		for _, address := range iface.IPAddresses {
+			if address.Family == upcloud.IPAddressFamilyIPv4 {
+				return iface.MAC
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrateTestSourceServer = `{"server":{"uuid":"source","zone":"fi-hel1","state":"stopped","hostname":"web.example.com","title":"web",
+	"plan":"custom","core_number":"2","memory_amount":"4096","metadata":"yes","simple_backup":"no",
+	"tags":{"tag":["prod"]},"labels":{"label":[{"key":"team","value":"web"}]},
+	"networking":{"interfaces":{"interface":[
+		{"index":1,"type":"public","mac":"aa","ip_addresses":{"ip_address":[{"family":"IPv4","address":"94.237.0.1"},{"family":"IPv4","address":"94.237.0.9","floating":"yes"}]}},
+		{"index":2,"type":"utility","mac":"bb","ip_addresses":{"ip_address":[{"family":"IPv4","address":"10.0.0.1"}]}},
+		{"index":3,"type":"private","mac":"cc","network":"net-hel","ip_addresses":{"ip_address":[{"family":"IPv4","address":"172.16.0.2"}]}},
+		{"index":4,"type":"private","mac":"dd","network":"net-other","ip_addresses":{"ip_address":[{"family":"IPv4","address":"172.17.0.2"}]}}
+	]}},
+	"storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","storage_title":"root","storage_tier":"maxiops","type":"disk"},
+		{"address":"ide:0:0","storage":"cdrom-1","type":"cdrom"},
+		{"address":"virtio:1","storage":"disk-2","storage_title":"data","storage_tier":"hdd","type":"disk"}
+	]}}}`
+
+func TestMigrateServerToZone(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/source").Reply(http.StatusOK, migrateTestSourceServer)
+	m.On(http.MethodPost, "/storage/disk-1/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-1","state":"maintenance"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-2","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone-1").Reply(http.StatusOK, `{"storage":{"uuid":"clone-1","state":"online"}}`)
+	m.On(http.MethodGet, "/storage/clone-2").Reply(http.StatusOK, `{"storage":{"uuid":"clone-2","state":"online"}}`)
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"target","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/target").Reply(http.StatusOK, `{"server":{"uuid":"target","zone":"de-fra1","state":"started","networking":{"interfaces":{"interface":[
+		{"index":1,"type":"public","mac":"ee","ip_addresses":{"ip_address":[{"family":"IPv4","address":"94.237.1.1"}]}}
+	]}}}}`)
+	m.On(http.MethodPost, "/server/target/tag/prod").Reply(http.StatusOK, `{"server":{"uuid":"target"}}`)
+	m.On(http.MethodPatch, "/ip_address/94.237.1.9").Reply(http.StatusAccepted, `{"ip_address":{"address":"94.237.1.9"}}`)
+	m.On(http.MethodDelete, "/server/source/?storages=1").Reply(http.StatusNoContent, "")
+
+	server, err := svc.MigrateServerToZone(context.Background(), &request.MigrateServerToZoneRequest{
+		ServerUUID:        "source",
+		Zone:              "de-fra1",
+		PrivateNetworks:   map[string]string{"net-hel": "net-fra"},
+		FloatingIPAddress: "94.237.1.9",
+		DeleteSource:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "target", server.UUID)
+	m.AssertExpectations(t)
+
+	var create, floating struct {
+		Server    json.RawMessage `json:"server"`
+		IPAddress json.RawMessage `json:"ip_address"`
+	}
+	for _, call := range m.Calls() {
+		switch {
+		case call.Method == http.MethodPost && call.Path == "/storage/disk-1/clone":
+			assert.JSONEq(t, `{"storage":{"zone":"de-fra1","tier":"maxiops","title":"root"}}`, string(call.Body))
+		case call.Method == http.MethodPost && call.Path == "/server":
+			require.NoError(t, json.Unmarshal(call.Body, &create))
+		case call.Method == http.MethodPatch:
+			require.NoError(t, json.Unmarshal(call.Body, &floating))
+		}
+	}
+	assert.JSONEq(t, `{
+		"zone": "de-fra1",
+		"title": "web",
+		"hostname": "web.example.com",
+		"plan": "custom",
+		"core_number": 2,
+		"memory_amount": 4096,
+		"metadata": "yes",
+		"simple_backup": "no",
+		"labels": {"label": [{"key": "team", "value": "web"}]},
+		"remote_access_enabled": "no",
+		"networking": {"interfaces": {"interface": [
+			{"type": "public", "ip_addresses": {"ip_address": [{"family": "IPv4"}]}},
+			{"type": "utility", "ip_addresses": {"ip_address": [{"family": "IPv4"}]}},
+			{"type": "private", "network": "net-fra", "ip_addresses": {"ip_address": [{"family": "IPv4"}]}}
+		]}},
+		"storage_devices": {"storage_device": [
+			{"action": "attach", "storage": "clone-1"},
+			{"action": "attach", "storage": "clone-2"}
+		]}
+	}`, string(create.Server))
+	assert.JSONEq(t, `{"mac": "ee"}`, string(floating.IPAddress))
+}
+
+func TestMigrateServerToZone_cleanup(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/source").Reply(http.StatusOK, migrateTestSourceServer)
+	m.On(http.MethodPost, "/storage/disk-1/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-1","state":"maintenance"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/clone").ReplyError(http.StatusConflict, upcloud.ErrCodeStorageStateIllegal, "storage is busy")
+	m.On(http.MethodGet, "/storage/clone-1").Reply(http.StatusOK, `{"storage":{"uuid":"clone-1","state":"online"}}`)
+	m.On(http.MethodDelete, "/storage/clone-1").Reply(http.StatusNoContent, "")
+
+	_, err := svc.MigrateServerToZone(context.Background(), &request.MigrateServerToZoneRequest{ServerUUID: "source", Zone: "de-fra1"})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, upcloud.ErrCodeStorageStateIllegal, problem.ErrorCode())
+	m.AssertExpectations(t)
+
+	_, err = svc.MigrateServerToZone(context.Background(), &request.MigrateServerToZoneRequest{ServerUUID: "source", Zone: "fi-hel1"})
+	assert.EqualError(t, err, "server source is already in zone fi-hel1")
+}
+
+func TestMigrateServerToZone_deleteSourceFails(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/source").Reply(http.StatusOK, migrateTestSourceServer)
+	m.On(http.MethodPost, "/storage/disk-1/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-1","state":"maintenance"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-2","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone-1").Reply(http.StatusOK, `{"storage":{"uuid":"clone-1","state":"online"}}`)
+	m.On(http.MethodGet, "/storage/clone-2").Reply(http.StatusOK, `{"storage":{"uuid":"clone-2","state":"online"}}`)
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"target","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/target").Reply(http.StatusOK, `{"server":{"uuid":"target","zone":"de-fra1","state":"started"}}`)
+	m.On(http.MethodPost, "/server/target/tag/prod").Reply(http.StatusOK, `{"server":{"uuid":"target"}}`)
+	m.On(http.MethodDelete, "/server/source/?storages=1").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "server is busy")
+
+	// The new server is running, so its details are returned with the error
+	server, err := svc.MigrateServerToZone(context.Background(), &request.MigrateServerToZoneRequest{
+		ServerUUID:   "source",
+		Zone:         "de-fra1",
+		DeleteSource: true,
+	})
+	assert.ErrorContains(t, err, "deleting source server source")
+	require.NotNil(t, server)
+	assert.Equal(t, "target", server.UUID)
+	assert.Equal(t, upcloud.ServerStateStarted, server.State)
+	m.AssertExpectations(t)
+}
+
+func TestMigrateServerToZone_rollback(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/source").Reply(http.StatusOK, migrateTestSourceServer)
+	m.On(http.MethodPost, "/storage/disk-1/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-1","state":"maintenance"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-2","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone-1").Reply(http.StatusOK, `{"storage":{"uuid":"clone-1","state":"online"}}`)
+	m.On(http.MethodGet, "/storage/clone-2").Reply(http.StatusOK, `{"storage":{"uuid":"clone-2","state":"online"}}`)
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"target","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/target").Reply(http.StatusOK, `{"server":{"uuid":"target","state":"started"}}`).Times = 2
+	m.On(http.MethodPost, "/server/target/tag/prod").ReplyError(http.StatusBadRequest, "TAG_NOT_FOUND", "tag not found")
+	m.On(http.MethodPost, "/server/target/stop").Reply(http.StatusAccepted, `{"server":{"uuid":"target"}}`)
+	m.On(http.MethodGet, "/server/target").Reply(http.StatusOK, `{"server":{"uuid":"target","state":"stopped"}}`)
+	m.On(http.MethodDelete, "/server/target/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+
+	_, err := svc.MigrateServerToZone(context.Background(), &request.MigrateServerToZoneRequest{ServerUUID: "source", Zone: "de-fra1"})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, "TAG_NOT_FOUND", problem.ErrorCode())
+	m.AssertExpectations(t)
+}
+
+func TestMigrateServerToZone_cleanupAfterCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodGet, "/server/source").Reply(http.StatusOK, migrateTestSourceServer)
+	m.On(http.MethodPost, "/storage/disk-1/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone-1","state":"maintenance"}}`)
+	m.On(http.MethodPost, "/storage/disk-2/clone").ReplyError(http.StatusConflict, upcloud.ErrCodeStorageStateIllegal, "storage is busy")
+	m.On(http.MethodGet, "/storage/clone-1").Reply(http.StatusOK, `{"storage":{"uuid":"clone-1","state":"online"}}`)
+	m.On(http.MethodDelete, "/storage/clone-1").Reply(http.StatusNoContent, "")
+	transport := &customRoundTripper{fn: func(r *http.Request) (*http.Response, error) {
+		// The caller gives up while the second disk is being cloned
+		if r.URL.Path == "/"+client.APIVersion+"/storage/disk-2/clone" {
+			cancel()
+		}
+		return m.RoundTrip(r)
+	}}
+	svc := New(client.New("user", "pass", client.WithHTTPClient(&http.Client{Transport: transport})), WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+
+	_, err := svc.MigrateServerToZone(ctx, &request.MigrateServerToZoneRequest{ServerUUID: "source", Zone: "de-fra1"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/storage/clone-1"))
+}
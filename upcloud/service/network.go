@@ -37,7 +37,7 @@ func (s *Service) GetNetworks(ctx context.Context, f ...request.QueryFilter) (*u
 // GetNetworksInZone returns the all the available networks within the specified zone.
 func (s *Service) GetNetworksInZone(ctx context.Context, r *request.GetNetworksInZoneRequest) (*upcloud.Networks, error) {
 	networks := upcloud.Networks{}
-	return &networks, s.get(ctx, r.RequestURL(), &networks)
+	return &networks, s.get(ctx, s.withDefaults(r).RequestURL(), &networks)
 }
 
 // CreateNetwork creates a new network and returns the network details for the new network.
@@ -14,6 +14,7 @@ type NetworkPeering interface {
 	ModifyNetworkPeering(ctx context.Context, r *request.ModifyNetworkPeeringRequest) (*upcloud.NetworkPeering, error)
 	DeleteNetworkPeering(ctx context.Context, r *request.DeleteNetworkPeeringRequest) error
 	WaitForNetworkPeeringState(ctx context.Context, r *request.WaitForNetworkPeeringStateRequest) (*upcloud.NetworkPeering, error)
+	DisableAndDeleteNetworkPeering(ctx context.Context, r *request.DeleteNetworkPeeringRequest) error
 }
 
 // GetNetworkPeerings (EXPERIMENTAL) retrieves a list of network peerings within an account.
@@ -46,6 +47,9 @@ func (s *Service) DeleteNetworkPeering(ctx context.Context, r *request.DeleteNet
 	return s.delete(ctx, r)
 }
 
+// WaitForNetworkPeeringState (EXPERIMENTAL) blocks execution until the specified network peering has entered the
+// specified state, e.g. active. If the state changes favorably, peering details is returned. The method will give up
+// after the specified timeout
 func (s *Service) WaitForNetworkPeeringState(ctx context.Context, r *request.WaitForNetworkPeeringStateRequest) (*upcloud.NetworkPeering, error) {
 	return retry(ctx, func(_ int, c context.Context) (*upcloud.NetworkPeering, error) {
 		details, err := s.GetNetworkPeering(c, &request.GetNetworkPeeringRequest{
@@ -59,5 +63,36 @@ func (s *Service) WaitForNetworkPeeringState(ctx context.Context, r *request.Wai
 			return details, nil
 		}
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
+}
+
+// DisableAndDeleteNetworkPeering (EXPERIMENTAL) deletes a peering regardless of its state. Active peering is disabled
+// first and deleted once the state has changed to disabled.
+func (s *Service) DisableAndDeleteNetworkPeering(ctx context.Context, r *request.DeleteNetworkPeeringRequest) error {
+	p, err := s.GetNetworkPeering(ctx, &request.GetNetworkPeeringRequest{UUID: r.UUID})
+	if err != nil {
+		return err
+	}
+
+	if p.ConfiguredStatus != upcloud.NetworkPeeringConfiguredStatusDisabled {
+		if _, err := s.ModifyNetworkPeering(ctx, &request.ModifyNetworkPeeringRequest{
+			UUID: r.UUID,
+			NetworkPeering: request.ModifyNetworkPeering{
+				ConfiguredStatus: upcloud.NetworkPeeringConfiguredStatusDisabled,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if p.State != upcloud.NetworkPeeringStateDisabled {
+		if _, err := s.WaitForNetworkPeeringState(ctx, &request.WaitForNetworkPeeringStateRequest{
+			UUID:         r.UUID,
+			DesiredState: upcloud.NetworkPeeringStateDisabled,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return s.DeleteNetworkPeering(ctx, r)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
@@ -204,6 +205,31 @@ func TestDeleteNetworkPeering(t *testing.T) {
 	assert.NoError(t, svc.DeleteNetworkPeering(context.TODO(), &request.DeleteNetworkPeeringRequest{UUID: "_UUID_"}))
 }
 
+func TestDisableAndDeleteNetworkPeering(t *testing.T) {
+	t.Parallel()
+
+	const path = "/network-peering/0f7984bc-5d72-4aaf-b587-90e6a8f32efc"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"network_peering":{"uuid":"0f7984bc-5d72-4aaf-b587-90e6a8f32efc","configured_status":"active","state":"active"}}`).Once()
+	m.On(http.MethodPatch, path).Reply(http.StatusOK, `{"network_peering":{"uuid":"0f7984bc-5d72-4aaf-b587-90e6a8f32efc","configured_status":"disabled","state":"active"}}`)
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"network_peering":{"uuid":"0f7984bc-5d72-4aaf-b587-90e6a8f32efc","configured_status":"disabled","state":"provisioning"}}`).Once()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"network_peering":{"uuid":"0f7984bc-5d72-4aaf-b587-90e6a8f32efc","configured_status":"disabled","state":"disabled"}}`)
+	m.On(http.MethodDelete, path).Reply(http.StatusNoContent, "")
+
+	require.NoError(t, svc.DisableAndDeleteNetworkPeering(context.Background(), &request.DeleteNetworkPeeringRequest{UUID: "0f7984bc-5d72-4aaf-b587-90e6a8f32efc"}))
+	calls := m.Calls()
+	require.Len(t, calls, 5)
+	assert.JSONEq(t, `{"network_peering":{"configured_status":"disabled"}}`, string(calls[1].Body))
+	assert.Equal(t, http.MethodDelete, calls[4].Method)
+
+	// Disabled peering is deleted right away
+	m, svc = setupMockTransportAndService()
+	m.On(http.MethodGet, path).Reply(http.StatusOK, `{"network_peering":{"uuid":"0f7984bc-5d72-4aaf-b587-90e6a8f32efc","configured_status":"disabled","state":"disabled"}}`)
+	m.On(http.MethodDelete, path).Reply(http.StatusNoContent, "")
+	require.NoError(t, svc.DisableAndDeleteNetworkPeering(context.Background(), &request.DeleteNetworkPeeringRequest{UUID: "0f7984bc-5d72-4aaf-b587-90e6a8f32efc"}))
+	assert.Len(t, m.Calls(), 2)
+}
+
 func checkNetworkPeeringResponse(t *testing.T, p *upcloud.NetworkPeering) {
 	assert.Equal(t, "03585987-bf7d-4544-8e9b-5a1b4d74a333", p.PeerNetwork.UUID)
 	assert.Equal(t, "03126dc1-a69f-4bc2-8b24-e31c22d64712", p.Network.UUID)
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// ErrOperationNotCancellable is returned when cancelling an operation that the API does not allow to cancel
+var ErrOperationNotCancellable = errors.New("operation can not be cancelled")
+
+// Operation is a handle to an asynchronous operation, such as cloning a storage. The API request starting the operation
+// returns immediately, and the operation can then be polled or waited on.
+type Operation[T any] struct {
+	// Details are the details returned by the request that started the operation
+	Details *T
+
+	poll   func(ctx context.Context) (*T, bool, error)
+	cancel func(ctx context.Context) error
+	config *retryConfig
+}
+
+// Poll fetches the current details of the operation and reports whether the operation has completed. Failed operation
+// returns an error.
+func (o *Operation[T]) Poll(ctx context.Context) (*T, bool, error) {
+	details, done, err := o.poll(ctx)
+	if details != nil {
+		o.Details = details
+	}
+	return details, done, err
+}
+
+// Wait blocks until the operation has completed and returns its final details. The method will give up when the
+// context is done.
+func (o *Operation[T]) Wait(ctx context.Context) (*T, error) {
+	return retry(ctx, func(_ int, c context.Context) (*T, error) {
+		details, done, err := o.Poll(c)
+		if err != nil || done {
+			return details, err
+		}
+		return nil, nil
+	}, o.config)
+}
+
+// Cancel cancels the operation. ErrOperationNotCancellable is returned if the operation can not be cancelled.
+func (o *Operation[T]) Cancel(ctx context.Context) error {
+	if o.cancel == nil {
+		return ErrOperationNotCancellable
+	}
+	return o.cancel(ctx)
+}
+
+// CloneStorageOperation starts cloning the storage. The operation completes when the new storage is online.
+func (s *Service) CloneStorageOperation(ctx context.Context, r *request.CloneStorageRequest) (*Operation[upcloud.StorageDetails], error) {
+	details, err := s.CloneStorage(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return s.storageOperation(details), nil
+}
+
+// TemplatizeStorageOperation starts creating a template from the storage. The operation completes when the template is
+// online.
+func (s *Service) TemplatizeStorageOperation(ctx context.Context, r *request.TemplatizeStorageRequest) (*Operation[upcloud.StorageDetails], error) {
+	details, err := s.TemplatizeStorage(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return s.storageOperation(details), nil
+}
+
+// CreateBackupOperation starts backing up the storage. The operation completes when the backup is online.
+func (s *Service) CreateBackupOperation(ctx context.Context, r *request.CreateBackupRequest) (*Operation[upcloud.StorageDetails], error) {
+	details, err := s.CreateBackup(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return s.storageOperation(details), nil
+}
+
+// CreateStorageImportOperation starts importing an image to the storage. The operation completes when the import has
+// completed, and can be cancelled while the import is in progress.
+func (s *Service) CreateStorageImportOperation(ctx context.Context, r *request.CreateStorageImportRequest) (*Operation[upcloud.StorageImportDetails], error) {
+	details, err := s.CreateStorageImport(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	uuid := r.StorageUUID
+	return &Operation[upcloud.StorageImportDetails]{
+		Details: details,
+		poll: func(ctx context.Context) (*upcloud.StorageImportDetails, bool, error) {
+			details, err := s.GetStorageImportDetails(ctx, &request.GetStorageImportDetailsRequest{UUID: uuid})
+			if err != nil {
+				return nil, false, err
+			}
+			switch details.State {
+			case upcloud.StorageImportStateCompleted:
+				return details, true, nil
+			case upcloud.StorageImportStateCancelled, upcloud.StorageImportStateCancelling, upcloud.StorageImportStateFailed:
+				return details, true, storageImportProblem(details)
+			}
+			return details, false, nil
+		},
+		cancel: func(ctx context.Context) error {
+			_, err := s.CancelStorageImport(ctx, &request.CancelStorageImportRequest{StorageUUID: uuid})
+			return err
+		},
+		config: s.retryConfig(),
+	}, nil
+}
+
+// storageOperation returns an operation that completes when the storage is online
+func (s *Service) storageOperation(storage *upcloud.StorageDetails) *Operation[upcloud.StorageDetails] {
+	uuid := storage.UUID
+	return &Operation[upcloud.StorageDetails]{
+		Details: storage,
+		poll: func(ctx context.Context) (*upcloud.StorageDetails, bool, error) {
+			details, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: uuid})
+			if err != nil {
+				return nil, false, err
+			}
+			switch details.State {
+			case upcloud.StorageStateOnline:
+				return details, true, nil
+			case upcloud.StorageStateError:
+				return details, true, fmt.Errorf("storage %s is in %s state", uuid, details.State)
+			}
+			return details, false, nil
+		},
+		config: s.retryConfig(),
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneStorageOperation(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/storage/source/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone").Reply(http.StatusOK, `{"storage":{"uuid":"clone","state":"maintenance"}}`).Once()
+	m.On(http.MethodGet, "/storage/clone").Reply(http.StatusOK, `{"storage":{"uuid":"clone","state":"online"}}`)
+
+	op, err := svc.CloneStorageOperation(context.Background(), &request.CloneStorageRequest{UUID: "source", Title: "clone"})
+	require.NoError(t, err)
+	assert.Equal(t, "clone", op.Details.UUID)
+
+	_, done, err := op.Poll(context.Background())
+	require.NoError(t, err)
+	assert.False(t, done)
+
+	details, err := op.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.StorageStateOnline, details.State)
+	assert.Equal(t, details, op.Details)
+	assert.ErrorIs(t, op.Cancel(context.Background()), ErrOperationNotCancellable)
+	m.AssertExpectations(t)
+}
+
+func TestCreateBackupOperation_error(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/storage/source/backup").Reply(http.StatusCreated, `{"storage":{"uuid":"backup","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/backup").Reply(http.StatusOK, `{"storage":{"uuid":"backup","state":"error"}}`)
+
+	op, err := svc.CreateBackupOperation(context.Background(), &request.CreateBackupRequest{UUID: "source", Title: "backup"})
+	require.NoError(t, err)
+	_, err = op.Wait(context.Background())
+	assert.EqualError(t, err, "storage backup is in error state")
+}
+
+func TestCreateStorageImportOperation(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/storage/uuid/import").Reply(http.StatusCreated, `{"storage_import":{"uuid":"import","state":"prepared"}}`)
+	m.On(http.MethodPost, "/storage/uuid/import/cancel").Reply(http.StatusOK, `{"storage_import":{"uuid":"import","state":"cancelling"}}`)
+	m.On(http.MethodGet, "/storage/uuid/import").Reply(http.StatusOK, `{"storage_import":{"uuid":"import","state":"cancelled"}}`)
+
+	op, err := svc.CreateStorageImportOperation(context.Background(), &request.CreateStorageImportRequest{
+		StorageUUID:    "uuid",
+		Source:         request.StorageImportSourceHTTPImport,
+		SourceLocation: "https://example.com/image.img",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.StorageImportStatePrepared, op.Details.State)
+	require.NoError(t, op.Cancel(context.Background()))
+
+	details, err := op.Wait(context.Background())
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, upcloud.StorageImportStateCancelled, problem.Type)
+	assert.Equal(t, upcloud.StorageImportStateCancelled, details.State)
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// Storage size limits in gigabytes checked by PreflightCreateServer
+const (
+	preflightStorageSizeMin = 1
+	preflightStorageSizeMax = 4096
+)
+
+// PreflightError lists the problems found in a request before sending it to the API
+type PreflightError struct {
+	Violations []string
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("preflight check failed: %s", strings.Join(e.Violations, "; "))
+}
+
+// PreflightCreateServer checks that the zone of the request exists, the plan and storage tiers are available in the
+// zone, the cloned and attached storages exist in the zone, the storage sizes are within limits and simple backup is
+// not combined with backup rules. All found
+// problems are returned at once as *PreflightError. Other errors are returned as is.
+func (s *Service) PreflightCreateServer(ctx context.Context, r *request.CreateServerRequest) error {
+	capabilities, err := s.GetZoneCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	var zone *upcloud.ZoneCapabilities
+	for i := range capabilities {
+		if capabilities[i].Zone.ID == r.Zone {
+			zone = &capabilities[i]
+		}
+	}
+
+	var violations []string
+	if zone == nil {
+		violations = append(violations, fmt.Sprintf("zone %q does not exist", r.Zone))
+	} else if r.Plan != "" && r.Plan != "custom" && !zone.HasPlan(r.Plan) {
+		violations = append(violations, fmt.Sprintf("plan %q is not available in zone %s", r.Plan, r.Zone))
+	}
+
+	if err := r.ValidateBackups(); err != nil {
+		violations = append(violations, err.Error())
+	}
+
+	for i, device := range r.StorageDevices {
+		prefix := fmt.Sprintf("storage device %d", i+1)
+		if device.Tier != "" && zone != nil && !zone.HasStorageTier(device.Tier) {
+			violations = append(violations, fmt.Sprintf("%s: storage tier %q is not available in zone %s", prefix, device.Tier, r.Zone))
+		}
+		if device.Action != request.CreateServerStorageDeviceActionAttach && device.Size != 0 &&
+			(device.Size < preflightStorageSizeMin || device.Size > preflightStorageSizeMax) {
+			violations = append(violations, fmt.Sprintf("%s: size %d GB is not between %d and %d GB", prefix, device.Size, preflightStorageSizeMin, preflightStorageSizeMax))
+		}
+		if device.Action == request.CreateServerStorageDeviceActionCreate {
+			continue
+		}
+
+		storage, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: device.Storage})
+		if err != nil {
+			if upcloud.IsNotFound(err) {
+				violations = append(violations, fmt.Sprintf("%s: storage %s does not exist", prefix, device.Storage))
+				continue
+			}
+			return err
+		}
+
+		// Public templates are available in all zones
+		if storage.Zone != "" && storage.Zone != r.Zone && storage.Access != upcloud.StorageAccessPublic {
+			violations = append(violations, fmt.Sprintf("%s: storage %s is in zone %s", prefix, device.Storage, storage.Zone))
+		}
+		switch device.Action {
+		case request.CreateServerStorageDeviceActionClone:
+			if device.Size != 0 && device.Size < storage.Size {
+				violations = append(violations, fmt.Sprintf("%s: size %d GB is smaller than the %d GB of the cloned storage", prefix, device.Size, storage.Size))
+			}
+		case request.CreateServerStorageDeviceActionAttach:
+			if len(storage.ServerUUIDs) > 0 {
+				violations = append(violations, fmt.Sprintf("%s: storage %s is already attached to a server", prefix, device.Storage))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PreflightError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+)
+
+func setupPreflightMocks(m *upcloudtest.MockTransport) {
+	m.On(http.MethodGet, "/zone").ReplyCorpus(upcloudtest.CorpusZones)
+	m.On(http.MethodGet, "/price").ReplyCorpus(upcloudtest.CorpusPrices)
+	m.On(http.MethodGet, "/plan").ReplyCorpus(upcloudtest.CorpusPlans)
+	m.On(http.MethodGet, "/storage/template").Reply(http.StatusOK, `{"storage":{"uuid":"template","access":"public","type":"template","size":10,"zone":"de-fra1"}}`)
+	m.On(http.MethodGet, "/storage/private").Reply(http.StatusOK, `{"storage":{"uuid":"private","access":"private","type":"disk","size":10,"zone":"de-fra1","servers":{"server":["server"]}}}`)
+	m.On(http.MethodGet, "/storage/missing").ReplyError(http.StatusNotFound, upcloud.ErrCodeStorageNotFound, "The storage missing does not exist.")
+}
+
+func TestPreflightCreateServer(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	setupPreflightMocks(m)
+
+	r, err := request.NewServerBuilder().
+		Zone("fi-hel1").
+		Plan("1xCPU-1GB").
+		Hostname("web").
+		CloneStorage("template", 25).
+		CreateStorage(10, upcloud.StorageTierMaxIOPS).
+		Build()
+	require.NoError(t, err)
+	assert.NoError(t, svc.PreflightCreateServer(context.Background(), r))
+}
+
+func TestPreflightCreateServer_violations(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	setupPreflightMocks(m)
+
+	err := svc.PreflightCreateServer(context.Background(), &request.CreateServerRequest{
+		Zone: "fi-hel1",
+		Plan: "64xCPU-1TB",
+		StorageDevices: request.CreateServerStorageDeviceSlice{
+			{Action: request.CreateServerStorageDeviceActionClone, Storage: "template", Size: 5},
+			{Action: request.CreateServerStorageDeviceActionCreate, Size: 5000, Tier: "archive"},
+			{Action: request.CreateServerStorageDeviceActionAttach, Storage: "private"},
+			{Action: request.CreateServerStorageDeviceActionClone, Storage: "missing"},
+		},
+	})
+
+	var preflightErr *PreflightError
+	require.ErrorAs(t, err, &preflightErr)
+	assert.Equal(t, []string{
+		`plan "64xCPU-1TB" is not available in zone fi-hel1`,
+		"storage device 1: size 5 GB is smaller than the 10 GB of the cloned storage",
+		`storage device 2: storage tier "archive" is not available in zone fi-hel1`,
+		"storage device 2: size 5000 GB is not between 1 and 4096 GB",
+		"storage device 3: storage private is in zone de-fra1",
+		"storage device 3: storage private is already attached to a server",
+		"storage device 4: storage missing does not exist",
+	}, preflightErr.Violations)
+
+	err = svc.PreflightCreateServer(context.Background(), &request.CreateServerRequest{Zone: "xx-xxx1"})
+	assert.EqualError(t, err, `preflight check failed: zone "xx-xxx1" does not exist`)
+}
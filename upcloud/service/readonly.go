@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnlyMode is the error matched by errors.Is when a mutating request is made with a read-only service.
+var ErrReadOnlyMode = errors.New("service is in read-only mode")
+
+// ReadOnlyModeError is returned by mutating methods of a service configured with WithReadOnly. No request is sent to
+// the API.
+type ReadOnlyModeError struct {
+	Method   string
+	Location string
+}
+
+func (e *ReadOnlyModeError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Method, e.Location, ErrReadOnlyMode)
+}
+
+// Unwrap returns ErrReadOnlyMode
+func (e *ReadOnlyModeError) Unwrap() error {
+	return ErrReadOnlyMode
+}
+
+// WithReadOnly makes the service refuse all requests other than GET and HEAD requests. Mutating methods return
+// *ReadOnlyModeError without performing HTTP calls, while reads work normally.
+func WithReadOnly() ConfigFn {
+	return func(c *config) {
+		c.readOnly = true
+	}
+}
+
+// readOnlyClient passes GET and HEAD requests to the wrapped client and rejects other requests.
+type readOnlyClient struct {
+	client Client
+}
+
+func (c readOnlyClient) Get(ctx context.Context, path string) ([]byte, error) {
+	return c.client.Get(ctx, path)
+}
+
+func (c readOnlyClient) Post(_ context.Context, path string, _ []byte) ([]byte, error) {
+	return nil, &ReadOnlyModeError{Method: http.MethodPost, Location: path}
+}
+
+func (c readOnlyClient) Put(_ context.Context, path string, _ []byte) ([]byte, error) {
+	return nil, &ReadOnlyModeError{Method: http.MethodPut, Location: path}
+}
+
+func (c readOnlyClient) Patch(_ context.Context, path string, _ []byte) ([]byte, error) {
+	return nil, &ReadOnlyModeError{Method: http.MethodPatch, Location: path}
+}
+
+func (c readOnlyClient) Delete(_ context.Context, path string) ([]byte, error) {
+	return nil, &ReadOnlyModeError{Method: http.MethodDelete, Location: path}
+}
+
+func (c readOnlyClient) Do(r *http.Request) ([]byte, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return nil, &ReadOnlyModeError{Method: r.Method, Location: r.URL.String()}
+	}
+	return c.client.Do(r)
+}
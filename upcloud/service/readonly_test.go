@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceReadOnly(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithReadOnly())
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid"}}`)
+
+	server, err := svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, "uuid", server.UUID)
+
+	_, err = svc.CreateServer(context.Background(), &request.CreateServerRequest{Zone: "fi-hel1"})
+	var readOnlyErr *ReadOnlyModeError
+	require.ErrorAs(t, err, &readOnlyErr)
+	assert.Equal(t, http.MethodPost, readOnlyErr.Method)
+	assert.Equal(t, "/server", readOnlyErr.Location)
+
+	_, err = svc.ModifyServer(context.Background(), &request.ModifyServerRequest{UUID: "uuid"})
+	assert.ErrorIs(t, err, ErrReadOnlyMode)
+	_, err = svc.ModifyStorage(context.Background(), &request.ModifyStorageRequest{UUID: "uuid"})
+	assert.ErrorIs(t, err, ErrReadOnlyMode)
+	assert.ErrorIs(t, svc.DeleteServer(context.Background(), &request.DeleteServerRequest{UUID: "uuid"}), ErrReadOnlyMode)
+	_, err = svc.ReplaceManagedObjectStorage(context.Background(), &request.ReplaceManagedObjectStorageRequest{UUID: "uuid"})
+	assert.ErrorIs(t, err, ErrReadOnlyMode)
+
+	assert.EqualError(t, &ReadOnlyModeError{Method: http.MethodDelete, Location: "/server/uuid"}, "DELETE /server/uuid: service is in read-only mode")
+	require.Len(t, m.Calls(), 1)
+}
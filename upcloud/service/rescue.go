@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// rescueBootOrder boots the server from the CD-ROM device before the disks
+const rescueBootOrder = "cdrom,disk"
+
+// RescueBoot boots the server from an ISO: the server is stopped, the ISO is loaded into the CD-ROM device of the
+// server, which is attached if the server has none, the boot order is set to boot from the CD-ROM first and the
+// server is started. The returned session records the previous configuration, which ExitRescue restores. If the
+// server can not be booted from the ISO, the previous configuration is restored, even if ctx is done.
+func (s *Service) RescueBoot(ctx context.Context, r *request.RescueBootRequest) (*upcloud.RescueSession, error) {
+	server, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+	session := &upcloud.RescueSession{
+		ServerUUID:  server.UUID,
+		StorageUUID: r.StorageUUID,
+		BootOrder:   server.BootOrder,
+		Started:     server.State == upcloud.ServerStateStarted,
+	}
+	if err := s.stopForRescue(ctx, server, r.StopType, r.Timeout); err != nil {
+		return nil, err
+	}
+
+	if err := s.bootFromISO(ctx, session); err != nil {
+		cleanupCtx, cancel := cleanupContext(ctx)
+		defer cancel()
+		if _, restoreErr := s.restoreRescue(cleanupCtx, session, r.StopType, r.Timeout); restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("restoring server %s: %w", session.ServerUUID, restoreErr))
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// ExitRescue reverses RescueBoot: the server is stopped, the ISO is ejected, or the CD-ROM device detached if it was
+// attached for the rescue boot, and the previous boot order is restored. The server is started if it was started
+// before the rescue boot. The details of the server are returned.
+func (s *Service) ExitRescue(ctx context.Context, r *request.ExitRescueRequest) (*upcloud.ServerDetails, error) {
+	return s.restoreRescue(ctx, &r.Session, r.StopType, r.Timeout)
+}
+
+// bootFromISO loads the ISO of the session into the CD-ROM device of the stopped server and starts the server from it.
+// The changes are recorded in the session as they are made.
+func (s *Service) bootFromISO(ctx context.Context, session *upcloud.RescueSession) error {
+	server, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: session.ServerUUID})
+	if err != nil {
+		return err
+	}
+	cdrom := serverCDROM(server)
+	if cdrom != nil {
+		session.CDROMAddress = cdrom.Address
+		if cdrom.UUID != "" {
+			if _, err := s.EjectCDROM(ctx, &request.EjectCDROMRequest{ServerUUID: server.UUID}); err != nil {
+				return fmt.Errorf("ejecting storage %s: %w", cdrom.UUID, err)
+			}
+			session.PreviousCDROM = cdrom.UUID
+		}
+		if _, err := s.LoadCDROM(ctx, &request.LoadCDROMRequest{ServerUUID: server.UUID, StorageUUID: session.StorageUUID}); err != nil {
+			return fmt.Errorf("loading storage %s: %w", session.StorageUUID, err)
+		}
+	} else {
+		details, err := s.AttachStorage(ctx, &request.AttachStorageRequest{
+			ServerUUID:  server.UUID,
+			Type:        upcloud.StorageTypeCDROM,
+			StorageUUID: session.StorageUUID,
+		})
+		if err != nil {
+			return fmt.Errorf("attaching storage %s: %w", session.StorageUUID, err)
+		}
+		session.CDROMAttached = true
+		if cdrom := serverCDROM(details); cdrom != nil {
+			session.CDROMAddress = cdrom.Address
+		}
+	}
+
+	if _, err := s.ModifyServer(ctx, &request.ModifyServerRequest{UUID: server.UUID, BootOrder: rescueBootOrder}); err != nil {
+		return fmt.Errorf("setting boot order: %w", err)
+	}
+	if _, err := s.StartServer(ctx, &request.StartServerRequest{UUID: server.UUID}); err != nil {
+		return err
+	}
+	_, err = s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: server.UUID, DesiredState: upcloud.ServerStateStarted})
+	return err
+}
+
+// restoreRescue restores the configuration recorded in the session. The steps that were not taken, e.g. because
+// RescueBoot failed, are skipped.
+func (s *Service) restoreRescue(ctx context.Context, session *upcloud.RescueSession, stopType string, timeout time.Duration) (*upcloud.ServerDetails, error) {
+	server, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: session.ServerUUID, UndesiredState: upcloud.ServerStateMaintenance})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.stopForRescue(ctx, server, stopType, timeout); err != nil {
+		return nil, err
+	}
+
+	var cdrom *upcloud.ServerStorageDevice
+	if session.CDROMAddress != "" {
+		cdrom = server.StorageDeviceByAddress(session.CDROMAddress)
+	}
+	switch {
+	case cdrom == nil:
+	case session.CDROMAttached:
+		if _, err := s.DetachStorage(ctx, &request.DetachStorageRequest{ServerUUID: server.UUID, Address: cdrom.Address}); err != nil {
+			return nil, fmt.Errorf("detaching CD-ROM device %s: %w", cdrom.Address, err)
+		}
+	case cdrom.UUID != session.PreviousCDROM:
+		if cdrom.UUID != "" {
+			if _, err := s.EjectCDROM(ctx, &request.EjectCDROMRequest{ServerUUID: server.UUID}); err != nil {
+				return nil, fmt.Errorf("ejecting storage %s: %w", cdrom.UUID, err)
+			}
+		}
+		if session.PreviousCDROM != "" {
+			if _, err := s.LoadCDROM(ctx, &request.LoadCDROMRequest{ServerUUID: server.UUID, StorageUUID: session.PreviousCDROM}); err != nil {
+				return nil, fmt.Errorf("loading storage %s: %w", session.PreviousCDROM, err)
+			}
+		}
+	}
+
+	if session.BootOrder != "" && server.BootOrder != session.BootOrder {
+		if _, err := s.ModifyServer(ctx, &request.ModifyServerRequest{UUID: server.UUID, BootOrder: session.BootOrder}); err != nil {
+			return nil, fmt.Errorf("restoring boot order: %w", err)
+		}
+	}
+	if session.Started {
+		if _, err := s.StartServer(ctx, &request.StartServerRequest{UUID: server.UUID}); err != nil {
+			return nil, err
+		}
+		return s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: server.UUID, DesiredState: upcloud.ServerStateStarted})
+	}
+	return s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: server.UUID})
+}
+
+// stopForRescue stops the server, unless it is stopped already, and waits for it to stop
+func (s *Service) stopForRescue(ctx context.Context, server *upcloud.ServerDetails, stopType string, timeout time.Duration) error {
+	if server.State == upcloud.ServerStateStopped {
+		return nil
+	}
+	if err := upcloud.CheckServerTransition(server, upcloud.ServerOperationStop); err != nil {
+		return err
+	}
+	if _, err := s.StopServer(ctx, &request.StopServerRequest{UUID: server.UUID, StopType: stopType, Timeout: timeout}); err != nil {
+		return err
+	}
+	_, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: server.UUID, DesiredState: upcloud.ServerStateStopped})
+	return err
+}
+
+// serverCDROM returns the CD-ROM device of the server, or nil if the server has none
+func serverCDROM(server *upcloud.ServerDetails) *upcloud.ServerStorageDevice {
+	for i := range server.StorageDevices {
+		if server.StorageDevices[i].IsCDROM() {
+			return &server.StorageDevices[i]
+		}
+	}
+	return nil
+}
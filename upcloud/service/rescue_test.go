@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rescueServerJSON(state, bootOrder string, cdrom *string) string {
+	devices := `{"address":"virtio:0","storage":"disk","type":"disk"}`
+	if cdrom != nil {
+		devices += fmt.Sprintf(`,{"address":"ide:0:0","storage":%q,"type":"cdrom"}`, *cdrom)
+	}
+	return fmt.Sprintf(`{"server":{"uuid":"uuid","state":%q,"boot_order":%q,"storage_devices":{"storage_device":[%s]}}}`, state, bootOrder, devices)
+}
+
+func TestRescueBoot(t *testing.T) {
+	t.Parallel()
+
+	empty, iso := "", "iso"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	for _, reply := range []string{
+		rescueServerJSON(upcloud.ServerStateStarted, "disk", &empty),
+		rescueServerJSON(upcloud.ServerStateStopped, "disk", &empty),
+		rescueServerJSON(upcloud.ServerStateStopped, "disk", &empty),
+		rescueServerJSON(upcloud.ServerStateStarted, rescueBootOrder, &iso),
+		rescueServerJSON(upcloud.ServerStateStarted, rescueBootOrder, &iso),
+		rescueServerJSON(upcloud.ServerStateStopped, rescueBootOrder, &iso),
+		rescueServerJSON(upcloud.ServerStateStarted, "disk", &empty),
+	} {
+		m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, reply).Once()
+	}
+	m.On(http.MethodPost, "/server/uuid/stop").Reply(http.StatusAccepted, rescueServerJSON(upcloud.ServerStateStarted, "disk", &empty))
+	m.On(http.MethodPost, "/server/uuid/cdrom/load").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, "disk", &iso))
+	m.On(http.MethodPost, "/server/uuid/cdrom/eject").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, rescueBootOrder, &empty))
+	m.On(http.MethodPut, "/server/uuid").Reply(http.StatusAccepted, rescueServerJSON(upcloud.ServerStateStopped, rescueBootOrder, &iso))
+	m.On(http.MethodPost, "/server/uuid/start").Reply(http.StatusAccepted, rescueServerJSON(upcloud.ServerStateStarted, rescueBootOrder, &iso))
+
+	session, err := svc.RescueBoot(context.Background(), &request.RescueBootRequest{ServerUUID: "uuid", StorageUUID: "iso"})
+	require.NoError(t, err)
+	assert.Equal(t, &upcloud.RescueSession{
+		ServerUUID:   "uuid",
+		StorageUUID:  "iso",
+		BootOrder:    "disk",
+		CDROMAddress: "ide:0:0",
+		Started:      true,
+	}, session)
+
+	server, err := svc.ExitRescue(context.Background(), &request.ExitRescueRequest{Session: *session})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStarted, server.State)
+	assert.Equal(t, "disk", server.BootOrder)
+
+	var steps []string
+	for _, call := range m.Calls() {
+		if call.Method != http.MethodGet {
+			steps = append(steps, call.Method+" "+call.Path+" "+string(call.Body))
+		}
+	}
+	assert.Equal(t, []string{
+		`POST /server/uuid/stop {"stop_server":{}}`,
+		`POST /server/uuid/cdrom/load {"storage_device":{"storage":"iso"}}`,
+		`PUT /server/uuid {"server":{"boot_order":"cdrom,disk"}}`,
+		`POST /server/uuid/start {"server":{}}`,
+		`POST /server/uuid/stop {"stop_server":{}}`,
+		`POST /server/uuid/cdrom/eject {"ServerUUID":"uuid"}`,
+		`PUT /server/uuid {"server":{"boot_order":"disk"}}`,
+		`POST /server/uuid/start {"server":{}}`,
+	}, steps)
+	m.AssertExpectations(t)
+}
+
+func TestRescueBoot_restoreOnFailure(t *testing.T) {
+	t.Parallel()
+
+	iso := "iso"
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, "disk", nil)).Times = 2
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, "disk", &iso))
+	m.On(http.MethodPost, "/server/uuid/storage/attach").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, "disk", &iso))
+	m.On(http.MethodPut, "/server/uuid").ReplyError(http.StatusBadRequest, "BOOT_ORDER_INVALID", "invalid boot order")
+	m.On(http.MethodPost, "/server/uuid/storage/detach").Reply(http.StatusOK, rescueServerJSON(upcloud.ServerStateStopped, "disk", nil))
+
+	_, err := svc.RescueBoot(context.Background(), &request.RescueBootRequest{ServerUUID: "uuid", StorageUUID: "iso"})
+	assert.ErrorContains(t, err, "setting boot order")
+	assert.Equal(t, 1, m.Called(http.MethodPost, "/server/uuid/storage/detach"))
+	assert.Zero(t, m.Called(http.MethodPost, "/server/uuid/start"))
+	m.AssertExpectations(t)
+}
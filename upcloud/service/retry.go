@@ -2,13 +2,25 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 )
 
+// deadlineMargin is the time reserved before the context deadline for the final attempt of the operation.
+const deadlineMargin = time.Second
+
 type retryConfig struct {
 	interval time.Duration
+	// Backoff strategy used to determine the delay before each attempt. Defaults to constant interval.
+	backoff client.BackoffStrategy
+	// Timeout of the operation when the context has no deadline. Zero means no timeout.
+	timeout time.Duration
 	// Inverse the should retry logic. By default, operation is retried until operation returns a value. If inverse is set to true, operation is retried while operation returns a value. This should be used, for example, for waiting until resource is deleted.
 	inverse bool
+	// Logger for the polls and failures of the operation. Nil disables logging.
+	logger *slog.Logger
 }
 
 func fillDefaults(c *retryConfig) *retryConfig {
@@ -20,20 +32,33 @@ func fillDefaults(c *retryConfig) *retryConfig {
 		c.interval = time.Second * 5
 	}
 
+	if c.backoff == nil {
+		c.backoff = client.ConstantBackoff{Interval: c.interval}
+	}
+
 	return c
 }
 
 func retry[T any](ctx context.Context, operation func(int, context.Context) (*T, error), config *retryConfig) (*T, error) {
 	config = fillDefaults(config)
+	if _, ok := ctx.Deadline(); !ok && config.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.timeout)
+		defer cancel()
+	}
 
-	ticker := time.NewTicker(config.interval)
-	defer ticker.Stop()
-
+	start := time.Now()
+	var delay time.Duration
 	for i := 0; ; i++ {
+		delay = config.backoff.Backoff(i, delay)
+		timer := time.NewTimer(deadlineDelay(ctx, delay))
+
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			config.log(ctx, slog.LevelDebug, "wait poll", slog.Int("attempt", i), slog.Duration("delay", delay))
 			value, err := operation(i, ctx)
 			if err != nil {
+				config.log(ctx, slog.LevelError, "wait failed", slog.Int("attempt", i), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
 				return value, err
 			}
 			if !config.inverse && value != nil {
@@ -43,7 +68,30 @@ func retry[T any](ctx context.Context, operation func(int, context.Context) (*T,
 				return nil, nil
 			}
 		case <-ctx.Done():
+			timer.Stop()
+			config.log(ctx, slog.LevelError, "wait failed", slog.Int("attempt", i), slog.Duration("duration", time.Since(start)), slog.Any("error", ctx.Err()))
 			return nil, ctx.Err()
 		}
 	}
 }
+
+func (c *retryConfig) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+// deadlineDelay shortens the delay so that the operation is attempted once more before the context deadline instead
+// of waiting past it.
+func deadlineDelay(ctx context.Context, delay time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return delay
+	}
+
+	remaining := time.Until(deadline) - deadlineMargin
+	if remaining > 0 && delay > remaining {
+		return remaining
+	}
+	return delay
+}
@@ -1,10 +1,13 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
 	"testing"
 	"time"
 
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -83,3 +86,74 @@ func TestRetry_noInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestRetry_backoff(t *testing.T) {
+	t.Parallel()
+
+	var delays []time.Duration
+	backoff := client.BackoffFunc(func(attempt int, previous time.Duration) time.Duration {
+		delays = append(delays, previous)
+		return time.Duration(attempt+1) * time.Millisecond
+	})
+
+	value, err := retry(context.TODO(), func(i int, _ context.Context) (*int, error) {
+		if i < 2 {
+			return nil, nil
+		}
+		return &i, nil
+	}, &retryConfig{backoff: backoff})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *value)
+	assert.Equal(t, []time.Duration{0, time.Millisecond, 2 * time.Millisecond}, delays)
+}
+
+func TestRetry_deadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin+250*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	value, err := retry(ctx, func(i int, _ context.Context) (*int, error) {
+		return &i, nil
+	}, &retryConfig{interval: time.Minute})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, *value)
+	assert.Less(t, time.Since(start), deadlineMargin)
+}
+
+func TestDeadlineDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Minute, deadlineDelay(context.Background(), time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin+10*time.Second)
+	defer cancel()
+	assert.Equal(t, time.Second, deadlineDelay(ctx, time.Second))
+	assert.InDelta(t, 10*time.Second, deadlineDelay(ctx, time.Minute), float64(time.Second))
+
+	short, cancel := context.WithTimeout(context.Background(), deadlineMargin/2)
+	defer cancel()
+	assert.Equal(t, time.Minute, deadlineDelay(short, time.Minute))
+}
+
+func TestRetry_logger(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := retry(ctx, func(i int, _ context.Context) (*string, error) {
+		return nil, nil
+	}, &retryConfig{backoff: client.ConstantBackoff{Interval: 10 * time.Millisecond}, logger: logger})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, logs.String(), `level=DEBUG msg="wait poll" attempt=0 delay=10ms`)
+	assert.Contains(t, logs.String(), `level=DEBUG msg="wait poll" attempt=1 delay=10ms`)
+	assert.Contains(t, logs.String(), `level=ERROR msg="wait failed"`)
+	assert.Contains(t, logs.String(), `error="context deadline exceeded"`)
+}
@@ -9,9 +9,15 @@ import (
 
 type Server interface {
 	GetServerConfigurations(ctx context.Context) (*upcloud.ServerConfigurations, error)
+	GetServerConfigurationsWithFilters(ctx context.Context, r *request.GetServerConfigurationsRequest) (*upcloud.ServerConfigurations, error)
 	GetServers(ctx context.Context) (*upcloud.Servers, error)
+	GetServersWithFilters(ctx context.Context, r *request.GetServersWithFiltersRequest) (*upcloud.Servers, error)
 	GetServerDetails(ctx context.Context, r *request.GetServerDetailsRequest) (*upcloud.ServerDetails, error)
 	CreateServer(ctx context.Context, r *request.CreateServerRequest) (*upcloud.ServerDetails, error)
+	PreflightCreateServer(ctx context.Context, r *request.CreateServerRequest) error
+	PreflightModifyServer(ctx context.Context, r *request.ModifyServerRequest) error
+	MigrateToSimpleBackup(ctx context.Context, r *request.MigrateToSimpleBackupRequest) (*upcloud.ServerDetails, error)
+	MigrateToBackupRules(ctx context.Context, r *request.MigrateToBackupRulesRequest) (*upcloud.ServerDetails, error)
 	WaitForServerState(ctx context.Context, r *request.WaitForServerStateRequest) (*upcloud.ServerDetails, error)
 	StartServer(ctx context.Context, r *request.StartServerRequest) (*upcloud.ServerDetails, error)
 	StopServer(ctx context.Context, r *request.StopServerRequest) (*upcloud.ServerDetails, error)
@@ -19,6 +25,10 @@ type Server interface {
 	ModifyServer(ctx context.Context, r *request.ModifyServerRequest) (*upcloud.ServerDetails, error)
 	DeleteServer(ctx context.Context, r *request.DeleteServerRequest) error
 	DeleteServerAndStorages(ctx context.Context, r *request.DeleteServerAndStoragesRequest) error
+	GetServersWithDetails(ctx context.Context, r *request.GetServersWithDetailsRequest) ([]upcloud.ServerDetails, error)
+	MigrateServerToZone(ctx context.Context, r *request.MigrateServerToZoneRequest) (*upcloud.ServerDetails, error)
+	RescueBoot(ctx context.Context, r *request.RescueBootRequest) (*upcloud.RescueSession, error)
+	ExitRescue(ctx context.Context, r *request.ExitRescueRequest) (*upcloud.ServerDetails, error)
 }
 
 // GetServerConfigurations returns the available pre-configured server configurations
@@ -27,13 +37,26 @@ func (s *Service) GetServerConfigurations(ctx context.Context) (*upcloud.ServerC
 	return &serverConfigurations, s.get(ctx, "/server_size", &serverConfigurations)
 }
 
-// GetServers returns the available servers
+// GetServerConfigurationsWithFilters returns the available pre-configured server configurations matching the
+// core number and memory amount specified in the request
+func (s *Service) GetServerConfigurationsWithFilters(ctx context.Context, r *request.GetServerConfigurationsRequest) (*upcloud.ServerConfigurations, error) {
+	serverConfigurations := upcloud.ServerConfigurations{}
+	if err := s.get(ctx, r.RequestURL(), &serverConfigurations); err != nil {
+		return nil, err
+	}
+	serverConfigurations.ServerConfigurations = serverConfigurations.Filter(r.CoreNumber, r.MemoryAmount)
+	return &serverConfigurations, nil
+}
+
+// GetServers returns the available servers. The list contains only the summary of each server; API 1.3 has no option
+// for including IP addresses, storage devices or networking in the list, use GetServersWithDetails for those.
 func (s *Service) GetServers(ctx context.Context) (*upcloud.Servers, error) {
 	servers := upcloud.Servers{}
 	return &servers, s.get(ctx, "/server", &servers)
 }
 
-// GetServersWithFilters returns the all the available servers using given filters.
+// GetServersWithFilters returns the all the available servers using given filters. Like GetServers, the list contains
+// only the summary of each server.
 func (s *Service) GetServersWithFilters(ctx context.Context, r *request.GetServersWithFiltersRequest) (*upcloud.Servers, error) {
 	servers := upcloud.Servers{}
 	return &servers, s.get(ctx, r.RequestURL(), &servers)
@@ -52,7 +75,8 @@ func (s *Service) CreateServer(ctx context.Context, r *request.CreateServerReque
 }
 
 // WaitForServerState blocks execution until the specified server has entered the specified state. If the state changes
-// favorably, the new server details are returned. The method will give up after the specified timeout
+// favorably, the server details fetched by the last poll are returned, so there is no need to fetch them again. The
+// method will give up when the context is done
 func (s *Service) WaitForServerState(ctx context.Context, r *request.WaitForServerStateRequest) (*upcloud.ServerDetails, error) {
 	return retry(ctx, func(i int, c context.Context) (*upcloud.ServerDetails, error) {
 		details, err := s.GetServerDetails(c, &request.GetServerDetailsRequest{
@@ -70,7 +94,7 @@ func (s *Service) WaitForServerState(ctx context.Context, r *request.WaitForServ
 		}
 
 		return nil, nil
-	}, nil)
+	}, s.retryConfig())
 }
 
 // StartServer starts the specified server
@@ -81,6 +105,9 @@ func (s *Service) StartServer(ctx context.Context, r *request.StartServerRequest
 
 // StopServer stops the specified server
 func (s *Service) StopServer(ctx context.Context, r *request.StopServerRequest) (*upcloud.ServerDetails, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
 	serverDetails := upcloud.ServerDetails{}
 	if r.Timeout > 0 {
 		timeoutCtx, cancel := context.WithTimeout(ctx, r.Timeout)
@@ -108,12 +135,19 @@ func (s *Service) ModifyServer(ctx context.Context, r *request.ModifyServerReque
 	return &serverDetails, s.replace(ctx, r, &serverDetails)
 }
 
-// DeleteServer deletes the specified server
+// DeleteServer deletes the specified server. Set Storages in the request to delete the attached storages, and their
+// backups according to Backups, in the same call.
 func (s *Service) DeleteServer(ctx context.Context, r *request.DeleteServerRequest) error {
+	if err := s.checkServerDeletion(ctx, r.UUID, r.Storages); err != nil {
+		return err
+	}
 	return s.delete(ctx, r)
 }
 
 // DeleteServerAndStorages deletes the specified server and all attached storages
 func (s *Service) DeleteServerAndStorages(ctx context.Context, r *request.DeleteServerAndStoragesRequest) error {
+	if err := s.checkServerDeletion(ctx, r.UUID, true); err != nil {
+		return err
+	}
 	return s.delete(ctx, r)
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+const defaultServerDetailsConcurrency = 8
+
+// GetServersWithDetails lists the servers matching the filters and fetches the details of each server concurrently.
+// Servers deleted between listing and fetching the details are omitted. The details are returned in the order of the
+// server list. The first error cancels the remaining requests.
+func (s *Service) GetServersWithDetails(ctx context.Context, r *request.GetServersWithDetailsRequest) ([]upcloud.ServerDetails, error) {
+	servers, err := s.GetServersWithFilters(ctx, &request.GetServersWithFiltersRequest{Filters: r.Filters})
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultServerDetailsConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+		details  = make([]*upcloud.ServerDetails, len(servers.Servers))
+	)
+	for i, server := range servers.Servers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, uuid string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			d, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: uuid})
+			if upcloud.IsNotFound(err) {
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			details[i] = d
+		}(i, server.UUID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]upcloud.ServerDetails, 0, len(details))
+	for _, d := range details {
+		if d != nil {
+			result = append(result, *d)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+const testServerList = `{"servers":{"server":[{"uuid":"a"},{"uuid":"b"},{"uuid":"c"}]}}`
+
+func TestGetServersWithDetails(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/").Reply(http.StatusOK, testServerList)
+	m.On(http.MethodGet, "/server/a").Reply(http.StatusOK, `{"server":{"uuid":"a","ip_addresses":{"ip_address":[{"address":"94.237.0.1","access":"public","family":"IPv4"}]}}}`)
+	m.On(http.MethodGet, "/server/b").ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "server not found")
+	m.On(http.MethodGet, "/server/c").Reply(http.StatusOK, `{"server":{"uuid":"c"}}`)
+
+	details, err := svc.GetServersWithDetails(context.Background(), &request.GetServersWithDetailsRequest{Concurrency: 2})
+	require.NoError(t, err)
+	require.Len(t, details, 2)
+	assert.Equal(t, "a", details[0].UUID)
+	assert.Equal(t, "94.237.0.1", details[0].PublicIPv4Address())
+	assert.Equal(t, "c", details[1].UUID)
+}
+
+func TestGetServersWithDetails_error(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/").Reply(http.StatusOK, testServerList)
+	m.On(http.MethodGet, "/server/a").Reply(http.StatusOK, `{"server":{"uuid":"a"}}`)
+	m.On(http.MethodGet, "/server/b").ReplyError(http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+	m.On(http.MethodGet, "/server/c").Reply(http.StatusOK, `{"server":{"uuid":"c"}}`)
+
+	_, err := svc.GetServersWithDetails(context.Background(), &request.GetServersWithDetailsRequest{Concurrency: 1})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Zero(t, m.Called(http.MethodGet, "/server/c"))
+}
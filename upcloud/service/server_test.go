@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,6 +38,24 @@ func TestGetServerConfigurations(t *testing.T) {
 	})
 }
 
+// TestGetServerConfigurationsWithFilters ensures that the GetServerConfigurationsWithFilters() function filters the configurations.
+func TestGetServerConfigurationsWithFilters(t *testing.T) {
+	t.Parallel()
+
+	record(t, "getserverconfigurations", func(ctx context.Context, t *testing.T, rec *recorder.Recorder, svc *Service) {
+		configurations, err := svc.GetServerConfigurationsWithFilters(ctx, &request.GetServerConfigurationsRequest{
+			CoreNumber: 1,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, configurations.ServerConfigurations)
+
+		for _, sc := range configurations.ServerConfigurations {
+			assert.Equal(t, 1, sc.CoreNumber)
+		}
+		assert.NoError(t, configurations.Validate(1, 1024))
+	})
+}
+
 // TestGetServersWithFilters ensures that the GetServersWithFilters() function returns proper data.
 func TestGetServersWithFilters(t *testing.T) {
 	record(t, "getserverswithfilters", func(ctx context.Context, t *testing.T, rec *recorder.Recorder, svc *Service) {
@@ -577,3 +597,72 @@ func stopServerWithoutRecorder(ctx context.Context, svc *Service, uuid string) e
 
 	return err
 }
+
+// TestGetServerDetails_notFound ensures that API errors are returned as problems
+func TestGetServerDetails_notFound(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "The server uuid does not exist.")
+
+	_, err := svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	upcloudtest.AssertProblem(t, err, http.StatusNotFound, upcloud.ErrCodeServerNotFound)
+	m.AssertExpectations(t)
+}
+
+// TestDeleteServerAndStorages_backups ensures that backup deletion mode is passed to the API
+func TestDeleteServerAndStorages_backups(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodDelete, "/server/uuid/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+
+	err := svc.DeleteServerAndStorages(context.Background(), &request.DeleteServerAndStoragesRequest{
+		UUID:    "uuid",
+		Backups: request.DeleteStorageBackupsModeDelete,
+	})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
+
+// TestWaitForServerState_backoff ensures that the configured backoff strategy is used between polling the server state
+func TestWaitForServerState_backoff(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	m, svc := setupMockTransportAndService(WithBackoff(client.BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		attempts = attempt + 1
+		return time.Millisecond
+	})))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`).Once()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`).Once()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"started"}}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	details, err := svc.WaitForServerState(ctx, &request.WaitForServerStateRequest{
+		UUID:         "uuid",
+		DesiredState: upcloud.ServerStateStarted,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStarted, details.State)
+	assert.Equal(t, "uuid", details.UUID)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 3, m.Called(http.MethodGet, "/server/uuid"))
+}
+
+func TestDeleteServer_storages(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodDelete, "/server/uuid/?storages=1&backups=keep_latest").Reply(http.StatusNoContent, "")
+
+	err := svc.DeleteServer(context.Background(), &request.DeleteServerRequest{
+		UUID:     "uuid",
+		Storages: true,
+		Backups:  request.DeleteStorageBackupsModeKeepLatest,
+	})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
@@ -27,11 +29,19 @@ type Client interface {
 	Do(r *http.Request) ([]byte, error)
 }
 
+type codecProvider interface {
+	Codec() client.Codec
+}
+
 type requestable interface {
 	RequestURL() string
 }
 
-type service interface {
+// ServiceAPI is the set of all methods of Service. It is the compatibility surface of the package: methods may be added
+// in minor versions, but existing methods are changed or removed only in major versions. Wrappers, e.g. for caching or
+// metrics, can implement ServiceAPI by embedding another ServiceAPI and overriding the methods they decorate, so that
+// they keep compiling when methods are added.
+type ServiceAPI interface {
 	Cloud
 	Account
 	Firewall
@@ -51,27 +61,35 @@ type service interface {
 	Kubernetes
 	ManagedObjectStorage
 	Gateway
+	Inventory
 }
 
-var _ service = (*Service)(nil)
+var _ ServiceAPI = (*Service)(nil)
+
+// AssertServiceAPI fails to compile if T does not implement ServiceAPI. Call it from a test of a downstream
+// implementation, e.g. AssertServiceAPI[*MyService](), to catch missing methods when the SDK is upgraded.
+func AssertServiceAPI[T ServiceAPI]() {}
 
 // Service represents the API service with context support. The specified client is used to communicate with the API
 type Service struct {
 	client Client
+	config config
+
+	zoneCapabilities zoneCapabilitiesCache
 }
 
 // Get performs a GET request to the specified location with context and stores the result in the value pointed to by v.
 func (s *Service) get(ctx context.Context, location string, v interface{}) error {
 	res, err := s.client.Get(ctx, location)
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 
 	if v == nil {
 		return nil
 	}
 
-	err = json.Unmarshal(res, v)
+	err = s.unmarshal(location, res, v)
 	if err == nil {
 		return nil
 	}
@@ -85,88 +103,195 @@ func (s *Service) get(ctx context.Context, location string, v interface{}) error
 
 // Create performs a POST request to the specified location with context and stores the response in the value pointed to by v.
 func (s *Service) create(ctx context.Context, r requestable, v interface{}) error {
-	payload, err := json.Marshal(r)
+	r = s.withDefaults(r)
+	payload, err := s.codec().Marshal(r)
 	if err != nil {
 		return err
 	}
 
 	res, err := s.client.Post(ctx, r.RequestURL(), payload)
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 	if v == nil {
 		return nil
 	}
-	return json.Unmarshal(res, v)
+	return s.unmarshal(r.RequestURL(), res, v)
 }
 
 // Modify performs a PATCH request to the specified location with context and stores the response in the value pointed to by v.
 func (s *Service) modify(ctx context.Context, r requestable, v interface{}) error {
-	payload, err := json.Marshal(r)
+	payload, err := s.codec().Marshal(r)
 	if err != nil {
 		return err
 	}
 
 	res, err := s.client.Patch(ctx, r.RequestURL(), payload)
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 	if v == nil {
 		return nil
 	}
-	return json.Unmarshal(res, v)
+	return s.unmarshal(r.RequestURL(), res, v)
 }
 
 // Modify performs a PUT request to the specified location with context and stores the response in the value pointed to by v.
 func (s *Service) replace(ctx context.Context, r requestable, v interface{}) error {
-	payload, err := json.Marshal(r)
+	payload, err := s.codec().Marshal(r)
 	if err != nil {
 		return err
 	}
 
 	res, err := s.client.Put(ctx, r.RequestURL(), payload)
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 	if v == nil {
 		return nil
 	}
-	return json.Unmarshal(res, v)
+	return s.unmarshal(r.RequestURL(), res, v)
 }
 
 // Delete performs a DELETE request to the specified location with context
 func (s *Service) delete(ctx context.Context, r requestable) error {
 	_, err := s.client.Delete(ctx, r.RequestURL())
 	if err != nil {
-		return parseJSONServiceError(err)
+		return s.serviceError(err)
 	}
 	return nil
 }
 
-func New(client Client) *Service {
-	return &Service{client}
+// unmarshal stores the response body from the specified location in the value pointed to by v. In strict decoding
+// mode, fields not modelled by v are reported to the configured callback or returned as an error.
+func (s *Service) unmarshal(location string, data []byte, v interface{}) error {
+	if err := s.codec().Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if !s.config.strictDecoding {
+		return nil
+	}
+
+	fields, err := unknownFields(s.codec(), data, v)
+	if err != nil || len(fields) == 0 {
+		return err
+	}
+
+	if s.config.unknownFieldsFn != nil {
+		s.config.unknownFieldsFn(location, fields)
+		return nil
+	}
+	return &UnknownFieldsError{Location: location, Fields: fields}
+}
+
+// codec returns the codec used for encoding requests and decoding responses.
+func (s *Service) codec() client.Codec {
+	if s.config.codec == nil {
+		return client.JSONCodec{}
+	}
+	return s.config.codec
+}
+
+type config struct {
+	strictDecoding  bool
+	unknownFieldsFn func(location string, fields []string)
+	backoff         client.BackoffStrategy
+	codec           client.Codec
+
+	defaultZone        string
+	defaultStorageTier string
+	defaultTimeout     time.Duration
+
+	readOnly       bool
+	deletionGuards []DeletionGuard
+
+	ipAddressLimitPerServer int
+
+	logger *slog.Logger
+}
+
+type ConfigFn func(c *config)
+
+// WithStrictDecoding enables strict decoding mode in which API responses are checked for fields that the SDK types
+// do not model. Unknown fields are reported to fn along with the request location. If fn is nil, an
+// *UnknownFieldsError is returned instead.
+func WithStrictDecoding(fn func(location string, fields []string)) ConfigFn {
+	return func(c *config) {
+		c.strictDecoding = true
+		c.unknownFieldsFn = fn
+	}
+}
+
+// WithBackoff sets the backoff strategy used by the WaitFor methods to determine the delay between polling the
+// resource state. By default, the state is polled every five seconds.
+func WithBackoff(backoff client.BackoffStrategy) ConfigFn {
+	return func(c *config) {
+		c.backoff = backoff
+	}
+}
+
+// WithLogger logs the waits of the service to logger: each poll of the WaitFor methods and of the methods that wait
+// for resources, such as BuildTemplate, at debug level and the failed waits at error level. The events have the
+// attributes attempt, delay, duration and error, matching the request events of client.WithLogger, which logs the API
+// requests.
+func WithLogger(logger *slog.Logger) ConfigFn {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// New creates and returns a new service that uses the specified client and optional config functions. If the client
+// provides a codec, it is used for encoding requests and decoding responses.
+func New(client Client, c ...ConfigFn) *Service {
+	s := &Service{client: client, config: config{ipAddressLimitPerServer: defaultIPAddressLimitPerServer}}
+	if cc, ok := client.(codecProvider); ok {
+		s.config.codec = cc.Codec()
+	}
+	for _, fn := range c {
+		fn(&s.config)
+	}
+	if s.config.readOnly {
+		s.client = readOnlyClient{client: s.client}
+	}
+	return s
+}
+
+// serviceError parses an error returned from the client into corresponding error type using the codec of the service
+func (s *Service) serviceError(err error) error {
+	return parseServiceError(s.codec(), err)
 }
 
 // Parses an error returned from the client into corresponding error type
-func parseJSONServiceError(err error) error {
+func parseServiceError(codec client.Codec, err error) error {
+	var retryErr *client.RetryError
+	if errors.As(err, &retryErr) {
+		e := *retryErr
+		e.Err = parseServiceError(codec, retryErr.Err)
+		return &e
+	}
 	if clientError, ok := err.(*client.Error); ok {
 		prob := &upcloud.Problem{}
 
 		switch clientError.Type {
 		case client.ErrorTypeProblem:
-			if err := json.Unmarshal(clientError.ResponseBody, prob); err != nil {
+			if err := codec.Unmarshal(clientError.ResponseBody, prob); err != nil {
 				return fmt.Errorf("received malformed client error: %s", string(clientError.ResponseBody))
 			}
+			prob.RequestID = clientError.RequestID
+			prob.CorrelationID = defaultString(prob.CorrelationID, clientError.CorrelationID)
 			return prob
 		default:
 			ucError := &legacyError{}
-			if err := json.Unmarshal(clientError.ResponseBody, ucError); err != nil {
+			if err := codec.Unmarshal(clientError.ResponseBody, ucError); err != nil {
 				return fmt.Errorf("received malformed client error: %s", string(clientError.ResponseBody))
 			}
 
 			prob.Type = ucError.ErrorCode
 			prob.Title = ucError.ErrorMessage
 			prob.Status = clientError.ErrorCode
+			prob.RequestID = clientError.RequestID
+			prob.CorrelationID = clientError.CorrelationID
 			return prob
 		}
 	}
@@ -11,7 +11,10 @@ import (
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseJSONServiceErrorMinimal(t *testing.T) {
@@ -20,7 +23,7 @@ func TestParseJSONServiceErrorMinimal(t *testing.T) {
 		Title:  "msg",
 		Status: http.StatusNotFound,
 	}
-	got := parseJSONServiceError(&client.Error{
+	got := parseServiceError(client.JSONCodec{}, &client.Error{
 		ErrorCode: http.StatusNotFound,
 		ResponseBody: []byte(`
 		{
@@ -35,6 +38,48 @@ func TestParseJSONServiceErrorMinimal(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestServiceErrorRequestID(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "server not found")
+
+	_, err := svc.GetServerDetails(client.ContextWithRequestID(context.Background(), "my-id"), &request.GetServerDetailsRequest{UUID: "uuid"})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, "my-id", problem.RequestID)
+	assert.Contains(t, problem.Error(), "request_id=my-id")
+}
+
+func TestParseJSONServiceErrorCorrelationID(t *testing.T) {
+	t.Parallel()
+
+	// The correlation ID of the response headers is used if the problem does not include one
+	got := parseServiceError(client.JSONCodec{}, &client.Error{
+		ErrorCode:     http.StatusConflict,
+		ResponseBody:  []byte(`{"type":"SERVER_STATE_ILLEGAL","status":409}`),
+		Type:          client.ErrorTypeProblem,
+		CorrelationID: "header",
+	})
+	assert.Equal(t, "header", got.(*upcloud.Problem).CorrelationID)
+
+	got = parseServiceError(client.JSONCodec{}, &client.Error{
+		ErrorCode:     http.StatusConflict,
+		ResponseBody:  []byte(`{"type":"SERVER_STATE_ILLEGAL","status":409,"correlation_id":"body"}`),
+		Type:          client.ErrorTypeProblem,
+		CorrelationID: "header",
+	})
+	assert.Equal(t, "body", got.(*upcloud.Problem).CorrelationID)
+
+	got = parseServiceError(client.JSONCodec{}, &client.Error{
+		ErrorCode:     http.StatusNotFound,
+		ResponseBody:  []byte(`{"error":{"error_code":"SERVER_NOT_FOUND","error_message":"not found"}}`),
+		Type:          client.ErrorTypeError,
+		CorrelationID: "header",
+	})
+	assert.Equal(t, "header", got.(*upcloud.Problem).CorrelationID)
+}
+
 func TestParseJSONServiceErrorWithProblem(t *testing.T) {
 	want := &upcloud.Problem{
 		Type:          "typexx",
@@ -49,7 +94,7 @@ func TestParseJSONServiceErrorWithProblem(t *testing.T) {
 		},
 	}
 
-	got := parseJSONServiceError(&client.Error{
+	got := parseServiceError(client.JSONCodec{}, &client.Error{
 		ErrorCode: http.StatusBadRequest,
 		Type:      client.ErrorTypeProblem,
 		ResponseBody: []byte(`
@@ -70,6 +115,25 @@ func TestParseJSONServiceErrorWithProblem(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestParseJSONServiceErrorWithRetries(t *testing.T) {
+	got := parseServiceError(client.JSONCodec{}, &client.RetryError{
+		Attempts:    2,
+		StatusCodes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		Err: &client.Error{
+			ErrorCode:    http.StatusServiceUnavailable,
+			Type:         client.ErrorTypeProblem,
+			ResponseBody: []byte(`{"type": "typexx", "title": "titlexx", "status": 503}`),
+		},
+	})
+
+	var retryErr *client.RetryError
+	assert.ErrorAs(t, got, &retryErr)
+	assert.Equal(t, 2, retryErr.Attempts)
+	var problem *upcloud.Problem
+	assert.ErrorAs(t, got, &problem)
+	assert.Equal(t, &upcloud.Problem{Type: "typexx", Title: "titlexx", Status: http.StatusServiceUnavailable}, problem)
+}
+
 // TestMain is the main test method
 func TestMain(m *testing.M) {
 	retCode := m.Run()
@@ -104,3 +168,81 @@ func getService() *Service {
 
 	return New(c)
 }
+
+type countingCodec struct {
+	client.JSONCodec
+	marshal, unmarshal int
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	c.marshal++
+	return c.JSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.unmarshal++
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+// TestService_codec ensures that the codec configured for the client is used for encoding requests and decoding responses
+func TestService_codec(t *testing.T) {
+	t.Parallel()
+
+	codec := &countingCodec{}
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"uuid","state":"maintenance"}}`)
+	svc := New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient()), client.WithCodec(codec)))
+
+	details, err := svc.CreateServer(context.Background(), &request.CreateServerRequest{Zone: "fi-hel1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "uuid", details.UUID)
+	assert.Equal(t, 1, codec.marshal)
+	assert.Equal(t, 1, codec.unmarshal)
+
+	// Strict decoding and error responses use the codec too
+	codec = &countingCodec{}
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"started"}}`)
+	m.On(http.MethodGet, "/server/missing").ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "Server not found")
+	svc = New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient()), client.WithCodec(codec)), WithStrictDecoding(nil))
+	_, err = svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, codec.unmarshal)
+	_, err = svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: "missing"})
+	assert.True(t, upcloud.IsNotFound(err))
+	assert.Equal(t, 3, codec.unmarshal)
+}
+
+// cachingService shows a ServiceAPI decorator overriding a single method
+type cachingService struct {
+	ServiceAPI
+	account *upcloud.Account
+}
+
+func (s *cachingService) GetAccount(ctx context.Context) (*upcloud.Account, error) {
+	if s.account != nil {
+		return s.account, nil
+	}
+	account, err := s.ServiceAPI.GetAccount(ctx)
+	if err == nil {
+		s.account = account
+	}
+	return account, err
+}
+
+func TestServiceAPIDecorator(t *testing.T) {
+	t.Parallel()
+
+	AssertServiceAPI[*Service]()
+	AssertServiceAPI[*cachingService]()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/account").Reply(http.StatusOK, `{"account":{"username":"user"}}`).Once()
+
+	var api ServiceAPI = &cachingService{ServiceAPI: svc}
+	for i := 0; i < 2; i++ {
+		account, err := api.GetAccount(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "user", account.UserName)
+	}
+	assert.Len(t, m.Calls(), 1)
+}
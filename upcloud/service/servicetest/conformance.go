@@ -0,0 +1,265 @@
+// Package servicetest provides a conformance test suite for implementations of the service interfaces, such as
+// fakes, mocks and in-memory implementations used in unit tests.
+package servicetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/service"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+)
+
+// NonExistentUUID is used by the conformance tests to look up resources that do not exist
+const NonExistentUUID string = "00000000-0000-4000-8000-000000000000"
+
+// maxDetailChecks limits the number of listed resources whose details are compared with the listing
+const maxDetailChecks = 3
+
+// Service is the set of service interfaces covered by the conformance tests
+type Service interface {
+	service.Cloud
+	service.Server
+	service.Storage
+}
+
+// LifecycleOptions configures the server lifecycle conformance tests
+type LifecycleOptions struct {
+	Zone string
+	Plan string
+	// Template is the UUID of the storage template the server is created from
+	Template string
+	// Timeout for each of the state changes, defaults to 10 minutes
+	Timeout time.Duration
+}
+
+// RunServiceConformanceTests runs read-only conformance tests against svc. Implementation is expected to contain at
+// least one zone and one plan; servers and storages, if any, must be consistent between listings and details. Methods
+// must return an error wrapping context.Canceled when called with a canceled context.
+func RunServiceConformanceTests(t *testing.T, svc Service) {
+	t.Helper()
+
+	t.Run("GetZones", func(t *testing.T) {
+		zones, err := svc.GetZones(context.Background())
+		if !noError(t, err) {
+			return
+		}
+		if len(zones.Zones) == 0 {
+			t.Error("expected at least one zone")
+		}
+		for _, z := range zones.Zones {
+			if z.ID == "" {
+				t.Errorf("zone %+v has empty ID", z)
+			}
+		}
+	})
+
+	t.Run("GetPlans", func(t *testing.T) {
+		plans, err := svc.GetPlans(context.Background())
+		if !noError(t, err) {
+			return
+		}
+		if len(plans.Plans) == 0 {
+			t.Error("expected at least one plan")
+		}
+		for _, p := range plans.Plans {
+			if p.Name == "" || p.CoreNumber < 1 || p.MemoryAmount < 1 {
+				t.Errorf("plan %+v is missing name, core number or memory amount", p)
+			}
+		}
+	})
+
+	t.Run("GetServers", func(t *testing.T) {
+		servers, err := svc.GetServers(context.Background())
+		if !noError(t, err) {
+			return
+		}
+		for i, s := range servers.Servers {
+			if i >= maxDetailChecks {
+				break
+			}
+			details, err := svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: s.UUID})
+			if !noError(t, err) {
+				continue
+			}
+			if details.UUID != s.UUID || details.Hostname != s.Hostname || details.Zone != s.Zone {
+				t.Errorf("server details %s (%s, %s) do not match the listing %s (%s, %s)",
+					details.UUID, details.Hostname, details.Zone, s.UUID, s.Hostname, s.Zone)
+			}
+		}
+	})
+
+	t.Run("GetServerDetails_notFound", func(t *testing.T) {
+		_, err := svc.GetServerDetails(context.Background(), &request.GetServerDetailsRequest{UUID: NonExistentUUID})
+		upcloudtest.AssertProblem(t, err, http.StatusNotFound, upcloud.ErrCodeServerNotFound)
+	})
+
+	t.Run("GetStorages", func(t *testing.T) {
+		storages, err := svc.GetStorages(context.Background(), &request.GetStoragesRequest{Access: upcloud.StorageAccessPrivate})
+		if !noError(t, err) {
+			return
+		}
+		for i, s := range storages.Storages {
+			if i >= maxDetailChecks {
+				break
+			}
+			if s.Access != upcloud.StorageAccessPrivate {
+				t.Errorf("storage %s has access %q, expected only private storages", s.UUID, s.Access)
+			}
+			details, err := svc.GetStorageDetails(context.Background(), &request.GetStorageDetailsRequest{UUID: s.UUID})
+			if !noError(t, err) {
+				continue
+			}
+			if details.UUID != s.UUID || details.Size != s.Size || details.Zone != s.Zone {
+				t.Errorf("storage details %s (%d GB, %s) do not match the listing %s (%d GB, %s)",
+					details.UUID, details.Size, details.Zone, s.UUID, s.Size, s.Zone)
+			}
+		}
+	})
+
+	t.Run("GetStorageDetails_notFound", func(t *testing.T) {
+		_, err := svc.GetStorageDetails(context.Background(), &request.GetStorageDetailsRequest{UUID: NonExistentUUID})
+		upcloudtest.AssertProblem(t, err, http.StatusNotFound, upcloud.ErrCodeStorageNotFound)
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := svc.GetServers(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected GetServers to return context.Canceled when the context is canceled, got %v", err)
+		}
+		_, err := svc.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: NonExistentUUID, DesiredState: upcloud.ServerStateStarted})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected WaitForServerState to return context.Canceled when the context is canceled, got %v", err)
+		}
+	})
+}
+
+// RunServerLifecycleConformanceTests creates, stops and deletes a server using svc and checks that the server moves
+// through the expected states. This creates billable resources when run against the real API.
+func RunServerLifecycleConformanceTests(t *testing.T, svc Service, opts LifecycleOptions) {
+	t.Helper()
+
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout*4)
+	defer cancel()
+
+	created, err := svc.CreateServer(ctx, &request.CreateServerRequest{
+		Hostname: "conformance.example.com",
+		Title:    "conformance test server",
+		Zone:     opts.Zone,
+		Plan:     opts.Plan,
+		StorageDevices: []request.CreateServerStorageDevice{
+			{
+				Action:  request.CreateServerStorageDeviceActionClone,
+				Storage: opts.Template,
+				Title:   "conformance test disk",
+			},
+		},
+		Networking: &request.CreateServerNetworking{
+			Interfaces: []request.CreateServerInterface{
+				{
+					IPAddresses: []request.CreateServerIPAddress{{Family: upcloud.IPAddressFamilyIPv4}},
+					Type:        upcloud.IPAddressAccessUtility,
+				},
+			},
+		},
+	})
+	if !noError(t, err) {
+		return
+	}
+	if created.UUID != "" {
+		t.Cleanup(func() { cleanupServer(t, svc, created.UUID, opts.Timeout) })
+	}
+	if created.UUID == "" || created.Zone != opts.Zone {
+		t.Errorf("created server has UUID %q and zone %q, expected non-empty UUID and zone %q", created.UUID, created.Zone, opts.Zone)
+		return
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, opts.Timeout)
+	defer waitCancel()
+	started, err := svc.WaitForServerState(waitCtx, &request.WaitForServerStateRequest{
+		UUID:         created.UUID,
+		DesiredState: upcloud.ServerStateStarted,
+	})
+	if !noError(t, err) {
+		return
+	}
+	if started.State != upcloud.ServerStateStarted {
+		t.Errorf("server is in state %q, expected %q", started.State, upcloud.ServerStateStarted)
+		return
+	}
+
+	if _, err = svc.StopServer(ctx, &request.StopServerRequest{UUID: created.UUID, StopType: request.ServerStopTypeHard}); !noError(t, err) {
+		return
+	}
+
+	waitCtx, waitCancel = context.WithTimeout(ctx, opts.Timeout)
+	defer waitCancel()
+	stopped, err := svc.WaitForServerState(waitCtx, &request.WaitForServerStateRequest{
+		UUID:         created.UUID,
+		DesiredState: upcloud.ServerStateStopped,
+	})
+	if !noError(t, err) {
+		return
+	}
+	if stopped.State != upcloud.ServerStateStopped {
+		t.Errorf("server is in state %q, expected %q", stopped.State, upcloud.ServerStateStopped)
+		return
+	}
+
+	if err := svc.DeleteServerAndStorages(ctx, &request.DeleteServerAndStoragesRequest{UUID: created.UUID}); !noError(t, err) {
+		return
+	}
+
+	_, err = svc.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: created.UUID})
+	upcloudtest.AssertProblem(t, err, http.StatusNotFound, upcloud.ErrCodeServerNotFound)
+}
+
+// cleanupServer stops and deletes the server and its storages unless the test deleted it already, so that failed tests
+// do not leave billable resources behind
+func cleanupServer(t *testing.T, svc Service, uuid string, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout*2)
+	defer cancel()
+
+	server, err := svc.WaitForServerState(ctx, &request.WaitForServerStateRequest{
+		UUID:           uuid,
+		UndesiredState: upcloud.ServerStateMaintenance,
+	})
+	if upcloud.IsNotFound(err) {
+		return
+	}
+	if err == nil && server.State != upcloud.ServerStateStopped {
+		if _, err = svc.StopServer(ctx, &request.StopServerRequest{UUID: uuid, StopType: request.ServerStopTypeHard}); err == nil {
+			_, err = svc.WaitForServerState(ctx, &request.WaitForServerStateRequest{
+				UUID:         uuid,
+				DesiredState: upcloud.ServerStateStopped,
+			})
+		}
+	}
+	if err == nil {
+		err = svc.DeleteServerAndStorages(ctx, &request.DeleteServerAndStoragesRequest{UUID: uuid})
+	}
+	if err != nil {
+		t.Errorf("cleaning up server %s: %v", uuid, err)
+	}
+}
+
+func noError(t *testing.T, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package servicetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/service"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunServiceConformanceTests runs the conformance tests against the service using recorded API responses
+func TestRunServiceConformanceTests(t *testing.T) {
+	t.Parallel()
+
+	server := upcloud.ServerDetails{}
+	require.NoError(t, json.Unmarshal(upcloudtest.MustCorpus(upcloudtest.CorpusServerDetails), &server))
+	storage := upcloud.StorageDetails{}
+	require.NoError(t, json.Unmarshal(upcloudtest.MustCorpus(upcloudtest.CorpusStorageDetails), &storage))
+
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodGet, "/zone").ReplyCorpus(upcloudtest.CorpusZones)
+	m.On(http.MethodGet, "/plan").ReplyCorpus(upcloudtest.CorpusPlans)
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, fmt.Sprintf(
+		`{"servers":{"server":[{"uuid":%q,"hostname":%q,"zone":%q,"state":%q}]}}`,
+		server.UUID, server.Hostname, server.Zone, server.State,
+	))
+	m.On(http.MethodGet, "/server/"+server.UUID).ReplyCorpus(upcloudtest.CorpusServerDetails)
+	m.On(http.MethodGet, "/server/"+NonExistentUUID).ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "Server not found")
+	m.On(http.MethodGet, "/storage/private").Reply(http.StatusOK, fmt.Sprintf(
+		`{"storages":{"storage":[{"uuid":%q,"access":%q,"size":%d,"zone":%q}]}}`,
+		storage.UUID, storage.Access, storage.Size, storage.Zone,
+	))
+	m.On(http.MethodGet, "/storage/"+storage.UUID).ReplyCorpus(upcloudtest.CorpusStorageDetails)
+	m.On(http.MethodGet, "/storage/"+NonExistentUUID).ReplyError(http.StatusNotFound, upcloud.ErrCodeStorageNotFound, "Storage not found")
+
+	RunServiceConformanceTests(t, service.New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient()))))
+	m.AssertExpectations(t)
+}
+
+func TestCleanupServer(t *testing.T) {
+	t.Parallel()
+
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"started"}}`).Once()
+	m.On(http.MethodPost, "/server/uuid/stop").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"started"}}`)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+	m.On(http.MethodDelete, "/server/uuid/").Reply(http.StatusNoContent, "")
+
+	svc := service.New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient())), service.WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	cleanupServer(t, svc, "uuid", time.Minute)
+	m.AssertExpectations(t)
+
+	// Servers deleted by the test are skipped
+	m = upcloudtest.NewMockTransport()
+	m.On(http.MethodGet, "/server/uuid").ReplyError(http.StatusNotFound, upcloud.ErrCodeServerNotFound, "Server not found")
+	svc = service.New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient())))
+	cleanupServer(t, svc, "uuid", time.Minute)
+	require.Len(t, m.Calls(), 1)
+}
@@ -14,29 +14,95 @@ import (
 
 type Storage interface {
 	GetStorages(ctx context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error)
+	ForEachStorage(ctx context.Context, r *request.GetStoragesRequest, fn func(upcloud.Storage) bool) error
 	GetStorageDetails(ctx context.Context, r *request.GetStorageDetailsRequest) (*upcloud.StorageDetails, error)
 	CreateStorage(ctx context.Context, r *request.CreateStorageRequest) (*upcloud.StorageDetails, error)
 	ModifyStorage(ctx context.Context, r *request.ModifyStorageRequest) (*upcloud.StorageDetails, error)
+	PreflightModifyStorage(ctx context.Context, r *request.ModifyStorageRequest) error
 	AttachStorage(ctx context.Context, r *request.AttachStorageRequest) (*upcloud.ServerDetails, error)
 	DetachStorage(ctx context.Context, r *request.DetachStorageRequest) (*upcloud.ServerDetails, error)
+	DetachAllStorages(ctx context.Context, r *request.DetachAllStoragesRequest) (*upcloud.ServerDetails, error)
+	ChangeStorageTier(ctx context.Context, r *request.ChangeStorageTierRequest) (*upcloud.StorageDetails, error)
+	BuildTemplate(ctx context.Context, r *request.BuildTemplateRequest) (*upcloud.StorageDetails, error)
 	CloneStorage(ctx context.Context, r *request.CloneStorageRequest) (*upcloud.StorageDetails, error)
 	TemplatizeStorage(ctx context.Context, r *request.TemplatizeStorageRequest) (*upcloud.StorageDetails, error)
 	WaitForStorageState(ctx context.Context, r *request.WaitForStorageStateRequest) (*upcloud.StorageDetails, error)
 	LoadCDROM(ctx context.Context, r *request.LoadCDROMRequest) (*upcloud.ServerDetails, error)
 	EjectCDROM(ctx context.Context, r *request.EjectCDROMRequest) (*upcloud.ServerDetails, error)
+	LoadCDROMByTitle(ctx context.Context, r *request.LoadCDROMByTitleRequest) (*upcloud.ServerDetails, error)
+	GetCDROMs(ctx context.Context) (upcloud.CDROMs, error)
 	CreateBackup(ctx context.Context, r *request.CreateBackupRequest) (*upcloud.StorageDetails, error)
 	RestoreBackup(ctx context.Context, r *request.RestoreBackupRequest) error
+	CreateBackupGroup(ctx context.Context, r *request.CreateBackupGroupRequest) (*upcloud.BackupGroup, error)
+	GetBackupGroup(ctx context.Context, r *request.GetBackupGroupRequest) (*upcloud.BackupGroup, error)
+	RestoreBackupGroup(ctx context.Context, r *request.RestoreBackupGroupRequest) error
+	DeleteBackupGroup(ctx context.Context, r *request.DeleteBackupGroupRequest) error
+	GetBackups(ctx context.Context, r *request.GetBackupsRequest) (*upcloud.Backups, error)
 	CreateStorageImport(ctx context.Context, r *request.CreateStorageImportRequest) (*upcloud.StorageImportDetails, error)
 	GetStorageImportDetails(ctx context.Context, r *request.GetStorageImportDetailsRequest) (*upcloud.StorageImportDetails, error)
 	WaitForStorageImportCompletion(ctx context.Context, r *request.WaitForStorageImportCompletionRequest) (*upcloud.StorageImportDetails, error)
+	CancelStorageImport(ctx context.Context, r *request.CancelStorageImportRequest) (*upcloud.StorageImportDetails, error)
+	CloneStorageOperation(ctx context.Context, r *request.CloneStorageRequest) (*Operation[upcloud.StorageDetails], error)
+	TemplatizeStorageOperation(ctx context.Context, r *request.TemplatizeStorageRequest) (*Operation[upcloud.StorageDetails], error)
+	CreateBackupOperation(ctx context.Context, r *request.CreateBackupRequest) (*Operation[upcloud.StorageDetails], error)
+	CreateStorageImportOperation(ctx context.Context, r *request.CreateStorageImportRequest) (*Operation[upcloud.StorageImportDetails], error)
 	DeleteStorage(ctx context.Context, r *request.DeleteStorageRequest) error
 	ResizeStorageFilesystem(ctx context.Context, r *request.ResizeStorageFilesystemRequest) (*upcloud.ResizeStorageFilesystemBackup, error)
+	GetServerStorageProtection(ctx context.Context, r *request.GetServerDetailsRequest) ([]upcloud.StorageProtection, error)
 }
 
-// GetStorages returns all available storages
+// GetStorages returns all available storages. Use Page to retrieve the storages in smaller chunks, or ForEachStorage to
+// iterate over all storages page by page.
 func (s *Service) GetStorages(ctx context.Context, r *request.GetStoragesRequest) (*upcloud.Storages, error) {
 	storages := upcloud.Storages{}
-	return &storages, s.get(ctx, r.RequestURL(), &storages)
+	if err := s.get(ctx, r.RequestURL(), &storages); err != nil {
+		return &storages, err
+	}
+	if r.Zone != "" {
+		storages.Storages = filterStoragesByZone(storages.Storages, r.Zone)
+	}
+	return &storages, nil
+}
+
+// ForEachStorage retrieves storages page by page and calls fn for each of them until fn returns false. Page of the
+// request sets the page size and the first page, and defaults to request.DefaultPage.
+func (s *Service) ForEachStorage(ctx context.Context, r *request.GetStoragesRequest, fn func(upcloud.Storage) bool) error {
+	req := *r
+	if req.Page == nil {
+		req.Page = request.DefaultPage
+	}
+
+	for {
+		storages := upcloud.Storages{}
+		if err := s.get(ctx, req.RequestURL(), &storages); err != nil {
+			return err
+		}
+
+		page := storages.Storages
+		if req.Zone != "" {
+			page = filterStoragesByZone(page, req.Zone)
+		}
+		for _, storage := range page {
+			if !fn(storage) {
+				return nil
+			}
+		}
+		if len(storages.Storages) < req.Page.Size || req.Page.Size < 1 {
+			return nil
+		}
+
+		req.Page = req.Page.Next()
+	}
+}
+
+func filterStoragesByZone(storages []upcloud.Storage, zone string) []upcloud.Storage {
+	filtered := make([]upcloud.Storage, 0, len(storages))
+	for _, storage := range storages {
+		if storage.Zone == zone {
+			filtered = append(filtered, storage)
+		}
+	}
+	return filtered
 }
 
 // GetStorageDetails returns extended details about the specified piece of storage
@@ -71,9 +137,50 @@ func (s *Service) DetachStorage(ctx context.Context, r *request.DetachStorageReq
 
 // DeleteStorage deletes the specified storage device
 func (s *Service) DeleteStorage(ctx context.Context, r *request.DeleteStorageRequest) error {
+	if err := s.checkStorageDeletion(ctx, r.UUID); err != nil {
+		return err
+	}
 	return s.delete(ctx, r)
 }
 
+// DetachAllStorages detaches all disks except the boot disk from the server, e.g. before deleting the server while
+// keeping its data disks. The boot disk is the disk marked as boot disk, or the first disk if none is marked. CD-ROM
+// devices are left as they are. Storages are detached one at a time, waiting for the server to leave maintenance
+// state between the operations, and the server details after the last operation are returned.
+func (s *Service) DetachAllStorages(ctx context.Context, r *request.DetachAllStoragesRequest) (*upcloud.ServerDetails, error) {
+	details, err := s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: r.ServerUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []upcloud.ServerStorageDevice
+	for _, device := range details.StorageDevices {
+		if device.Type == upcloud.StorageTypeDisk {
+			disks = append(disks, device)
+		}
+	}
+	boot := 0
+	for i, disk := range disks {
+		if disk.BootDisk == 1 {
+			boot = i
+			break
+		}
+	}
+
+	for i, disk := range disks {
+		if i == boot {
+			continue
+		}
+		details, err = s.waitOutMaintenance(ctx, r.ServerUUID, func() (*upcloud.ServerDetails, error) {
+			return s.DetachStorage(ctx, &request.DetachStorageRequest{ServerUUID: r.ServerUUID, Address: disk.Address})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("detaching storage %s from %s: %w", disk.UUID, disk.Address, err)
+		}
+	}
+	return details, nil
+}
+
 // CloneStorage detaches the specified storage from the specified server
 func (s *Service) CloneStorage(ctx context.Context, r *request.CloneStorageRequest) (*upcloud.StorageDetails, error) {
 	storageDetails := upcloud.StorageDetails{}
@@ -87,7 +194,8 @@ func (s *Service) TemplatizeStorage(ctx context.Context, r *request.TemplatizeSt
 }
 
 // WaitForStorageState blocks execution until the specified storage device has entered the specified state. If the
-// state changes favorably, the new storage details is returned. The method will give up after the specified timeout
+// state changes favorably, the storage details fetched by the last poll are returned, so there is no need to fetch
+// them again. The method will give up when the context is done
 func (s *Service) WaitForStorageState(ctx context.Context, r *request.WaitForStorageStateRequest) (*upcloud.StorageDetails, error) {
 	return retry(ctx, func(i int, c context.Context) (*upcloud.StorageDetails, error) {
 		details, err := s.GetStorageDetails(c, &request.GetStorageDetailsRequest{
@@ -102,19 +210,7 @@ func (s *Service) WaitForStorageState(ctx context.Context, r *request.WaitForSto
 		}
 
 		return nil, nil
-	}, nil)
-}
-
-// LoadCDROM loads a storage as a CD-ROM in the CD-ROM device of a server
-func (s *Service) LoadCDROM(ctx context.Context, r *request.LoadCDROMRequest) (*upcloud.ServerDetails, error) {
-	serverDetails := upcloud.ServerDetails{}
-	return &serverDetails, s.create(ctx, r, &serverDetails)
-}
-
-// EjectCDROM ejects the storage from the CD-ROM device of a server
-func (s *Service) EjectCDROM(ctx context.Context, r *request.EjectCDROMRequest) (*upcloud.ServerDetails, error) {
-	serverDetails := upcloud.ServerDetails{}
-	return &serverDetails, s.create(ctx, r, &serverDetails)
+	}, s.retryConfig())
 }
 
 // CreateBackup creates a backup of the specified storage
@@ -228,20 +324,31 @@ func (s *Service) WaitForStorageImportCompletion(ctx context.Context, r *request
 		case upcloud.StorageImportStateCancelled,
 			upcloud.StorageImportStateCancelling,
 			upcloud.StorageImportStateFailed:
-			if details.ErrorCode != "" || details.ErrorMessage != "" {
-				return details, &upcloud.Problem{
-					Type:  details.ErrorCode,
-					Title: details.ErrorMessage,
-				}
-			}
-			return details, &upcloud.Problem{
-				Type:  details.State,
-				Title: "Storage Import Failed",
-			}
+			return details, storageImportProblem(details)
 		default:
 			return nil, nil
 		}
-	}, nil)
+	}, s.retryConfig())
+}
+
+// CancelStorageImport cancels an ongoing storage import
+func (s *Service) CancelStorageImport(ctx context.Context, r *request.CancelStorageImportRequest) (*upcloud.StorageImportDetails, error) {
+	storageImport := upcloud.StorageImportDetails{}
+	return &storageImport, s.create(ctx, r, &storageImport)
+}
+
+// storageImportProblem returns the error of a failed or cancelled storage import
+func storageImportProblem(details *upcloud.StorageImportDetails) error {
+	if details.ErrorCode != "" || details.ErrorMessage != "" {
+		return &upcloud.Problem{
+			Type:  details.ErrorCode,
+			Title: details.ErrorMessage,
+		}
+	}
+	return &upcloud.Problem{
+		Type:  details.State,
+		Title: "Storage Import Failed",
+	}
 }
 
 // ResizeStorageFilesystem resizes the last partition of a storage and the ext3/ext4/XFS/NTFS filesystem
@@ -257,3 +364,25 @@ func (s *Service) ResizeStorageFilesystem(ctx context.Context, r *request.Resize
 	resizeBackup := upcloud.ResizeStorageFilesystemBackup{}
 	return &resizeBackup, s.create(ctx, r, &resizeBackup)
 }
+
+// GetServerStorageProtection returns the backup and encryption status of each disk attached to the server. CD-ROM
+// devices are skipped.
+func (s *Service) GetServerStorageProtection(ctx context.Context, r *request.GetServerDetailsRequest) ([]upcloud.StorageProtection, error) {
+	server, err := s.GetServerDetails(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	protection := make([]upcloud.StorageProtection, 0, len(server.StorageDevices))
+	for _, device := range server.StorageDevices {
+		if !device.IsDisk() {
+			continue
+		}
+		details, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: device.UUID})
+		if err != nil {
+			return nil, err
+		}
+		protection = append(protection, upcloud.NewStorageProtection(device, details))
+	}
+	return protection, nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
 )
 
@@ -687,3 +688,113 @@ func waitForStorageOnlineState(ctx context.Context, rec *recorder.Recorder, svc
 
 	return err
 }
+
+// TestWaitForStorageState_details ensures that the storage details fetched by the last poll are returned
+func TestWaitForStorageState_details(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/storage/uuid").Reply(http.StatusOK, `{"storage":{"uuid":"uuid","state":"syncing","size":10}}`).Once()
+	m.On(http.MethodGet, "/storage/uuid").Reply(http.StatusOK, `{"storage":{"uuid":"uuid","state":"online","size":20}}`)
+
+	details, err := svc.WaitForStorageState(context.Background(), &request.WaitForStorageStateRequest{
+		UUID:         "uuid",
+		DesiredState: upcloud.StorageStateOnline,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.StorageStateOnline, details.State)
+	assert.Equal(t, 20, details.Size)
+	assert.Equal(t, 2, m.Called(http.MethodGet, "/storage/uuid"))
+}
+
+func TestGetServerStorageProtection(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"disk-1","storage_title":"root","type":"disk","storage_encrypted":"yes"},
+		{"address":"ide:0:0","storage":"cdrom-1","type":"cdrom"}
+	]}}}`)
+	m.On(http.MethodGet, "/storage/disk-1").Reply(http.StatusOK, `{"storage":{"uuid":"disk-1","backup_rule":{"interval":"daily","time":"0430","retention":"7"},"backups":{"backup":["backup-1"]}}}`)
+
+	protection, err := svc.GetServerStorageProtection(context.Background(), &request.GetServerDetailsRequest{UUID: "uuid"})
+	require.NoError(t, err)
+	require.Len(t, protection, 1)
+	assert.Equal(t, "virtio:0", protection[0].Address)
+	assert.True(t, protection[0].Encrypted)
+	assert.Equal(t, &upcloud.BackupRule{Interval: "daily", Time: "0430", Retention: 7}, protection[0].BackupRule)
+	assert.Equal(t, []string{"backup-1"}, protection[0].BackupUUIDs)
+	assert.Zero(t, m.Called(http.MethodGet, "/storage/cdrom-1"))
+}
+
+func TestForEachStorage(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/backup?limit=2&offset=0").Reply(http.StatusOK, `{"storages":{"storage":[{"uuid":"b-1","zone":"fi-hel1"},{"uuid":"b-2","zone":"de-fra1"}]}}`)
+	m.On(http.MethodGet, "/storage/backup?limit=2&offset=2").Reply(http.StatusOK, `{"storages":{"storage":[{"uuid":"b-3","zone":"fi-hel1"}]}}`)
+
+	var uuids []string
+	err := svc.ForEachStorage(context.Background(), &request.GetStoragesRequest{
+		Type: upcloud.StorageTypeBackup,
+		Page: &request.Page{Size: 2},
+		Zone: "fi-hel1",
+	}, func(s upcloud.Storage) bool {
+		uuids = append(uuids, s.UUID)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b-1", "b-3"}, uuids)
+	assert.Len(t, m.Calls(), 2)
+
+	// Iteration stops when the callback returns false
+	uuids = nil
+	err = svc.ForEachStorage(context.Background(), &request.GetStoragesRequest{
+		Type: upcloud.StorageTypeBackup,
+		Page: &request.Page{Size: 2},
+	}, func(s upcloud.Storage) bool {
+		uuids = append(uuids, s.UUID)
+		return false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b-1"}, uuids)
+	assert.Len(t, m.Calls(), 3)
+
+	// Zone filter is applied to listings as well
+	storages, err := svc.GetStorages(context.Background(), &request.GetStoragesRequest{
+		Type: upcloud.StorageTypeBackup,
+		Page: &request.Page{Size: 2},
+		Zone: "de-fra1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []upcloud.Storage{{UUID: "b-2", Zone: "de-fra1"}}, storages.Storages)
+}
+
+func TestDetachAllStorages(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"data-1","type":"disk","boot_disk":"0"},
+		{"address":"virtio:1","storage":"root","type":"disk","boot_disk":"1"},
+		{"address":"ide:0:0","storage":"cdrom-1","type":"cdrom"},
+		{"address":"virtio:2","storage":"data-2","type":"disk","boot_disk":"0"}
+	]}}}`).Once()
+	m.On(http.MethodPost, "/server/uuid/storage/detach").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/uuid").Reply(http.StatusOK, `{"server":{"uuid":"uuid","state":"stopped"}}`)
+
+	details, err := svc.DetachAllStorages(context.Background(), &request.DetachAllStoragesRequest{ServerUUID: "uuid"})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.ServerStateStopped, details.State)
+
+	var detached []string
+	for _, call := range m.Calls() {
+		if call.Method == http.MethodPost {
+			detached = append(detached, string(call.Body))
+		}
+	}
+	assert.Equal(t, []string{
+		`{"storage_device":{"address":"virtio:0"}}`,
+		`{"storage_device":{"address":"virtio:2"}}`,
+	}, detached)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// ChangeStorageTier moves a storage to another tier. The tier of a storage can not be modified, so the storage is
+// cloned into the target tier and, if the storage is attached to a server, the clone is attached in its place at the
+// same address. The server needs to be stopped, otherwise *upcloud.ServerTransitionError is returned. The source
+// storage is kept, unless DeleteSource is set, in which case it is deleted after the clone has been verified to be
+// attached. If the clone can not be attached in place of the source, the source is attached back and the clone is
+// deleted, even if ctx is done. The details of the new storage are returned, also together with the error if deleting
+// the source storage fails, as the new storage is in use by then.
+func (s *Service) ChangeStorageTier(ctx context.Context, r *request.ChangeStorageTierRequest) (*upcloud.StorageDetails, error) {
+	source, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: r.UUID})
+	if err != nil {
+		return nil, err
+	}
+	if source.Tier == r.Tier {
+		return nil, fmt.Errorf("storage %s is already in tier %s", source.UUID, r.Tier)
+	}
+
+	var server *upcloud.ServerDetails
+	var device *upcloud.ServerStorageDevice
+	if len(source.ServerUUIDs) > 0 {
+		server, err = s.GetServerDetails(ctx, &request.GetServerDetailsRequest{UUID: source.ServerUUIDs[0]})
+		if err != nil {
+			return nil, err
+		}
+		if err := upcloud.CheckServerTransition(server, upcloud.ServerOperationChangeStorageTier); err != nil {
+			return nil, err
+		}
+		device = server.StorageDevice(source.UUID)
+	}
+
+	title := r.Title
+	if title == "" {
+		title = source.Title
+	}
+	op, err := s.CloneStorageOperation(ctx, &request.CloneStorageRequest{
+		UUID:      source.UUID,
+		Zone:      source.Zone,
+		Tier:      r.Tier,
+		Title:     title,
+		Encrypted: source.Encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clone, err := op.Wait(ctx)
+	if err != nil {
+		return nil, s.deleteClones(ctx, []string{op.Details.UUID}, err)
+	}
+
+	if device != nil {
+		if err := s.swapStorage(ctx, server.UUID, *device, clone.UUID); err != nil {
+			return nil, s.deleteClones(ctx, []string{clone.UUID}, err)
+		}
+	}
+	var deleteErr error
+	if r.DeleteSource {
+		if err := s.DeleteStorage(ctx, &request.DeleteStorageRequest{UUID: source.UUID}); err != nil {
+			deleteErr = fmt.Errorf("deleting source storage %s: %w", source.UUID, err)
+		}
+	}
+
+	details, err := s.GetStorageDetails(ctx, &request.GetStorageDetailsRequest{UUID: clone.UUID})
+	if deleteErr != nil {
+		if err != nil {
+			details = clone
+		}
+		return details, deleteErr
+	}
+	return details, err
+}
+
+// swapStorage replaces the storage of the device with the storage at the same address. If attaching the storage
+// fails, or the storage is not attached at the address afterwards, the original storage is attached back on a cleanup
+// context.
+func (s *Service) swapStorage(ctx context.Context, serverUUID string, device upcloud.ServerStorageDevice, storageUUID string) error {
+	if _, err := s.DetachStorage(ctx, &request.DetachStorageRequest{ServerUUID: serverUUID, Address: device.Address}); err != nil {
+		return fmt.Errorf("detaching storage %s: %w", device.UUID, err)
+	}
+	attach := &request.AttachStorageRequest{
+		ServerUUID:  serverUUID,
+		Type:        upcloud.StorageTypeDisk,
+		Address:     device.Address,
+		StorageUUID: storageUUID,
+		BootDisk:    device.BootDisk,
+	}
+	server, err := s.AttachStorage(ctx, attach)
+	if err != nil {
+		err = fmt.Errorf("attaching storage %s: %w", storageUUID, err)
+		return s.reattachStorage(ctx, serverUUID, device, false, err)
+	}
+	if attached := server.StorageDeviceByAddress(device.Address); attached == nil || attached.UUID != storageUUID {
+		err = fmt.Errorf("storage %s is not attached to server %s at %s", storageUUID, serverUUID, device.Address)
+		return s.reattachStorage(ctx, serverUUID, device, attached != nil, err)
+	}
+	return nil
+}
+
+// reattachStorage attaches the storage of the device back to the server after a failed swap and returns err joined
+// with the errors of doing so. If occupied is set, the storage at the address of the device is detached first.
+func (s *Service) reattachStorage(ctx context.Context, serverUUID string, device upcloud.ServerStorageDevice, occupied bool, err error) error {
+	ctx, cancel := cleanupContext(ctx)
+	defer cancel()
+
+	if occupied {
+		if _, detachErr := s.DetachStorage(ctx, &request.DetachStorageRequest{ServerUUID: serverUUID, Address: device.Address}); detachErr != nil {
+			return errors.Join(err, fmt.Errorf("detaching storage at %s: %w", device.Address, detachErr))
+		}
+	}
+	_, attachErr := s.AttachStorage(ctx, &request.AttachStorageRequest{
+		ServerUUID:  serverUUID,
+		Type:        upcloud.StorageTypeDisk,
+		Address:     device.Address,
+		StorageUUID: device.UUID,
+		BootDisk:    device.BootDisk,
+	})
+	if attachErr != nil {
+		return errors.Join(err, fmt.Errorf("reattaching storage %s: %w", device.UUID, attachErr))
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeStorageTier(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/storage/disk").Reply(http.StatusOK, `{"storage":{"uuid":"disk","title":"data","tier":"maxiops","zone":"fi-hel1","servers":{"server":["server"]}}}`)
+	m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, `{"server":{"uuid":"server","state":"stopped","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"root","type":"disk","boot_disk":"1"},
+		{"address":"virtio:1","storage":"disk","type":"disk","boot_disk":"0"}
+	]}}}`)
+	m.On(http.MethodPost, "/storage/disk/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone").Reply(http.StatusOK, `{"storage":{"uuid":"clone","state":"online","tier":"standard"}}`)
+	m.On(http.MethodPost, "/server/server/storage/detach").Reply(http.StatusAccepted, `{"server":{"uuid":"server"}}`)
+	m.On(http.MethodPost, "/server/server/storage/attach").Reply(http.StatusAccepted, `{"server":{"uuid":"server","storage_devices":{"storage_device":[
+		{"address":"virtio:0","storage":"root","type":"disk"},
+		{"address":"virtio:1","storage":"clone","type":"disk"}
+	]}}}`)
+	m.On(http.MethodDelete, "/storage/disk").Reply(http.StatusNoContent, "")
+
+	storage, err := svc.ChangeStorageTier(context.Background(), &request.ChangeStorageTierRequest{UUID: "disk", Tier: upcloud.StorageTierStandard, DeleteSource: true})
+	require.NoError(t, err)
+	assert.Equal(t, "clone", storage.UUID)
+	m.AssertExpectations(t)
+
+	for _, call := range m.Calls() {
+		switch call.Path {
+		case "/storage/disk/clone":
+			assert.JSONEq(t, `{"storage":{"zone":"fi-hel1","tier":"standard","title":"data"}}`, string(call.Body))
+		case "/server/server/storage/detach":
+			assert.JSONEq(t, `{"storage_device":{"address":"virtio:1"}}`, string(call.Body))
+		case "/server/server/storage/attach":
+			assert.JSONEq(t, `{"storage_device":{"type":"disk","address":"virtio:1","storage":"clone"}}`, string(call.Body))
+		}
+	}
+
+	_, err = svc.ChangeStorageTier(context.Background(), &request.ChangeStorageTierRequest{UUID: "disk", Tier: upcloud.StorageTierMaxIOPS})
+	assert.EqualError(t, err, "storage disk is already in tier maxiops")
+}
+
+func TestChangeStorageTier_deleteSourceFails(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodGet, "/storage/disk").Reply(http.StatusOK, `{"storage":{"uuid":"disk","title":"data","tier":"maxiops","zone":"fi-hel1"}}`)
+	m.On(http.MethodPost, "/storage/disk/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/clone").Reply(http.StatusOK, `{"storage":{"uuid":"clone","state":"online","tier":"standard"}}`)
+	m.On(http.MethodDelete, "/storage/disk").ReplyError(http.StatusConflict, upcloud.ErrCodeStorageStateIllegal, "storage is busy")
+
+	storage, err := svc.ChangeStorageTier(context.Background(), &request.ChangeStorageTierRequest{UUID: "disk", Tier: upcloud.StorageTierStandard, DeleteSource: true})
+	assert.ErrorContains(t, err, "deleting source storage disk")
+	require.NotNil(t, storage)
+	assert.Equal(t, "clone", storage.UUID)
+	m.AssertExpectations(t)
+}
+
+func TestChangeStorageTier_reattach(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name    string
+		attach  func(m *upcloudtest.MockTransport)
+		wantErr string
+		calls   []string
+	}{
+		{
+			name: "attach fails",
+			attach: func(m *upcloudtest.MockTransport) {
+				m.On(http.MethodPost, "/server/server/storage/attach").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "server is busy").Once()
+			},
+			wantErr: "attaching storage clone",
+			calls:   []string{"detach virtio:1", "attach clone", "attach disk"},
+		},
+		{
+			name: "clone not attached",
+			attach: func(m *upcloudtest.MockTransport) {
+				m.On(http.MethodPost, "/server/server/storage/attach").Reply(http.StatusAccepted, `{"server":{"uuid":"server","storage_devices":{"storage_device":[
+					{"address":"virtio:1","storage":"other","type":"disk"}
+				]}}}`).Once()
+			},
+			wantErr: "storage clone is not attached to server server at virtio:1",
+			calls:   []string{"detach virtio:1", "attach clone", "detach virtio:1", "attach disk"},
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+			m.On(http.MethodGet, "/storage/disk").Reply(http.StatusOK, `{"storage":{"uuid":"disk","tier":"maxiops","zone":"fi-hel1","servers":{"server":["server"]}}}`)
+			m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, `{"server":{"uuid":"server","state":"stopped","storage_devices":{"storage_device":[
+				{"address":"virtio:1","storage":"disk","type":"disk","boot_disk":"0"}
+			]}}}`)
+			m.On(http.MethodPost, "/storage/disk/clone").Reply(http.StatusCreated, `{"storage":{"uuid":"clone","state":"maintenance"}}`)
+			m.On(http.MethodGet, "/storage/clone").Reply(http.StatusOK, `{"storage":{"uuid":"clone","state":"online"}}`)
+			m.On(http.MethodPost, "/server/server/storage/detach").Reply(http.StatusAccepted, `{"server":{"uuid":"server"}}`)
+			test.attach(m)
+			m.On(http.MethodPost, "/server/server/storage/attach").Reply(http.StatusAccepted, `{"server":{"uuid":"server"}}`)
+			m.On(http.MethodDelete, "/storage/clone").Reply(http.StatusNoContent, "")
+
+			_, err := svc.ChangeStorageTier(context.Background(), &request.ChangeStorageTierRequest{UUID: "disk", Tier: upcloud.StorageTierStandard, DeleteSource: true})
+			assert.ErrorContains(t, err, test.wantErr)
+
+			var calls []string
+			for _, call := range m.Calls() {
+				var body struct {
+					StorageDevice struct {
+						Address string `json:"address"`
+						Storage string `json:"storage"`
+					} `json:"storage_device"`
+				}
+				switch call.Path {
+				case "/server/server/storage/detach":
+					require.NoError(t, json.Unmarshal(call.Body, &body))
+					calls = append(calls, "detach "+body.StorageDevice.Address)
+				case "/server/server/storage/attach":
+					require.NoError(t, json.Unmarshal(call.Body, &body))
+					calls = append(calls, "attach "+body.StorageDevice.Storage)
+				}
+			}
+			assert.Equal(t, test.calls, calls)
+			// The clone is deleted and the source is kept
+			assert.Equal(t, 1, m.Called(http.MethodDelete, "/storage/clone"))
+			assert.Equal(t, 0, m.Called(http.MethodDelete, "/storage/disk"))
+		})
+	}
+}
+
+func TestChangeStorageTier_serverStarted(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/storage/disk").Reply(http.StatusOK, `{"storage":{"uuid":"disk","tier":"maxiops","servers":{"server":["server"]}}}`)
+	m.On(http.MethodGet, "/server/server").Reply(http.StatusOK, `{"server":{"uuid":"server","state":"started"}}`)
+
+	_, err := svc.ChangeStorageTier(context.Background(), &request.ChangeStorageTierRequest{UUID: "disk", Tier: upcloud.StorageTierStandard})
+	require.ErrorIs(t, err, upcloud.ErrIllegalTransition)
+	assert.EqualError(t, err, "can not change_storage_tier server server in started state, required steps: stop")
+}
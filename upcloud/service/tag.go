@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
@@ -14,6 +16,7 @@ type Tag interface {
 	DeleteTag(ctx context.Context, r *request.DeleteTagRequest) error
 	TagServer(ctx context.Context, r *request.TagServerRequest) (*upcloud.ServerDetails, error)
 	UntagServer(ctx context.Context, r *request.UntagServerRequest) (*upcloud.ServerDetails, error)
+	RenameTag(ctx context.Context, r *request.RenameTagRequest) (*upcloud.Tag, error)
 }
 
 // CreateTag creates a new tag, optionally assigning it to one or more servers at the same time
@@ -50,3 +53,59 @@ func (s *Service) UntagServer(ctx context.Context, r *request.UntagServerRequest
 	serverDetails := upcloud.ServerDetails{}
 	return &serverDetails, s.create(ctx, r, &serverDetails)
 }
+
+// RenameTag renames a tag by creating the new tag, tagging all servers of the old tag with it and finally deleting the
+// old tag. The steps are idempotent, so a rename that failed part way can be resumed by calling RenameTag again with
+// the same request. The new tag is returned.
+func (s *Service) RenameTag(ctx context.Context, r *request.RenameTagRequest) (*upcloud.Tag, error) {
+	tags, err := s.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldTag, newTag *upcloud.Tag
+	for i := range tags.Tags {
+		switch tags.Tags[i].Name {
+		case r.Name:
+			oldTag = &tags.Tags[i]
+		case r.NewName:
+			newTag = &tags.Tags[i]
+		}
+	}
+
+	if oldTag == nil {
+		if newTag != nil {
+			// Rename has already been completed
+			return newTag, nil
+		}
+		return nil, fmt.Errorf("tag %q not found", r.Name)
+	}
+
+	if newTag == nil {
+		newTag, err = s.CreateTag(ctx, &request.CreateTagRequest{
+			Tag: upcloud.Tag{
+				Name:        r.NewName,
+				Description: oldTag.Description,
+				Servers:     oldTag.Servers,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating tag %q: %w", r.NewName, err)
+		}
+	}
+
+	for _, serverUUID := range oldTag.Servers {
+		if slices.Contains(newTag.Servers, serverUUID) {
+			continue
+		}
+		if _, err := s.TagServer(ctx, &request.TagServerRequest{UUID: serverUUID, Tags: []string{r.NewName}}); err != nil {
+			return nil, fmt.Errorf("tagging server %s with %q: %w", serverUUID, r.NewName, err)
+		}
+		newTag.Servers = append(newTag.Servers, serverUUID)
+	}
+
+	if err := s.DeleteTag(ctx, &request.DeleteTagRequest{Name: r.Name}); err != nil {
+		return nil, fmt.Errorf("deleting tag %q: %w", r.Name, err)
+	}
+	return newTag, nil
+}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,3 +207,58 @@ func deleteAllTags(ctx context.Context, svc *Service) error {
 
 	return nil
 }
+
+func TestRenameTag(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[{"name":"old","description":"desc","servers":{"server":["a","b"]}}]}}`)
+	m.On(http.MethodPost, "/tag").Reply(http.StatusCreated, `{"tag":{"name":"new","description":"desc","servers":{"server":["a","b"]}}}`)
+	m.On(http.MethodDelete, "/tag/old").Reply(http.StatusNoContent, "")
+
+	tag, err := svc.RenameTag(context.Background(), &request.RenameTagRequest{Name: "old", NewName: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, "new", tag.Name)
+	assert.Equal(t, upcloud.TagServerSlice{"a", "b"}, tag.Servers)
+	assert.JSONEq(t, `{"tag":{"name":"new","description":"desc","servers":{"server":["a","b"]}}}`, string(m.Calls()[1].Body))
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/tag/old"))
+}
+
+func TestRenameTag_resume(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[
+		{"name":"old","servers":{"server":["a","b"]}},
+		{"name":"new","servers":{"server":["a"]}}
+	]}}`).Once()
+	m.On(http.MethodPost, "/server/b/tag/new").ReplyError(http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "try again").Once()
+	m.On(http.MethodPost, "/server/b/tag/new").ReplyCorpus(upcloudtest.CorpusServerDetails)
+	m.On(http.MethodDelete, "/tag/old").Reply(http.StatusNoContent, "")
+
+	// First attempt fails when tagging the server and leaves the old tag in place
+	_, err := svc.RenameTag(context.Background(), &request.RenameTagRequest{Name: "old", NewName: "new"})
+	assert.ErrorContains(t, err, "tagging server b")
+	assert.Zero(t, m.Called(http.MethodDelete, "/tag/old"))
+
+	// Second attempt resumes from the failed step
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[
+		{"name":"old","servers":{"server":["a","b"]}},
+		{"name":"new","servers":{"server":["a"]}}
+	]}}`).Once()
+	tag, err := svc.RenameTag(context.Background(), &request.RenameTagRequest{Name: "old", NewName: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, upcloud.TagServerSlice{"a", "b"}, tag.Servers)
+	assert.Zero(t, m.Called(http.MethodPost, "/tag"))
+	assert.Zero(t, m.Called(http.MethodPost, "/server/a/tag/new"))
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/tag/old"))
+
+	// Completed rename is a no-op
+	m.On(http.MethodGet, "/tag").Reply(http.StatusOK, `{"tags":{"tag":[{"name":"new","servers":{"server":["a","b"]}}]}}`)
+	_, err = svc.RenameTag(context.Background(), &request.RenameTagRequest{Name: "old", NewName: "new"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/tag/old"))
+
+	_, err = svc.RenameTag(context.Background(), &request.RenameTagRequest{Name: "missing", NewName: "other"})
+	assert.ErrorContains(t, err, `tag "missing" not found`)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// TemplateVersionLabel is the label key holding the version of the templates built with BuildTemplate
+const TemplateVersionLabel = "template_version"
+
+// BuildTemplate builds a template from a temporary server. The server is created and started, and if ReadyTag is
+// set, BuildTemplate waits until the provisioning script has tagged the server. The server is then stopped, its boot
+// disk is templatized and labeled with the version and labels of the request, and the server is deleted together with
+// its disks. The server is deleted also if any of the steps fails, even if ctx is done. The details of the template are returned.
+func (s *Service) BuildTemplate(ctx context.Context, r *request.BuildTemplateRequest) (*upcloud.StorageDetails, error) {
+	if r.Title == "" {
+		return nil, errors.New("template title is required")
+	}
+	server, err := s.CreateServer(ctx, &r.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.buildTemplate(ctx, server.UUID, r)
+	if cleanupErr := s.deleteServerWithStorages(ctx, server.UUID); cleanupErr != nil {
+		err = errors.Join(err, fmt.Errorf("deleting build server %s: %w", server.UUID, cleanupErr))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *Service) buildTemplate(ctx context.Context, serverUUID string, r *request.BuildTemplateRequest) (*upcloud.StorageDetails, error) {
+	server, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: serverUUID, DesiredState: upcloud.ServerStateStarted})
+	if err != nil {
+		return nil, err
+	}
+	if r.ReadyTag != "" {
+		server, err = retry(ctx, func(_ int, c context.Context) (*upcloud.ServerDetails, error) {
+			details, err := s.GetServerDetails(c, &request.GetServerDetailsRequest{UUID: serverUUID})
+			if err != nil || !slices.Contains(details.Tags, r.ReadyTag) {
+				return nil, err
+			}
+			return details, nil
+		}, s.retryConfig())
+		if err != nil {
+			return nil, fmt.Errorf("waiting for server %s to be tagged %s: %w", serverUUID, r.ReadyTag, err)
+		}
+	}
+
+	if _, err := s.StopServer(ctx, &request.StopServerRequest{UUID: serverUUID}); err != nil {
+		return nil, err
+	}
+	if _, err := s.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: serverUUID, DesiredState: upcloud.ServerStateStopped}); err != nil {
+		return nil, err
+	}
+
+	disk := bootDisk(server.StorageDevices)
+	if disk == nil {
+		return nil, fmt.Errorf("server %s has no disks", serverUUID)
+	}
+	op, err := s.TemplatizeStorageOperation(ctx, &request.TemplatizeStorageRequest{UUID: disk.UUID, Title: r.Title})
+	if err != nil {
+		return nil, err
+	}
+	template, err := op.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := slices.Clone(r.Labels)
+	if r.Version != "" {
+		labels = append(labels, upcloud.Label{Key: TemplateVersionLabel, Value: r.Version})
+	}
+	if len(labels) > 0 {
+		template, err = s.ModifyStorage(ctx, &request.ModifyStorageRequest{UUID: template.UUID, Labels: &labels})
+		if err != nil {
+			return nil, fmt.Errorf("labeling template %s: %w", op.Details.UUID, err)
+		}
+	}
+	return template, nil
+}
+
+// bootDisk returns the disk marked as boot disk, or the first disk if none is marked
+func bootDisk(devices []upcloud.ServerStorageDevice) *upcloud.ServerStorageDevice {
+	var first *upcloud.ServerStorageDevice
+	for i := range devices {
+		if !devices[i].IsDisk() {
+			continue
+		}
+		if devices[i].BootDisk == 1 {
+			return &devices[i]
+		}
+		if first == nil {
+			first = &devices[i]
+		}
+	}
+	return first
+}
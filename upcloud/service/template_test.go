@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const templateTestServer = `{"server":{"uuid":"build","state":"%s","tags":{"tag":[%s]},"storage_devices":{"storage_device":[
+	{"address":"virtio:0","storage":"disk","type":"disk","boot_disk":"1"}
+]}}}`
+
+func TestBuildTemplate(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"build","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "started", "")).Times = 2
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "started", `"ready"`)).Once()
+	m.On(http.MethodPost, "/server/build/stop").Reply(http.StatusAccepted, `{"server":{"uuid":"build"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "stopped", `"ready"`))
+	m.On(http.MethodPost, "/storage/disk/templatize").Reply(http.StatusCreated, `{"storage":{"uuid":"template","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/storage/template").Reply(http.StatusOK, `{"storage":{"uuid":"template","state":"online"}}`)
+	m.On(http.MethodPut, "/storage/template").Reply(http.StatusAccepted, `{"storage":{"uuid":"template","state":"online","labels":[{"key":"template_version","value":"1.2.0"}]}}`)
+	m.On(http.MethodDelete, "/server/build/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+
+	template, err := svc.BuildTemplate(context.Background(), &request.BuildTemplateRequest{
+		Server:   request.CreateServerRequest{Zone: "fi-hel1", Title: "build"},
+		ReadyTag: "ready",
+		Title:    "my-template",
+		Version:  "1.2.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "template", template.UUID)
+	assert.Equal(t, []upcloud.Label{{Key: TemplateVersionLabel, Value: "1.2.0"}}, template.Labels)
+	m.AssertExpectations(t)
+}
+
+func TestBuildTemplate_cleanup(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"build","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "started", "")).Times = 2
+	m.On(http.MethodPost, "/server/build/stop").ReplyError(http.StatusConflict, upcloud.ErrCodeServerStateIllegal, "server is busy").Once()
+	m.On(http.MethodPost, "/server/build/stop").Reply(http.StatusAccepted, `{"server":{"uuid":"build"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "stopped", ""))
+	m.On(http.MethodDelete, "/server/build/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+
+	_, err := svc.BuildTemplate(context.Background(), &request.BuildTemplateRequest{Title: "my-template"})
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, upcloud.ErrCodeServerStateIllegal, problem.ErrorCode())
+	m.AssertExpectations(t)
+	assert.Contains(t, string(m.Calls()[len(m.Calls())-3].Body), `"stop_type":"hard"`)
+}
+
+func TestBuildTemplate_cleanupAfterCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := upcloudtest.NewMockTransport()
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"build","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "started", "")).Times = 3
+	m.On(http.MethodPost, "/server/build/stop").Reply(http.StatusAccepted, `{"server":{"uuid":"build"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "stopped", ""))
+	m.On(http.MethodDelete, "/server/build/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+	var gets int
+	transport := &customRoundTripper{fn: func(r *http.Request) (*http.Response, error) {
+		// The caller gives up while waiting for the server to be tagged
+		if r.Method == http.MethodGet {
+			if gets++; gets == 2 {
+				cancel()
+			}
+		}
+		return m.RoundTrip(r)
+	}}
+	svc := New(client.New("user", "pass", client.WithHTTPClient(&http.Client{Transport: transport})), WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}))
+
+	_, err := svc.BuildTemplate(ctx, &request.BuildTemplateRequest{Title: "my-template", ReadyTag: "ready"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, m.Called(http.MethodDelete, "/server/build/"))
+}
+
+func TestBuildTemplate_deletionGuard(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService(
+		WithBackoff(client.ConstantBackoff{Interval: time.Millisecond}),
+		WithDeletionGuard(RequireLabel("env", "test")),
+	)
+	m.On(http.MethodPost, "/server").Reply(http.StatusAccepted, `{"server":{"uuid":"build","state":"maintenance"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "started", "")).Once()
+	m.On(http.MethodPost, "/storage/disk/templatize").ReplyError(http.StatusConflict, upcloud.ErrCodeStorageStateIllegal, "storage is busy")
+	m.On(http.MethodPost, "/server/build/stop").Reply(http.StatusAccepted, `{"server":{"uuid":"build"}}`)
+	m.On(http.MethodGet, "/server/build").Reply(http.StatusOK, fmt.Sprintf(templateTestServer, "stopped", ""))
+	m.On(http.MethodDelete, "/server/build/?storages=1&backups=delete").Reply(http.StatusNoContent, "")
+
+	// The unlabeled build server is deleted although the guard would refuse deleting it
+	_, err := svc.BuildTemplate(context.Background(), &request.BuildTemplateRequest{Title: "my-template"})
+	assert.NotErrorIs(t, err, ErrDeletionRefused)
+	m.AssertExpectations(t)
+}
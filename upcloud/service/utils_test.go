@@ -13,6 +13,7 @@ import (
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
 	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/upcloudtest"
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/stretchr/testify/require"
@@ -231,3 +232,9 @@ func setupTestServerAndService(handler http.Handler) (*httptest.Server, *Service
 	srv := httptest.NewServer(handler)
 	return srv, New(client.New("user", "pass", client.WithBaseURL(srv.URL)))
 }
+
+// Returns a mock transport and a new service that sends its requests through said transport
+func setupMockTransportAndService(c ...ConfigFn) (*upcloudtest.MockTransport, *Service) {
+	m := upcloudtest.NewMockTransport()
+	return m, New(client.New("user", "pass", client.WithHTTPClient(m.HTTPClient())), c...)
+}
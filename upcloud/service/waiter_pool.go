@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+)
+
+// defaultWaiterPoolInterval is the interval between listing the servers of a ServerWaiterPool by default
+const defaultWaiterPoolInterval = 5 * time.Second
+
+// ServerWaiterPool waits for the states of many servers with a single GetServers call per interval, instead of
+// polling GetServerDetails for each server as WaitForServerState does. The pool is safe for concurrent use and lists
+// the servers only while there are waiters.
+type ServerWaiterPool struct {
+	api interface {
+		GetServers(ctx context.Context) (*upcloud.Servers, error)
+	}
+	interval time.Duration
+
+	mu      sync.Mutex
+	waiters map[*serverWaiter]struct{}
+	running bool
+}
+
+type serverWaiter struct {
+	r    request.WaitForServerStateRequest
+	done chan serverWaitResult
+
+	// seen is set once the server has been listed and lastErr holds the error of the last failed listing, both guarded
+	// by the mutex of the pool
+	seen    bool
+	lastErr error
+}
+
+type serverWaitResult struct {
+	server *upcloud.Server
+	err    error
+}
+
+// NewServerWaiterPool returns a pool that lists the servers with api every interval, or every five seconds if
+// interval is zero
+func NewServerWaiterPool(api Server, interval time.Duration) *ServerWaiterPool {
+	if interval <= 0 {
+		interval = defaultWaiterPoolInterval
+	}
+	return &ServerWaiterPool{
+		api:      api,
+		interval: interval,
+		waiters:  make(map[*serverWaiter]struct{}),
+	}
+}
+
+// WaitForServerState blocks until the server has entered the desired state or left the undesired state, like
+// Service.WaitForServerState, and returns the server as listed by GetServers. Like Service.WaitForServerState, the
+// pool keeps polling if listing the servers fails or the server is not listed yet, e.g. because it was just created.
+// A problem for which upcloud.IsNotFound is true is returned if the server disappears from the list after it has been
+// listed. The method gives up when the context is done, and the error of the last failed listing is wrapped in the
+// returned error.
+func (p *ServerWaiterPool) WaitForServerState(ctx context.Context, r *request.WaitForServerStateRequest) (*upcloud.Server, error) {
+	w := &serverWaiter{r: *r, done: make(chan serverWaitResult, 1)}
+	p.mu.Lock()
+	p.waiters[w] = struct{}{}
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+	p.mu.Unlock()
+
+	select {
+	case result := <-w.done:
+		return result.server, result.err
+	case <-ctx.Done():
+		p.mu.Lock()
+		delete(p.waiters, w)
+		lastErr := w.lastErr
+		p.mu.Unlock()
+		// The result may have been delivered while the waiter was being removed
+		select {
+		case result := <-w.done:
+			return result.server, result.err
+		default:
+		}
+		if lastErr != nil {
+			return nil, fmt.Errorf("%w: listing servers: %w", ctx.Err(), lastErr)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// run lists the servers every interval until there are no waiters left
+func (p *ServerWaiterPool) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.mu.Lock()
+		if len(p.waiters) == 0 {
+			p.running = false
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), max(p.interval, time.Minute))
+		servers, err := p.api.GetServers(ctx)
+		cancel()
+		p.dispatch(servers, err)
+	}
+}
+
+// dispatch completes the waits that the listed servers satisfy. The waits are kept pending if listing the servers
+// failed.
+func (p *ServerWaiterPool) dispatch(servers *upcloud.Servers, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		for w := range p.waiters {
+			w.lastErr = err
+		}
+		return
+	}
+
+	byUUID := make(map[string]*upcloud.Server)
+	for i := range servers.Servers {
+		byUUID[servers.Servers[i].UUID] = &servers.Servers[i]
+	}
+	for w := range p.waiters {
+		var result serverWaitResult
+		server, ok := byUUID[w.r.UUID]
+		w.lastErr = nil
+		switch {
+		case !ok && !w.seen:
+			continue
+		case !ok:
+			result.err = &upcloud.Problem{
+				Type:   upcloud.ErrCodeServerNotFound,
+				Title:  fmt.Sprintf("server %s not found", w.r.UUID),
+				Status: http.StatusNotFound,
+			}
+		case w.r.DesiredState != "" && server.State == w.r.DesiredState,
+			w.r.UndesiredState != "" && server.State != w.r.UndesiredState:
+			s := *server
+			result.server = &s
+		default:
+			w.seen = true
+			continue
+		}
+		w.done <- result
+		delete(p.waiters, w)
+	}
+}
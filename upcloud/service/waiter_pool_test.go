@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerWaiterPool(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[
+		{"uuid":"a","state":"started"},{"uuid":"b","state":"maintenance"},{"uuid":"c","state":"stopped"}
+	]}}`).Once()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[
+		{"uuid":"a","state":"stopped"},{"uuid":"b","state":"started"}
+	]}}`)
+
+	pool := NewServerWaiterPool(svc, 10*time.Millisecond)
+	requests := []request.WaitForServerStateRequest{
+		{UUID: "a", DesiredState: upcloud.ServerStateStopped},
+		{UUID: "b", UndesiredState: upcloud.ServerStateMaintenance},
+		{UUID: "c", DesiredState: upcloud.ServerStateStarted},
+	}
+	servers := make([]*upcloud.Server, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			servers[i], errs[i] = pool.WaitForServerState(context.Background(), &requests[i])
+		}(i)
+	}
+	wg.Wait()
+
+	require.NoError(t, errs[0])
+	assert.Equal(t, upcloud.ServerStateStopped, servers[0].State)
+	require.NoError(t, errs[1])
+	assert.Equal(t, upcloud.ServerStateStarted, servers[1].State)
+	// The server was deleted after it had been listed
+	assert.True(t, upcloud.IsNotFound(errs[2]))
+
+	// All waiters were served by listing the servers
+	for _, call := range m.Calls() {
+		assert.Equal(t, "/server", call.Path)
+	}
+	assert.GreaterOrEqual(t, len(m.Calls()), 2)
+}
+
+func TestServerWaiterPool_contextDone(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[{"uuid":"a","state":"started"}]}}`)
+
+	pool := NewServerWaiterPool(svc, 5*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := pool.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: "a", DesiredState: upcloud.ServerStateStopped})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The pool stops listing the servers once there are no waiters
+	require.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		return !pool.running
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestServerWaiterPool_keepsPolling(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").ReplyError(http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "try again").Once()
+	// The server has just been created and is not listed yet
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[]}}`).Once()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[{"uuid":"a","state":"started"}]}}`)
+
+	pool := NewServerWaiterPool(svc, 5*time.Millisecond)
+	server, err := pool.WaitForServerState(context.Background(), &request.WaitForServerStateRequest{UUID: "a", DesiredState: upcloud.ServerStateStarted})
+	require.NoError(t, err)
+	assert.Equal(t, "a", server.UUID)
+	m.AssertExpectations(t)
+}
+
+func TestServerWaiterPool_lastError(t *testing.T) {
+	t.Parallel()
+
+	m, svc := setupMockTransportAndService()
+	m.On(http.MethodGet, "/server").ReplyError(http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "try again")
+
+	pool := NewServerWaiterPool(svc, 5*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := pool.WaitForServerState(ctx, &request.WaitForServerStateRequest{UUID: "a", DesiredState: upcloud.ServerStateStarted})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var problem *upcloud.Problem
+	require.ErrorAs(t, err, &problem)
+	assert.Equal(t, http.StatusServiceUnavailable, problem.Status)
+}
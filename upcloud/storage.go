@@ -73,20 +73,21 @@ type Storages struct {
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *Storages) UnmarshalJSON(b []byte) error {
-	type storageWrapper struct {
-		Storages []Storage `json:"storage"`
-	}
-
+	s.Storages = nil
+	var storages []storageJSON
 	v := struct {
-		Storages storageWrapper `json:"storages"`
+		Storages struct {
+			Storages *[]storageJSON `json:"storage"`
+		} `json:"storages"`
 	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
+	v.Storages.Storages = &storages
+
+	if err := json.Unmarshal(b, &v); err != nil {
 		return err
 	}
-
-	s.Storages = v.Storages.Storages
-
+	for _, storage := range storages {
+		s.Storages = append(s.Storages, Storage(storage))
+	}
 	return nil
 }
 
@@ -111,6 +112,61 @@ type Storage struct {
 	Labels  []Label   `json:"labels,omitempty"`
 }
 
+// storageLicense points to the license of a storage, which the API returns either as a JSON number or a numeric
+// string. Embedded in a decoding struct at a shallower depth than Storage, it takes precedence over Storage.License.
+type storageLicense struct {
+	License *License `json:"license"`
+}
+
+// storageJSON decodes a Storage with the license in either format
+type storageJSON Storage
+
+func (s *storageJSON) UnmarshalJSON(b []byte) error {
+	type localStorage Storage
+	type storage struct{ *localStorage }
+
+	v := struct {
+		storage
+		storageLicense
+	}{storage{(*localStorage)(s)}, storageLicense{(*License)(&s.License)}}
+
+	return json.Unmarshal(b, &v)
+}
+
+// BackupGroup represents backups that were created together and are labeled with the same group ID
+type BackupGroup struct {
+	ID      string
+	Backups []Storage
+}
+
+// BackupOrigin links a backup to the storage it was taken of and, if known, to the server and address the storage is
+// attached to
+type BackupOrigin struct {
+	StorageUUID string
+	// ServerUUID and Address, e.g. "virtio:0", are set if the backups were listed by server
+	ServerUUID string
+	Address    string
+}
+
+// Backup is a backup storage with a link to its origin
+type Backup struct {
+	Storage
+	Origin BackupOrigin
+}
+
+// Backups represents a list of backups ordered from the newest to the oldest
+type Backups struct {
+	Backups []Backup
+}
+
+// Latest returns the newest backup, or nil if there are no backups
+func (b *Backups) Latest() *Backup {
+	if len(b.Backups) == 0 {
+		return nil
+	}
+	return &b.Backups[0]
+}
+
 // BackupUUIDSlice is a slice of string.
 // It exists to allow for a custom JSON unmarshaller.
 type BackupUUIDSlice []string
@@ -118,17 +174,12 @@ type BackupUUIDSlice []string
 // UnmarshalJSON is a custom unmarshaller that deals with
 // deeply embedded values.
 func (s *BackupUUIDSlice) UnmarshalJSON(b []byte) error {
+	*s = nil
 	v := struct {
-		BackupUUIDs []string `json:"backup"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
+		BackupUUIDs *[]string `json:"backup"`
+	}{(*[]string)(s)}
 
-	(*s) = v.BackupUUIDs
-
-	return nil
+	return json.Unmarshal(b, &v)
 }
 
 // StorageDetails represents detailed information about a piece of storage
@@ -145,17 +196,57 @@ type StorageDetails struct {
 func (s *StorageDetails) UnmarshalJSON(b []byte) error {
 	type localStorageDetails StorageDetails
 
-	v := struct {
-		StorageDetails localStorageDetails `json:"storage"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
+	*s = StorageDetails{}
+	type storageDetails struct {
+		*localStorageDetails
+		storageLicense
 	}
+	v := struct {
+		StorageDetails storageDetails `json:"storage"`
+	}{storageDetails{(*localStorageDetails)(s), storageLicense{(*License)(&s.License)}}}
 
-	(*s) = StorageDetails(v.StorageDetails)
+	return json.Unmarshal(b, &v)
+}
 
-	return nil
+// HasBackupRule returns true if the storage has a backup rule. The API returns an empty backup rule for storages
+// without one.
+func (s *StorageDetails) HasBackupRule() bool {
+	return s.BackupRule != nil && s.BackupRule.Interval != ""
+}
+
+// StorageProtection summarizes the backup and encryption status of a storage device attached to a server.
+type StorageProtection struct {
+	Address     string
+	StorageUUID string
+	Title       string
+	Encrypted   bool
+	// BackupRule is nil if the storage has no backup rule
+	BackupRule  *BackupRule
+	BackupUUIDs []string
+	// Origin is the UUID of the storage the storage was created from, if reported by the API
+	Origin string
+}
+
+// NewStorageProtection combines the device of the server and the details of its storage into a protection summary.
+func NewStorageProtection(device ServerStorageDevice, details *StorageDetails) StorageProtection {
+	p := StorageProtection{
+		Address:     device.Address,
+		StorageUUID: device.UUID,
+		Title:       device.Title,
+		Encrypted:   device.Encrypted.Bool() || details.Encrypted.Bool(),
+		BackupUUIDs: append([]string(nil), details.BackupUUIDs...),
+		Origin:      details.Origin,
+	}
+	if details.HasBackupRule() {
+		rule := *details.BackupRule
+		p.BackupRule = &rule
+	}
+	return p
+}
+
+// BackedUp returns true if the storage has a backup rule or existing backups
+func (p StorageProtection) BackedUp() bool {
+	return p.BackupRule != nil || len(p.BackupUUIDs) > 0
 }
 
 // BackupRule represents a backup rule
@@ -180,6 +271,16 @@ type ServerStorageDevice struct {
 	BootDisk   int    `json:"boot_disk,string"`
 }
 
+// IsCDROM returns true if the device is a CD-ROM device
+func (s ServerStorageDevice) IsCDROM() bool {
+	return s.Type == StorageTypeCDROM
+}
+
+// IsDisk returns true if the device is a disk device
+func (s ServerStorageDevice) IsDisk() bool {
+	return s.Type == StorageTypeDisk
+}
+
 // StorageImportDetails represents the details of an ongoing or completed storage import operation.
 type StorageImportDetails struct {
 	ClientContentLength int       `json:"client_content_length"`
@@ -244,14 +345,21 @@ type ResizeStorageFilesystemBackup struct {
 // UnmarshalJSON is a custom unmarshaller that deals with deeply embedded values.
 func (s *ResizeStorageFilesystemBackup) UnmarshalJSON(b []byte) error {
 	type resizeBackup ResizeStorageFilesystemBackup
+	type backupFields struct{ *resizeBackup }
+	type backupWrapper struct {
+		backupFields
+		storageLicense
+	}
+
+	backup := resizeBackup{}
 	v := struct {
-		ResizeBackup resizeBackup `json:"resize_backup"`
-	}{}
+		ResizeBackup backupWrapper `json:"resize_backup"`
+	}{backupWrapper{backupFields{&backup}, storageLicense{(*License)(&backup.License)}}}
 
 	if err := json.Unmarshal(b, &v); err != nil {
 		return err
 	}
 
-	*s = ResizeStorageFilesystemBackup(v.ResizeBackup)
+	*s = ResizeStorageFilesystemBackup(backup)
 	return nil
 }
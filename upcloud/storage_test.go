@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestUnmarshalStorage tests that Storages and Storage struct are unmarshaled correctly
@@ -305,3 +306,50 @@ func TestUnmarshalResizeStorageFilesystemBackup(t *testing.T) {
 
 	assert.Equal(t, testResizeBackup, resizeBackup)
 }
+
+// TestStorageProtection tests that the protection summary combines device and storage details
+func TestStorageProtection(t *testing.T) {
+	device := ServerStorageDevice{Address: "virtio:0", UUID: "uuid", Title: "disk", Type: StorageTypeDisk, Encrypted: True}
+	details := StorageDetails{BackupRule: &BackupRule{}}
+
+	assert.False(t, details.HasBackupRule())
+	p := NewStorageProtection(device, &details)
+	assert.True(t, p.Encrypted)
+	assert.Nil(t, p.BackupRule)
+	assert.False(t, p.BackedUp())
+
+	details = StorageDetails{
+		Storage:     Storage{Origin: "origin-uuid"},
+		BackupRule:  &BackupRule{Interval: "daily", Time: "0430", Retention: 7},
+		BackupUUIDs: BackupUUIDSlice{"backup-uuid"},
+	}
+	p = NewStorageProtection(ServerStorageDevice{Address: "virtio:1", UUID: "uuid"}, &details)
+	assert.Equal(t, StorageProtection{
+		Address:     "virtio:1",
+		StorageUUID: "uuid",
+		BackupRule:  &BackupRule{Interval: "daily", Time: "0430", Retention: 7},
+		BackupUUIDs: []string{"backup-uuid"},
+		Origin:      "origin-uuid",
+	}, p)
+	assert.True(t, p.BackedUp())
+}
+
+// TestUnmarshalStorage_license tests that the storage license is accepted as both a JSON number and a numeric string
+func TestUnmarshalStorage_license(t *testing.T) {
+	storages := Storages{}
+	err := json.Unmarshal([]byte(`{"storages":{"storage":[{"license":"0.5","uuid":"a"},{"license":1,"uuid":"b"}]}}`), &storages)
+	require.NoError(t, err)
+	assert.Equal(t, []Storage{{License: 0.5, UUID: "a"}, {License: 1, UUID: "b"}}, storages.Storages)
+
+	details := StorageDetails{}
+	err = json.Unmarshal([]byte(`{"storage":{"license":"1.5","uuid":"a","servers":{"server":["s"]}}}`), &details)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, details.License)
+	assert.Equal(t, "a", details.UUID)
+	assert.Equal(t, ServerUUIDSlice{"s"}, details.ServerUUIDs)
+
+	backup := ResizeStorageFilesystemBackup{}
+	err = json.Unmarshal([]byte(`{"resize_backup":{"license":"2","uuid":"b"}}`), &backup)
+	require.NoError(t, err)
+	assert.Equal(t, ResizeStorageFilesystemBackup{License: 2, UUID: "b"}, backup)
+}
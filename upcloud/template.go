@@ -0,0 +1,129 @@
+package upcloud
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Operating system families of public templates
+const (
+	TemplateOSFamilyAlmaLinux  = "almalinux"
+	TemplateOSFamilyCentOS     = "centos"
+	TemplateOSFamilyDebian     = "debian"
+	TemplateOSFamilyRockyLinux = "rockylinux"
+	TemplateOSFamilyUbuntu     = "ubuntu"
+	TemplateOSFamilyWindows    = "windows"
+)
+
+// templateOSFamilies maps template title prefixes to operating system families
+var templateOSFamilies = []struct {
+	prefix string
+	family string
+}{
+	{"almalinux", TemplateOSFamilyAlmaLinux},
+	{"centos", TemplateOSFamilyCentOS},
+	{"debian", TemplateOSFamilyDebian},
+	{"rocky linux", TemplateOSFamilyRockyLinux},
+	{"ubuntu", TemplateOSFamilyUbuntu},
+	{"windows", TemplateOSFamilyWindows},
+}
+
+var templateOSVersionRegexp = regexp.MustCompile(`\b\d+(\.\d+)*\b`)
+
+// TemplateOS represents the operating system of a template
+type TemplateOS struct {
+	Family  string
+	Version string
+}
+
+// IsTemplate returns true if the storage is a template
+func (s Storage) IsTemplate() bool {
+	return s.Type == StorageTypeTemplate
+}
+
+// IsCloudInitTemplate returns true if the storage is a template that requires cloud-init user data or SSH keys when
+// deploying a server
+func (s Storage) IsCloudInitTemplate() bool {
+	return s.IsTemplate() && s.TemplateType == StorageTemplateTypeCloudInit
+}
+
+// TemplateOS returns the operating system of a public template. The API does not return operating system details, so
+// they are parsed from the template title, e.g. "Ubuntu Server 24.04 LTS (Noble Numbat)". Second return value is false
+// if the storage is not a template or the operating system is not recognised.
+func (s Storage) TemplateOS() (TemplateOS, bool) {
+	if !s.IsTemplate() {
+		return TemplateOS{}, false
+	}
+	title := strings.ToLower(s.Title)
+	for _, f := range templateOSFamilies {
+		if strings.HasPrefix(title, f.prefix) {
+			return TemplateOS{
+				Family:  f.family,
+				Version: templateOSVersionRegexp.FindString(title[len(f.prefix):]),
+			}, true
+		}
+	}
+	return TemplateOS{}, false
+}
+
+// TemplateFilter describes the requirements for selecting a template. Zero values match all templates.
+type TemplateFilter struct {
+	// Operating system family, e.g. TemplateOSFamilyUbuntu
+	OSFamily string
+	// Operating system version or version prefix, e.g. "24.04" or "12"
+	OSVersion string
+	// Template type, e.g. StorageTemplateTypeCloudInit
+	TemplateType string
+	// Zone where the server is deployed. Public templates are available in all zones.
+	Zone string
+	// Plan of the server. Template must fit in the storage included in the plan.
+	Plan *Plan
+}
+
+// Matches returns true if the template meets the requirements of the filter
+func (f TemplateFilter) Matches(s Storage) bool {
+	if !s.IsTemplate() {
+		return false
+	}
+	if f.TemplateType != "" && s.TemplateType != f.TemplateType {
+		return false
+	}
+	if f.Zone != "" && s.Access != StorageAccessPublic && s.Zone != f.Zone {
+		return false
+	}
+	// Template size is the minimum size of a storage cloned from it
+	if f.Plan != nil && f.Plan.StorageSize > 0 && s.Size > f.Plan.StorageSize {
+		return false
+	}
+	if f.OSFamily != "" || f.OSVersion != "" {
+		os, ok := s.TemplateOS()
+		if !ok || (f.OSFamily != "" && os.Family != f.OSFamily) {
+			return false
+		}
+		if f.OSVersion != "" && os.Version != f.OSVersion && !strings.HasPrefix(os.Version, f.OSVersion+".") {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectTemplate returns the template that meets the requirements of the filter. If several templates match, the one
+// with the latest operating system version is returned.
+func SelectTemplate(templates []Storage, filter TemplateFilter) (Storage, error) {
+	var selected *Storage
+	var selectedVersion string
+	for i := range templates {
+		if !filter.Matches(templates[i]) {
+			continue
+		}
+		os, _ := templates[i].TemplateOS()
+		if selected == nil || compareVersions(os.Version, selectedVersion) > 0 {
+			selected, selectedVersion = &templates[i], os.Version
+		}
+	}
+	if selected == nil {
+		return Storage{}, errors.New("no template matches the requirements")
+	}
+	return *selected, nil
+}
@@ -0,0 +1,57 @@
+package upcloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageTemplateOS(t *testing.T) {
+	t.Parallel()
+
+	for title, want := range map[string]TemplateOS{
+		"Ubuntu Server 24.04 LTS (Noble Numbat)": {Family: TemplateOSFamilyUbuntu, Version: "24.04"},
+		"Debian GNU/Linux 12 (Bookworm)":         {Family: TemplateOSFamilyDebian, Version: "12"},
+		"Rocky Linux 9":                          {Family: TemplateOSFamilyRockyLinux, Version: "9"},
+		"AlmaLinux 8":                            {Family: TemplateOSFamilyAlmaLinux, Version: "8"},
+		"CentOS Stream 9":                        {Family: TemplateOSFamilyCentOS, Version: "9"},
+		"Windows Server 2022 Standard":           {Family: TemplateOSFamilyWindows, Version: "2022"},
+	} {
+		os, ok := Storage{Type: StorageTypeTemplate, Title: title}.TemplateOS()
+		assert.True(t, ok, title)
+		assert.Equal(t, want, os, title)
+	}
+
+	_, ok := Storage{Type: StorageTypeTemplate, Title: "my-golden-image"}.TemplateOS()
+	assert.False(t, ok)
+	_, ok = Storage{Type: StorageTypeDisk, Title: "Ubuntu Server 24.04 LTS (Noble Numbat)"}.TemplateOS()
+	assert.False(t, ok)
+}
+
+func TestSelectTemplate(t *testing.T) {
+	t.Parallel()
+
+	templates := []Storage{
+		{UUID: "ubuntu-2204", Type: StorageTypeTemplate, Access: StorageAccessPublic, TemplateType: StorageTemplateTypeCloudInit, Size: 5, Title: "Ubuntu Server 22.04 LTS (Jammy Jellyfish)"},
+		{UUID: "ubuntu-2404", Type: StorageTypeTemplate, Access: StorageAccessPublic, TemplateType: StorageTemplateTypeCloudInit, Size: 5, Title: "Ubuntu Server 24.04 LTS (Noble Numbat)"},
+		{UUID: "debian-12", Type: StorageTypeTemplate, Access: StorageAccessPublic, TemplateType: StorageTemplateTypeNative, Size: 5, Title: "Debian GNU/Linux 12 (Bookworm)"},
+		{UUID: "windows-2022", Type: StorageTypeTemplate, Access: StorageAccessPublic, TemplateType: StorageTemplateTypeNative, Size: 30, Title: "Windows Server 2022 Standard"},
+		{UUID: "golden-image", Type: StorageTypeTemplate, Access: StorageAccessPrivate, TemplateType: StorageTemplateTypeNative, Size: 10, Title: "golden-image", Zone: "fi-hel1"},
+	}
+	selectUUID := func(f TemplateFilter) string {
+		s, err := SelectTemplate(templates, f)
+		require.NoError(t, err)
+		return s.UUID
+	}
+
+	assert.Equal(t, "ubuntu-2404", selectUUID(TemplateFilter{OSFamily: TemplateOSFamilyUbuntu}))
+	assert.Equal(t, "ubuntu-2204", selectUUID(TemplateFilter{OSFamily: TemplateOSFamilyUbuntu, OSVersion: "22"}))
+	assert.Equal(t, "debian-12", selectUUID(TemplateFilter{TemplateType: StorageTemplateTypeNative, Plan: &Plan{StorageSize: 25}}))
+	assert.Equal(t, "windows-2022", selectUUID(TemplateFilter{OSFamily: TemplateOSFamilyWindows, Zone: "de-fra1"}))
+	assert.True(t, TemplateFilter{Zone: "fi-hel1"}.Matches(templates[4]))
+	assert.False(t, TemplateFilter{Zone: "de-fra1"}.Matches(templates[4]))
+
+	_, err := SelectTemplate(templates, TemplateFilter{OSFamily: TemplateOSFamilyWindows, Plan: &Plan{StorageSize: 25}})
+	assert.EqualError(t, err, "no template matches the requirements")
+}
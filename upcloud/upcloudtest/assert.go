@@ -0,0 +1,56 @@
+package upcloudtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+// AssertServerState fails the test if the server is not in the expected state
+func AssertServerState(t testing.TB, server *upcloud.ServerDetails, state string) bool {
+	t.Helper()
+	if server == nil {
+		t.Errorf("expected server in state %q, got nil", state)
+		return false
+	}
+	if server.State != state {
+		t.Errorf("expected server %s to be in state %q, got %q", server.UUID, state, server.State)
+		return false
+	}
+	return true
+}
+
+// AssertStorageState fails the test if the storage is not in the expected state
+func AssertStorageState(t testing.TB, storage *upcloud.StorageDetails, state string) bool {
+	t.Helper()
+	if storage == nil {
+		t.Errorf("expected storage in state %q, got nil", state)
+		return false
+	}
+	if storage.State != state {
+		t.Errorf("expected storage %s to be in state %q, got %q", storage.UUID, state, storage.State)
+		return false
+	}
+	return true
+}
+
+// AssertProblem fails the test if err is not an *upcloud.Problem with the specified HTTP status and error code.
+// Empty error code matches any code.
+func AssertProblem(t testing.TB, err error, status int, code string) bool {
+	t.Helper()
+	var problem *upcloud.Problem
+	if !errors.As(err, &problem) {
+		t.Errorf("expected *upcloud.Problem error, got %T: %v", err, err)
+		return false
+	}
+	if problem.Status != status {
+		t.Errorf("expected problem with status %d, got %d", status, problem.Status)
+		return false
+	}
+	if code != "" && problem.ErrorCode() != code {
+		t.Errorf("expected problem with error code %q, got %q", code, problem.ErrorCode())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package upcloudtest
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+)
+
+// Names of the API responses included in the corpus
+const (
+	CorpusFirewallRules                       string = "firewall_rules"
+	CorpusGatewayDetails                      string = "gateway_details"
+	CorpusHostDetails                         string = "host_details"
+	CorpusIPAddressDetails                    string = "ip_address_details"
+	CorpusIPAddresses                         string = "ip_addresses"
+	CorpusKubernetesClusterDetails            string = "kubernetes_cluster_details"
+	CorpusLoadBalancerCertificateBundleDetail string = "load_balancer_certificate_bundle_details"
+	CorpusLoadBalancerDetails                 string = "load_balancer_details"
+	CorpusManagedDatabaseDetails              string = "managed_database_details"
+	CorpusManagedObjectStorageDetails         string = "managed_object_storage_details"
+	CorpusNetworkDetails                      string = "network_details"
+	CorpusNetworks                            string = "networks"
+	CorpusPlans                               string = "plans"
+	CorpusPrices                              string = "prices"
+	CorpusRouters                             string = "routers"
+	CorpusServerDetails                       string = "server_details"
+	CorpusServerGroupDetails                  string = "server_group_details"
+	CorpusServerSizes                         string = "server_sizes"
+	CorpusServers                             string = "servers"
+	CorpusStorageDetails                      string = "storage_details"
+	CorpusStorages                            string = "storages"
+	CorpusTags                                string = "tags"
+	CorpusTimeZones                           string = "timezones"
+	CorpusZones                               string = "zones"
+)
+
+// corpus contains sanitized API responses recorded by the SDK integration tests, grouped by API version.
+//
+//go:embed corpus
+var corpus embed.FS
+
+// Corpus returns the named API response recorded from the API version used by the client, see client.APIVersion.
+func Corpus(name string) ([]byte, error) {
+	return CorpusVersion(client.APIVersion, name)
+}
+
+// CorpusVersion returns the named API response recorded from the specified API version.
+func CorpusVersion(apiVersion, name string) ([]byte, error) {
+	b, err := corpus.ReadFile(path.Join("corpus", apiVersion, name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("upcloudtest: response %q not found from API version %s corpus", name, apiVersion)
+	}
+	return b, nil
+}
+
+// MustCorpus is like Corpus but panics if the response does not exist.
+func MustCorpus(name string) []byte {
+	b, err := Corpus(name)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// CorpusNames returns names of the responses available for the specified API version.
+func CorpusNames(apiVersion string) ([]string, error) {
+	entries, err := fs.ReadDir(corpus, path.Join("corpus", apiVersion))
+	if err != nil {
+		return nil, fmt.Errorf("upcloudtest: API version %s corpus not found", apiVersion)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".json"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
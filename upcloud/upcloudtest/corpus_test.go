@@ -0,0 +1,73 @@
+package upcloudtest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpus(t *testing.T) {
+	t.Parallel()
+
+	targets := map[string]func() interface{}{
+		CorpusFirewallRules:                       func() interface{} { return &upcloud.FirewallRules{} },
+		CorpusGatewayDetails:                      func() interface{} { return &upcloud.Gateway{} },
+		CorpusHostDetails:                         func() interface{} { return &upcloud.Host{} },
+		CorpusIPAddressDetails:                    func() interface{} { return &upcloud.IPAddress{} },
+		CorpusIPAddresses:                         func() interface{} { return &upcloud.IPAddresses{} },
+		CorpusKubernetesClusterDetails:            func() interface{} { return &upcloud.KubernetesCluster{} },
+		CorpusLoadBalancerCertificateBundleDetail: func() interface{} { return &upcloud.LoadBalancerCertificateBundle{} },
+		CorpusLoadBalancerDetails:                 func() interface{} { return &upcloud.LoadBalancer{} },
+		CorpusManagedDatabaseDetails:              func() interface{} { return &upcloud.ManagedDatabase{} },
+		CorpusManagedObjectStorageDetails:         func() interface{} { return &upcloud.ManagedObjectStorage{} },
+		CorpusNetworkDetails:                      func() interface{} { return &upcloud.Network{} },
+		CorpusNetworks:                            func() interface{} { return &upcloud.Networks{} },
+		CorpusPlans:                               func() interface{} { return &upcloud.Plans{} },
+		CorpusPrices:                              func() interface{} { return &upcloud.PriceZones{} },
+		CorpusRouters:                             func() interface{} { return &upcloud.Routers{} },
+		CorpusServerDetails:                       func() interface{} { return &upcloud.ServerDetails{} },
+		CorpusServerGroupDetails:                  func() interface{} { return &upcloud.ServerGroup{} },
+		CorpusServerSizes:                         func() interface{} { return &upcloud.ServerConfigurations{} },
+		CorpusServers:                             func() interface{} { return &upcloud.Servers{} },
+		CorpusStorageDetails:                      func() interface{} { return &upcloud.StorageDetails{} },
+		CorpusStorages:                            func() interface{} { return &upcloud.Storages{} },
+		CorpusTags:                                func() interface{} { return &upcloud.Tags{} },
+		CorpusTimeZones:                           func() interface{} { return &upcloud.TimeZones{} },
+		CorpusZones:                               func() interface{} { return &upcloud.Zones{} },
+	}
+
+	names, err := CorpusNames(client.APIVersion)
+	require.NoError(t, err)
+	assert.Len(t, names, len(targets))
+
+	for _, name := range names {
+		target, ok := targets[name]
+		require.True(t, ok, "no decode target for %s", name)
+		v := target()
+		require.NoError(t, json.Unmarshal(MustCorpus(name), v), name)
+		assert.NotEmpty(t, v, name)
+	}
+
+	_, err = Corpus("unknown")
+	assert.Error(t, err)
+	_, err = CorpusNames("0.0")
+	assert.Error(t, err)
+}
+
+func TestCorpusValues(t *testing.T) {
+	t.Parallel()
+
+	details := upcloud.ServerDetails{}
+	require.NoError(t, json.Unmarshal(MustCorpus(CorpusServerDetails), &details))
+	assert.NotEmpty(t, details.UUID)
+	assert.NotEmpty(t, details.IPAddresses)
+	assert.NotEmpty(t, details.StorageDevices)
+
+	zones := upcloud.Zones{}
+	require.NoError(t, json.Unmarshal(MustCorpus(CorpusZones), &zones))
+	assert.NotEmpty(t, zones.Zones)
+}
@@ -0,0 +1,188 @@
+// Package upcloudtest provides helpers for writing unit tests against code that uses the UpCloud SDK.
+package upcloudtest
+
+import (
+	"time"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+)
+
+// Default values used by the fixture builders
+const (
+	DefaultZone        string = "fi-hel1"
+	DefaultPlan        string = "1xCPU-1GB"
+	DefaultServerUUID  string = "00798b85-efdc-41ca-8021-f6ef457b8531"
+	DefaultStorageUUID string = "01f3286c-a5ea-4670-8121-d0b9767d625b"
+	DefaultNetworkUUID string = "03000000-0000-4000-8100-000000000000"
+	DefaultTemplate    string = "01000000-0000-4000-8000-000030240200"
+)
+
+// ServerDetailsOption modifies a server details fixture
+type ServerDetailsOption func(*upcloud.ServerDetails)
+
+// StorageDetailsOption modifies a storage details fixture
+type StorageDetailsOption func(*upcloud.StorageDetails)
+
+// ServerDetailsFixture returns a started server with a public IPv4, public IPv6 and utility IPv4 address and a
+// single boot disk. Options are applied in order after the defaults have been set.
+func ServerDetailsFixture(opts ...ServerDetailsOption) *upcloud.ServerDetails {
+	s := &upcloud.ServerDetails{
+		Server: upcloud.Server{
+			CoreNumber:   1,
+			Hostname:     "server.example.com",
+			MemoryAmount: 1024,
+			Plan:         DefaultPlan,
+			State:        upcloud.ServerStateStarted,
+			Tags:         upcloud.ServerTagSlice{},
+			Title:        "Test server",
+			UUID:         DefaultServerUUID,
+			Zone:         DefaultZone,
+		},
+		BootOrder: "disk",
+		Firewall:  "off",
+		Host:      7653311107,
+		IPAddresses: upcloud.IPAddressSlice{
+			{
+				Access:  upcloud.IPAddressAccessPublic,
+				Address: "94.237.0.207",
+				Family:  upcloud.IPAddressFamilyIPv4,
+			},
+			{
+				Access:  upcloud.IPAddressAccessPublic,
+				Address: "2a04:3540:1000:310:c05c:a3ff:fe36:4e61",
+				Family:  upcloud.IPAddressFamilyIPv6,
+			},
+			{
+				Access:  upcloud.IPAddressAccessUtility,
+				Address: "10.1.0.4",
+				Family:  upcloud.IPAddressFamilyIPv4,
+			},
+		},
+		Labels:   upcloud.LabelSlice{},
+		Metadata: upcloud.False,
+		NICModel: upcloud.NICModelVirtio,
+		StorageDevices: upcloud.ServerStorageDeviceSlice{
+			{
+				Address:    "virtio:0",
+				PartOfPlan: "yes",
+				UUID:       DefaultStorageUUID,
+				Size:       25,
+				Tier:       upcloud.StorageTierMaxIOPS,
+				Title:      "Test server disk",
+				Type:       upcloud.StorageTypeDisk,
+				BootDisk:   1,
+			},
+		},
+		Timezone:   "UTC",
+		VideoModel: upcloud.VideoModelCirrus,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithServerUUID sets the UUID of the server fixture
+func WithServerUUID(uuid string) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.UUID = uuid
+	}
+}
+
+// WithServerState sets the state of the server fixture
+func WithServerState(state string) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.State = state
+	}
+}
+
+// WithServerZone sets the zone of the server fixture
+func WithServerZone(zone string) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.Zone = zone
+	}
+}
+
+// WithServerLabels sets the labels of the server fixture
+func WithServerLabels(labels ...upcloud.Label) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.Labels = labels
+	}
+}
+
+// WithServerTags sets the tags of the server fixture
+func WithServerTags(tags ...string) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.Tags = tags
+	}
+}
+
+// WithServerStorageDevices replaces the storage devices of the server fixture
+func WithServerStorageDevices(devices ...upcloud.ServerStorageDevice) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.StorageDevices = devices
+	}
+}
+
+// WithServerIPAddresses replaces the IP addresses of the server fixture
+func WithServerIPAddresses(addresses ...upcloud.IPAddress) ServerDetailsOption {
+	return func(s *upcloud.ServerDetails) {
+		s.IPAddresses = addresses
+	}
+}
+
+// StorageDetailsFixture returns an online private MaxIOPS disk attached to the default server fixture. Options are
+// applied in order after the defaults have been set.
+func StorageDetailsFixture(opts ...StorageDetailsOption) *upcloud.StorageDetails {
+	s := &upcloud.StorageDetails{
+		Storage: upcloud.Storage{
+			Access:     upcloud.StorageAccessPrivate,
+			Encrypted:  upcloud.False,
+			PartOfPlan: "yes",
+			Size:       25,
+			State:      upcloud.StorageStateOnline,
+			Tier:       upcloud.StorageTierMaxIOPS,
+			Title:      "Test server disk",
+			Type:       upcloud.StorageTypeDisk,
+			UUID:       DefaultStorageUUID,
+			Zone:       DefaultZone,
+			Created:    time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		BackupUUIDs: upcloud.BackupUUIDSlice{},
+		ServerUUIDs: upcloud.ServerUUIDSlice{DefaultServerUUID},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithStorageUUID sets the UUID of the storage fixture
+func WithStorageUUID(uuid string) StorageDetailsOption {
+	return func(s *upcloud.StorageDetails) {
+		s.UUID = uuid
+	}
+}
+
+// WithStorageState sets the state of the storage fixture
+func WithStorageState(state string) StorageDetailsOption {
+	return func(s *upcloud.StorageDetails) {
+		s.State = state
+	}
+}
+
+// WithStorageType sets the type of the storage fixture
+func WithStorageType(storageType string) StorageDetailsOption {
+	return func(s *upcloud.StorageDetails) {
+		s.Type = storageType
+	}
+}
+
+// WithStorageServers sets the UUIDs of the servers the storage fixture is attached to
+func WithStorageServers(uuids ...string) StorageDetailsOption {
+	return func(s *upcloud.StorageDetails) {
+		s.ServerUUIDs = uuids
+	}
+}
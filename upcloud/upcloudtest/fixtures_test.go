@@ -0,0 +1,82 @@
+package upcloudtest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerDetailsFixture(t *testing.T) {
+	t.Parallel()
+
+	s := ServerDetailsFixture()
+	assert.Equal(t, DefaultServerUUID, s.UUID)
+	assert.Equal(t, DefaultZone, s.Zone)
+	assert.Len(t, s.IPAddresses, 3)
+	assert.NotNil(t, s.StorageDevice(DefaultStorageUUID))
+	AssertServerState(t, s, upcloud.ServerStateStarted)
+
+	s = ServerDetailsFixture(
+		WithServerUUID("uuid"),
+		WithServerState(upcloud.ServerStateStopped),
+		WithServerZone("de-fra1"),
+		WithServerTags("DEV"),
+		WithServerLabels(upcloud.Label{Key: "env", Value: "test"}),
+	)
+	assert.Equal(t, "uuid", s.UUID)
+	assert.Equal(t, "de-fra1", s.Zone)
+	assert.Equal(t, upcloud.ServerTagSlice{"DEV"}, s.Tags)
+	assert.Equal(t, upcloud.LabelSlice{{Key: "env", Value: "test"}}, s.Labels)
+	AssertServerState(t, s, upcloud.ServerStateStopped)
+
+	// Fixtures must not share state
+	s.IPAddresses[0].Address = "127.0.0.1"
+	assert.NotEqual(t, "127.0.0.1", ServerDetailsFixture().IPAddresses[0].Address)
+}
+
+func TestStorageDetailsFixture(t *testing.T) {
+	t.Parallel()
+
+	s := StorageDetailsFixture()
+	assert.Equal(t, DefaultStorageUUID, s.UUID)
+	assert.Equal(t, upcloud.ServerUUIDSlice{DefaultServerUUID}, s.ServerUUIDs)
+	AssertStorageState(t, s, upcloud.StorageStateOnline)
+
+	s = StorageDetailsFixture(
+		WithStorageUUID("uuid"),
+		WithStorageType(upcloud.StorageTypeBackup),
+		WithStorageState(upcloud.StorageStateMaintenance),
+		WithStorageServers(),
+	)
+	assert.Equal(t, "uuid", s.UUID)
+	assert.Equal(t, upcloud.StorageTypeBackup, s.Type)
+	assert.Empty(t, s.ServerUUIDs)
+	AssertStorageState(t, s, upcloud.StorageStateMaintenance)
+}
+
+func TestAssertProblem(t *testing.T) {
+	t.Parallel()
+
+	err := &upcloud.Problem{Type: upcloud.ErrCodeServerNotFound, Status: http.StatusNotFound}
+	assert.True(t, AssertProblem(t, err, http.StatusNotFound, upcloud.ErrCodeServerNotFound))
+	assert.True(t, AssertProblem(t, err, http.StatusNotFound, ""))
+
+	mock := &recordingTB{TB: t}
+	assert.False(t, AssertProblem(mock, errors.New("error"), http.StatusNotFound, ""))
+	assert.False(t, AssertProblem(mock, err, http.StatusConflict, ""))
+	assert.Len(t, mock.errors, 2)
+}
+
+// recordingTB records errors instead of failing the test
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
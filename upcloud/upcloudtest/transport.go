@@ -0,0 +1,193 @@
+package upcloudtest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+)
+
+// MockResponse is a canned response returned by MockTransport for requests matching Method and Path.
+type MockResponse struct {
+	Method string
+	// Path is the API path without API version prefix, e.g. "/server/uuid". If path contains a query string,
+	// query is matched as well; otherwise query parameters of the request are ignored.
+	Path   string
+	Status int
+	Body   []byte
+	Header http.Header
+	// Times limits how many times the response is returned. Zero means unlimited.
+	Times int
+
+	calls int
+}
+
+// Reply sets the status and body of the response
+func (r *MockResponse) Reply(status int, body string) *MockResponse {
+	r.Status = status
+	r.Body = []byte(body)
+	return r
+}
+
+// ReplyError sets the response to an API error with the specified status and error code
+func (r *MockResponse) ReplyError(status int, code, message string) *MockResponse {
+	r.Status = status
+	r.Body = []byte(fmt.Sprintf(`{"error":{"error_code":%q,"error_message":%q}}`, code, message))
+	return r
+}
+
+// ReplyCorpus sets the response to 200 OK with the named response from the corpus as body, see Corpus
+func (r *MockResponse) ReplyCorpus(name string) *MockResponse {
+	return r.Reply(http.StatusOK, string(MustCorpus(name)))
+}
+
+// Once limits the response to be returned only once. Subsequent requests fall through to the next matching response.
+func (r *MockResponse) Once() *MockResponse {
+	r.Times = 1
+	return r
+}
+
+func (r *MockResponse) matches(req *http.Request) bool {
+	if r.Method != req.Method {
+		return false
+	}
+	if r.Times > 0 && r.calls >= r.Times {
+		return false
+	}
+	path := strings.TrimPrefix(req.URL.Path, "/"+client.APIVersion)
+	if p, q, ok := strings.Cut(r.Path, "?"); ok {
+		return p == path && q == req.URL.RawQuery
+	}
+	return r.Path == path
+}
+
+// MockCall is a request recorded by MockTransport
+type MockCall struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// MockTransport is a http.RoundTripper that returns canned responses for requests matched by method and path,
+// and records all requests for later verification. Responses are matched in the order they were registered.
+type MockTransport struct {
+	mu        sync.Mutex
+	responses []*MockResponse
+	calls     []MockCall
+}
+
+// NewMockTransport returns a new mock transport without any responses
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// On registers a new response for the method and path. By default the response is 200 OK with an empty body.
+func (m *MockTransport) On(method, path string) *MockResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := &MockResponse{Method: method, Path: path, Status: http.StatusOK}
+	m.responses = append(m.responses, r)
+	return r
+}
+
+// RoundTrip implements http.RoundTripper
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		body = b
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, MockCall{
+		Method: req.Method,
+		Path:   strings.TrimPrefix(req.URL.Path, "/"+client.APIVersion),
+		Query:  req.URL.RawQuery,
+		Body:   body,
+	})
+
+	for _, r := range m.responses {
+		if !r.matches(req) {
+			continue
+		}
+		r.calls++
+
+		header := r.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", "application/json")
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+			StatusCode:    r.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(r.Body)),
+			ContentLength: int64(len(r.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("upcloudtest: no mock response for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// HTTPClient returns a HTTP client that uses the mock transport. Pass it to client.New with client.WithHTTPClient.
+func (m *MockTransport) HTTPClient() *http.Client {
+	return &http.Client{Transport: m}
+}
+
+// Calls returns all recorded requests
+func (m *MockTransport) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MockCall(nil), m.calls...)
+}
+
+// Called returns number of recorded requests with the specified method and path
+func (m *MockTransport) Called(method, path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int
+	for _, c := range m.calls {
+		if c.Method == method && c.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertExpectations fails the test if any of the registered responses was not used
+func (m *MockTransport) AssertExpectations(t testing.TB) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, r := range m.responses {
+		if r.calls == 0 {
+			t.Errorf("expected %s %s to be called", r.Method, r.Path)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,63 @@
+package upcloudtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/UpCloudLtd/upcloud-go-api/v8/upcloud/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockTransport(t *testing.T) {
+	t.Parallel()
+
+	m := NewMockTransport()
+	m.On(http.MethodGet, "/server").Reply(http.StatusOK, `{"servers":{"server":[]}}`)
+	m.On(http.MethodGet, "/storage?label=env%3Dtest").Reply(http.StatusOK, `{"storages":{"storage":[]}}`)
+	m.On(http.MethodDelete, "/server/uuid").Once()
+	m.On(http.MethodDelete, "/server/uuid").ReplyError(http.StatusNotFound, "SERVER_NOT_FOUND", "The server does not exist.")
+
+	c := client.New("user", "pass", client.WithHTTPClient(m.HTTPClient()))
+	ctx := context.Background()
+
+	body, err := c.Get(ctx, "/server")
+	require.NoError(t, err)
+	assert.Equal(t, `{"servers":{"server":[]}}`, string(body))
+
+	_, err = c.Get(ctx, "/storage?label=env%3Dtest")
+	require.NoError(t, err)
+
+	_, err = c.Get(ctx, "/storage?label=env%3Dprod")
+	assert.Error(t, err)
+
+	_, err = c.Delete(ctx, "/server/uuid")
+	require.NoError(t, err)
+
+	_, err = c.Delete(ctx, "/server/uuid")
+	var clientErr *client.Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusNotFound, clientErr.ErrorCode)
+
+	_, err = c.Post(ctx, "/server", []byte(`{}`))
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, m.Called(http.MethodGet, "/server"))
+	assert.Equal(t, 2, m.Called(http.MethodDelete, "/server/uuid"))
+	calls := m.Calls()
+	require.Len(t, calls, 6)
+	assert.Equal(t, "label=env%3Dprod", calls[2].Query)
+	assert.Equal(t, []byte(`{}`), calls[5].Body)
+	assert.True(t, m.AssertExpectations(t))
+
+	m.On(http.MethodGet, "/zone").ReplyCorpus(CorpusZones)
+	body, err = c.Get(ctx, "/zone")
+	require.NoError(t, err)
+	assert.Equal(t, MustCorpus(CorpusZones), body)
+
+	m.On(http.MethodGet, "/plan")
+	mock := &recordingTB{TB: t}
+	assert.False(t, m.AssertExpectations(mock))
+	assert.Len(t, mock.errors, 1)
+}
@@ -2,6 +2,9 @@ package upcloud
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -76,6 +79,101 @@ func FromBool(v bool) Boolean {
 	return False
 }
 
+// FlexibleInt is an integer that can be unmarshaled from both JSON numbers and numeric strings.
+// Empty string and null are unmarshaled as zero.
+type FlexibleInt int
+
+// UnmarshalJSON accepts both JSON numbers and numeric strings.
+func (i *FlexibleInt) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if str == "null" {
+		return nil
+	}
+	if strings.HasPrefix(str, `"`) {
+		if err := json.Unmarshal(b, &str); err != nil {
+			return err
+		}
+		if str == "" {
+			*i = 0
+			return nil
+		}
+	}
+
+	v, err := strconv.Atoi(str)
+	if err != nil {
+		return fmt.Errorf("invalid integer value %s", b)
+	}
+	*i = FlexibleInt(v)
+	return nil
+}
+
+// Int returns the value as int
+func (i FlexibleInt) Int() int {
+	return int(i)
+}
+
+// Progress is the progress of an ongoing operation as a percentage from 0 to 100. It can be unmarshaled from both
+// JSON numbers and numeric strings.
+type Progress int
+
+// UnmarshalJSON accepts both JSON numbers and numeric strings.
+func (p *Progress) UnmarshalJSON(b []byte) error {
+	var v FlexibleInt
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = Progress(v)
+	return nil
+}
+
+// Int returns the progress percentage as int
+func (p Progress) Int() int {
+	return int(p)
+}
+
+// Complete returns true if the progress has reached 100 percent
+func (p Progress) Complete() bool {
+	return p >= 100
+}
+
+// License is the license price of a resource in credits per hour. It can be unmarshaled from both JSON numbers and
+// numeric strings.
+type License float64
+
+// UnmarshalJSON accepts both JSON numbers and numeric strings.
+func (l *License) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if str == "null" {
+		return nil
+	}
+	if strings.HasPrefix(str, `"`) {
+		if err := json.Unmarshal(b, &str); err != nil {
+			return err
+		}
+		if str == "" {
+			*l = 0
+			return nil
+		}
+	}
+
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fmt.Errorf("invalid license value %s", b)
+	}
+	*l = License(v)
+	return nil
+}
+
+// Float64 returns the license price as float64
+func (l License) Float64() float64 {
+	return float64(l)
+}
+
+// Free returns true if the resource has no license costs
+func (l License) Free() bool {
+	return l == 0
+}
+
 func StringPtr(v string) *string {
 	return &v
 }
@@ -169,3 +169,44 @@ func TestBoolean_Empty(t *testing.T) {
 	var b Boolean
 	assert.True(t, b.Empty())
 }
+
+func TestFlexibleInt(t *testing.T) {
+	for input, want := range map[string]FlexibleInt{
+		`{"value": 2}`:      2,
+		`{"value": "2048"}`: 2048,
+		`{"value": ""}`:     0,
+		`{"value": null}`:   0,
+		`{}`:                0,
+	} {
+		v := struct {
+			Value FlexibleInt `json:"value"`
+		}{}
+		err := json.Unmarshal([]byte(input), &v)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, v.Value, input)
+	}
+
+	var v FlexibleInt
+	assert.Error(t, json.Unmarshal([]byte(`"two"`), &v))
+	assert.Error(t, json.Unmarshal([]byte(`1.5`), &v))
+	assert.Equal(t, 3, FlexibleInt(3).Int())
+}
+
+func TestProgressAndLicense(t *testing.T) {
+	v := struct {
+		Progress Progress `json:"progress"`
+		License  License  `json:"license"`
+	}{}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"progress": "95", "license": "0.5"}`), &v))
+	assert.Equal(t, 95, v.Progress.Int())
+	assert.False(t, v.Progress.Complete())
+	assert.Equal(t, 0.5, v.License.Float64())
+	assert.False(t, v.License.Free())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"progress": 100, "license": 0}`), &v))
+	assert.True(t, v.Progress.Complete())
+	assert.True(t, v.License.Free())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"license": "free"}`), &v))
+}
@@ -1,6 +1,9 @@
 package upcloud
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"slices"
+)
 
 // Zones represents a /zone response
 type Zones struct {
@@ -34,3 +37,106 @@ type Zone struct {
 	Public      Boolean `json:"public"`
 	ParentZone  string  `json:"parent_zone,omitempty"`
 }
+
+// IsPrivate returns true if the zone is a private cloud zone
+func (z Zone) IsPrivate() bool {
+	return !z.Public.Bool()
+}
+
+// PublicZones returns the public zones
+func (s *Zones) PublicZones() []Zone {
+	zones := make([]Zone, 0)
+	for _, z := range s.Zones {
+		if !z.IsPrivate() {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// PrivateZones returns the private cloud zones
+func (s *Zones) PrivateZones() []Zone {
+	zones := make([]Zone, 0)
+	for _, z := range s.Zones {
+		if z.IsPrivate() {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// ChildZones returns the private cloud zones whose parent zone is the specified zone
+func (s *Zones) ChildZones(parentZone string) []Zone {
+	zones := make([]Zone, 0)
+	for _, z := range s.Zones {
+		if z.ParentZone == parentZone {
+			zones = append(zones, z)
+		}
+	}
+	return zones
+}
+
+// Zone features that can be reported in ZoneCapabilities. Values match the price item names used to detect them.
+const (
+	ZoneFeatureFirewall       = "firewall"
+	ZoneFeatureIPv4Address    = "ipv4_address"
+	ZoneFeatureIPv6Address    = "ipv6_address"
+	ZoneFeaturePrivateNetwork = "network_private_vlan"
+	ZoneFeatureCustomPlan     = "server_core"
+	ZoneFeatureBackup         = "storage_backup"
+)
+
+// ZoneCapabilities describes which plans, storage tiers and features are available in a zone.
+type ZoneCapabilities struct {
+	Zone         Zone
+	Plans        []string
+	StorageTiers []string
+	Features     []string
+}
+
+// HasPlan returns true if the specified plan is available in the zone
+func (z *ZoneCapabilities) HasPlan(plan string) bool {
+	return slices.Contains(z.Plans, plan)
+}
+
+// HasStorageTier returns true if the specified storage tier is available in the zone
+func (z *ZoneCapabilities) HasStorageTier(tier string) bool {
+	return slices.Contains(z.StorageTiers, tier)
+}
+
+// HasFeature returns true if the specified feature, e.g. ZoneFeatureFirewall, is available in the zone
+func (z *ZoneCapabilities) HasFeature(feature string) bool {
+	return slices.Contains(z.Features, feature)
+}
+
+// NewZoneCapabilities combines zone and its price listing into a capability report.
+// Plans not included in the plan catalog are omitted, plans argument can be nil to skip this check.
+func NewZoneCapabilities(zone Zone, prices PriceZone, plans []Plan) ZoneCapabilities {
+	c := ZoneCapabilities{
+		Zone:         zone,
+		Plans:        make([]string, 0),
+		StorageTiers: prices.StorageTiers(),
+		Features:     make([]string, 0),
+	}
+
+	for _, name := range prices.Plans() {
+		if plans == nil || slices.ContainsFunc(plans, func(p Plan) bool { return p.Name == name }) {
+			c.Plans = append(c.Plans, name)
+		}
+	}
+
+	for _, feature := range []string{
+		ZoneFeatureFirewall,
+		ZoneFeatureIPv4Address,
+		ZoneFeatureIPv6Address,
+		ZoneFeaturePrivateNetwork,
+		ZoneFeatureCustomPlan,
+		ZoneFeatureBackup,
+	} {
+		if prices.Has(feature) {
+			c.Features = append(c.Features, feature)
+		}
+	}
+
+	return c
+}
@@ -82,3 +82,18 @@ func TestUnmarshalZones(t *testing.T) {
 		assert.Equal(t, d.ID, z.ID)
 	}
 }
+
+func TestZonesPublicAndPrivate(t *testing.T) {
+	zones := Zones{Zones: []Zone{
+		{ID: "fi-hel1", Public: True},
+		{ID: "fi-hel2", Public: True},
+		{ID: "fi-hel1-private", Public: False, ParentZone: "fi-hel1"},
+	}}
+
+	assert.False(t, zones.Zones[0].IsPrivate())
+	assert.True(t, zones.Zones[2].IsPrivate())
+	assert.Equal(t, zones.Zones[:2], zones.PublicZones())
+	assert.Equal(t, zones.Zones[2:], zones.PrivateZones())
+	assert.Equal(t, zones.Zones[2:], zones.ChildZones("fi-hel1"))
+	assert.Empty(t, zones.ChildZones("fi-hel2"))
+}